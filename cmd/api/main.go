@@ -10,7 +10,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/dya-andrade/cat-api/internal/blob"
 	ihttp "github.com/dya-andrade/cat-api/internal/http"
+	"github.com/dya-andrade/cat-api/internal/outbox"
 	"github.com/dya-andrade/cat-api/internal/service"
 	"github.com/dya-andrade/cat-api/internal/storage"
 	"github.com/dya-andrade/cat-api/internal/worker"
@@ -42,11 +44,129 @@ func main() {
 	wp.Start()          // Inicia os workers
 	defer wp.Shutdown() // Garante que os workers serão finalizados ao sair
 
-	// Cria o serviço de gatos, passando repositório, pool de workers e timeout
-	catSvc := service.NewCatService(catRepo, wp, cfg.RequestTimeout)
+	// Catálogo de raças: usado na listagem/autocomplete e para normalizar o breed dos gatos
+	breedRepo := storage.NewBreedRepository(pg.Pool)
+	breedSvc := service.NewBreedService(breedRepo, cfg.RequestTimeout)
+
+	// Esquema de atributos personalizados por tenant: gerenciado em /attributes e conferido na escrita dos gatos
+	attrRepo := storage.NewAttributeRepository(pg.Pool)
+	attrSvc := service.NewAttributeService(attrRepo, cfg.RequestTimeout)
+
+	// Miniaturas têm um pool próprio: a importação cria gatos de dentro de uma tarefa de wp e
+	// não pode disputar uma vaga na fila do mesmo pool
+	thumbs := worker.NewPoolQueue(int(cfg.WorkerConcurrency), int(cfg.ThumbnailQueue))
+	thumbs.Start()
+	defer thumbs.Shutdown()
+
+	// Cria o serviço de gatos, passando repositório, pool das miniaturas, timeout, limite de lote, o catálogo de raças e o esquema de atributos
+	catSvc := service.NewCatService(catRepo, thumbs, cfg.RequestTimeout, int(cfg.BatchMaxOps), breedRepo, cfg.BreedPolicy, attrRepo)
+	// Gatos sem miniatura (fila cheia, processo reiniciado) são agendados de novo periodicamente
+	waitThumbs := worker.Every(ctx, cfg.ThumbnailSweep, func(ctx context.Context) {
+		if _, err := catSvc.SweepThumbnails(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("thumbnail: sweep: %v", err)
+		}
+	})
+	tagSvc := service.NewTagService(storage.NewTagRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Blob store local para uploads, relatórios e arquivos exportados
+	blobs, err := blob.NewLocal(cfg.BlobDir)
+	if err != nil {
+		log.Fatalf("blob store error: %v", err)
+	}
+
+	// Jobs em background (importação, exportação) rodam no mesmo worker pool
+	jobSvc := service.NewJobService(storage.NewJobRepository(pg.Pool), blobs, wp, cfg.JobTimeout)
+	importSvc := service.NewImportService(catSvc, jobSvc, blobs)
+	exportSvc := service.NewExportService(catRepo, jobSvc, blobs)
+
+	// Tutores e tutela dos gatos
+	ownerSvc := service.NewOwnerService(storage.NewOwnerRepository(pg.Pool), cfg.RequestTimeout)
+	adoptionSvc := service.NewAdoptionService(storage.NewAdoptionRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Busca por microchip (achados e perdidos); toda busca fica registrada
+	chipSvc := service.NewMicrochipService(storage.NewMicrochipRepository(pg.Pool), cfg.RequestTimeout)
+	pedigreeSvc := service.NewPedigreeService(storage.NewPedigreeRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Notas dos voluntários e linha do tempo de cada gato
+	noteSvc := service.NewNoteService(storage.NewNoteRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Registro de auditoria (gravado por trigger a cada mudança nos gatos)
+	auditSvc := service.NewAuditService(storage.NewAuditRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Histórico de peso (medições e alertas são mantidos por triggers no banco)
+	weightSvc := service.NewWeightService(storage.NewWeightRepository(pg.Pool), cfg.RequestTimeout)
+
+	// Prontuário; o relatório de vacinas a vencer é recalculado periodicamente como job
+	healthSvc := service.NewHealthService(storage.NewHealthRepository(pg.Pool), jobSvc, cfg.RequestTimeout, int(cfg.VaccinesDueDays))
+	waitScheduler := worker.Every(ctx, cfg.VaccinesDueEvery, func(ctx context.Context) {
+		if _, err := healthSvc.ScheduleVaccinationsDue(ctx); err != nil {
+			log.Printf("vaccinations due: schedule: %v", err)
+		}
+	})
+
+	// Webhooks: a outbox cria as entregas de cada assinatura; o dispatcher as envia por um pool
+	// próprio, com um worker por entrega da rodada (um envio não espera atrás de uma importação)
+	webhookRepo := storage.NewWebhookRepository(pg.Pool)
+	webhookSvc := service.NewWebhookService(webhookRepo, cfg.RequestTimeout)
+	sends := worker.NewPoolQueue(int(cfg.WebhookBatch), int(cfg.WebhookBatch))
+	sends.Start()
+	defer sends.Shutdown()
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, sends, cfg.WebhookTimeout, int(cfg.WebhookBatch), int(cfg.WebhookAttempts), int(cfg.WebhookFailLimit))
+	waitDispatcher := worker.Every(ctx, cfg.WebhookEvery, dispatcher.Run)
+
+	// Relay da outbox: entrega os eventos dos gatos (gravados na mesma transação das mudanças) aos destinos configurados
+	outboxRepo := storage.NewOutboxRepository(pg.Pool)
+	sinks := []outbox.Sink{dispatcher}
+	if cfg.OutboxWebhookURL != "" {
+		sinks = append(sinks, outbox.NewWebhook(cfg.OutboxWebhookURL))
+	}
+	if cfg.OutboxFile != "" {
+		eventFile, err := outbox.NewFile(cfg.OutboxFile)
+		if err != nil {
+			log.Fatalf("outbox file error: %v", err)
+		}
+		defer eventFile.Close()
+		sinks = append(sinks, eventFile)
+	}
+
+	// Stream SSE (GET /cats/stream): cada réplica escuta o NOTIFY da outbox (LISTEN) e repassa os
+	// eventos aos seus clientes; Every reconecta se a conexão cair. Sem OUTBOX_CHANNEL, fica desligado.
+	var (
+		streamHub  *service.StreamHub
+		stream     service.StreamService // continua nil (e não um *StreamHub nil) com o stream desligado
+		waitStream = func() {}
+	)
+	if cfg.OutboxChannel != "" {
+		notify := outbox.NewNotify(outboxRepo, cfg.OutboxChannel)
+		sinks = append(sinks, notify)
+		streamHub = service.NewStreamHub(outboxRepo, cfg.OutboxChannel, notify.Name())
+		stream = streamHub
+		waitStream = worker.Every(ctx, time.Second, streamHub.Run)
+	}
+
+	relay := outbox.NewRelay(outboxRepo, sinks, int(cfg.OutboxBatch), cfg.OutboxRetention)
+	waitRelay := worker.Every(ctx, cfg.OutboxEvery, relay.Run)
 
 	// Cria o roteador HTTP e configura o servidor
-	router := ihttp.NewRouter(catSvc)
+	router := ihttp.NewRouter(cfg, ihttp.Services{
+		Cats:     catSvc,
+		Jobs:     jobSvc,
+		Imports:  importSvc,
+		Exports:  exportSvc,
+		Owners:   ownerSvc,
+		Adoption: adoptionSvc,
+		Health:   healthSvc,
+		Weights:  weightSvc,
+		Breeds:   breedSvc,
+		Chips:    chipSvc,
+		Pedigree: pedigreeSvc,
+		Tags:     tagSvc,
+		Attrs:    attrSvc,
+		Notes:    noteSvc,
+		Audit:    auditSvc,
+		Webhooks: webhookSvc,
+		Stream:   stream,
+	})
 	srv := &http.Server{
 		Addr:         cfg.AppAddr,      // Endereço e porta do servidor
 		Handler:      router,           // Handler das rotas
@@ -54,6 +174,10 @@ func main() {
 		WriteTimeout: 15 * time.Second, // Timeout para escrita da resposta
 		IdleTimeout:  60 * time.Second, // Timeout para conexões ociosas
 	}
+	// As conexões do stream não terminam sozinhas: o Shutdown as encerra antes de esperar as requisições
+	if streamHub != nil {
+		srv.RegisterOnShutdown(streamHub.Close)
+	}
 
 	// Inicia o servidor HTTP em uma goroutine e aguarda erro ou sinal de shutdown
 	errCh := make(chan error, 1)
@@ -79,8 +203,17 @@ func main() {
 		log.Printf("graceful shutdown error: %v", err)
 	}
 
-	// Finaliza o pool de workers (drena fila de tarefas)
+	// Para o agendador (cancela ctx também no caso de erro do servidor), espera-o terminar
+	// e finaliza o pool de workers (drena fila de tarefas)
+	stop()
+	waitScheduler()
+	waitRelay()
+	waitDispatcher()
+	waitStream()
+	waitThumbs()
 	wp.Shutdown()
+	sends.Shutdown()  // depois de waitDispatcher: Run não agenda mais envios
+	thumbs.Shutdown() // depois de wp: uma importação ainda pode agendar miniaturas
 	log.Println("bye!")
 	_ = os.Stderr // Evita erro de import não usado em alguns ambientes
 }
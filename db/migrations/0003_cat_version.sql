@@ -0,0 +1,2 @@
+-- Controle de concorrência otimista: cada UPDATE incrementa a versão do registro.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
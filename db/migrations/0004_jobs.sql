@@ -0,0 +1,18 @@
+-- Jobs em background (importação, exportação...) e seu progresso.
+CREATE TABLE IF NOT EXISTS jobs (
+    id           BIGSERIAL PRIMARY KEY,
+    kind         TEXT NOT NULL,
+    status       TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    params       JSONB NOT NULL DEFAULT '{}',
+    processed    INT NOT NULL DEFAULT 0,
+    succeeded    INT NOT NULL DEFAULT 0,
+    failed       INT NOT NULL DEFAULT 0,
+    error        TEXT,
+    result_key   TEXT,
+    result_type  TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at   TIMESTAMPTZ,
+    finished_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs(created_at DESC);
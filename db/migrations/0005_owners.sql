@@ -0,0 +1,48 @@
+-- Tutores e o vínculo (muitos-para-muitos) com os gatos.
+CREATE TABLE IF NOT EXISTS owners (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL,
+    email       TEXT NOT NULL,
+    phone       TEXT,
+    address     TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_owners_email ON owners(lower(email));
+CREATE INDEX IF NOT EXISTS idx_owners_created_at ON owners(created_at DESC);
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON owners
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Cada linha é um período de tutela; end_date NULL = vínculo ativo.
+-- Tutores com histórico não podem ser removidos (RESTRICT); remover o gato apaga o histórico dele.
+CREATE TABLE IF NOT EXISTS cat_owners (
+    id          BIGSERIAL PRIMARY KEY,
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    owner_id    BIGINT NOT NULL REFERENCES owners(id) ON DELETE RESTRICT,
+    role        TEXT NOT NULL CHECK (role IN ('primary', 'co-owner', 'foster')),
+    start_date  DATE NOT NULL DEFAULT current_date,
+    end_date    DATE CHECK (end_date IS NULL OR end_date >= start_date),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- no máximo um tutor principal ativo por gato, e um vínculo ativo por par gato/tutor
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cat_owners_primary ON cat_owners(cat_id) WHERE role = 'primary' AND end_date IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cat_owners_active ON cat_owners(cat_id, owner_id) WHERE end_date IS NULL;
+CREATE INDEX IF NOT EXISTS idx_cat_owners_owner ON cat_owners(owner_id);
+
+-- Histórico de transferências da tutela principal.
+CREATE TABLE IF NOT EXISTS cat_transfers (
+    id              BIGSERIAL PRIMARY KEY,
+    cat_id          BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    from_owner_id   BIGINT REFERENCES owners(id) ON DELETE RESTRICT,
+    to_owner_id     BIGINT NOT NULL REFERENCES owners(id) ON DELETE RESTRICT,
+    transferred_on  DATE NOT NULL,
+    reason          TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_transfers_cat ON cat_transfers(cat_id, transferred_on);
@@ -0,0 +1,41 @@
+-- Status de adoção do gato. As transições permitidas são verificadas na aplicação
+-- (domain.CheckTransition); o banco garante apenas que o valor é conhecido.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'available'
+    CHECK (status IN ('available', 'reserved', 'adoption_pending', 'adopted', 'returned', 'not_adoptable'));
+
+CREATE INDEX IF NOT EXISTS idx_cats_status ON cats(status, created_at DESC);
+
+-- Candidaturas de adoção: um tutor (owners) pede para adotar um gato.
+CREATE TABLE IF NOT EXISTS adoption_applications (
+    id               BIGSERIAL PRIMARY KEY,
+    cat_id           BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    owner_id         BIGINT NOT NULL REFERENCES owners(id) ON DELETE RESTRICT,
+    status           TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    message          TEXT,
+    decision_reason  TEXT,
+    decided_at       TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- no máximo uma candidatura pendente por par gato/tutor
+CREATE UNIQUE INDEX IF NOT EXISTS idx_adoption_applications_pending ON adoption_applications(cat_id, owner_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_adoption_applications_created_at ON adoption_applications(created_at DESC);
+
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON adoption_applications
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Histórico de status: toda transição (manual ou por candidatura) gera uma linha.
+CREATE TABLE IF NOT EXISTS cat_status_transitions (
+    id              BIGSERIAL PRIMARY KEY,
+    cat_id          BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    from_status     TEXT NOT NULL,
+    to_status       TEXT NOT NULL,
+    reason          TEXT,
+    application_id  BIGINT REFERENCES adoption_applications(id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_status_transitions_cat ON cat_status_transitions(cat_id, id);
@@ -0,0 +1,84 @@
+-- Prontuário dos gatos. Todos os registros são apagados junto com o gato.
+CREATE TABLE IF NOT EXISTS cat_vaccinations (
+    id               BIGSERIAL PRIMARY KEY,
+    cat_id           BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    vaccine          TEXT NOT NULL,
+    administered_on  DATE NOT NULL,
+    batch            TEXT,
+    next_due_on      DATE CHECK (next_due_on IS NULL OR next_due_on >= administered_on),
+    vet              TEXT,
+    notes            TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_vaccinations_cat ON cat_vaccinations(cat_id, administered_on DESC);
+-- usado pelo relatório de vencimentos (dose mais recente de cada vacina)
+CREATE INDEX IF NOT EXISTS idx_cat_vaccinations_vaccine ON cat_vaccinations(cat_id, lower(vaccine), administered_on DESC);
+
+CREATE TABLE IF NOT EXISTS cat_vet_visits (
+    id          BIGSERIAL PRIMARY KEY,
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    visited_on  DATE NOT NULL,
+    reason      TEXT NOT NULL,
+    clinic      TEXT,
+    vet         TEXT,
+    diagnosis   TEXT,
+    notes       TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_vet_visits_cat ON cat_vet_visits(cat_id, visited_on DESC);
+
+CREATE TABLE IF NOT EXISTS cat_medications (
+    id          BIGSERIAL PRIMARY KEY,
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    dosage      TEXT,
+    frequency   TEXT,
+    start_date  DATE NOT NULL,
+    end_date    DATE CHECK (end_date IS NULL OR end_date >= start_date),
+    notes       TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_medications_cat ON cat_medications(cat_id, start_date DESC);
+
+CREATE TABLE IF NOT EXISTS cat_allergies (
+    id          BIGSERIAL PRIMARY KEY,
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    allergen    TEXT NOT NULL,
+    severity    TEXT NOT NULL CHECK (severity IN ('mild', 'moderate', 'severe')),
+    reaction    TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- um registro por alérgeno
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cat_allergies_allergen ON cat_allergies(cat_id, lower(allergen));
+
+-- Castração: uma linha por gato (ausente = não informado).
+CREATE TABLE IF NOT EXISTS cat_neutering (
+    cat_id       BIGINT PRIMARY KEY REFERENCES cats(id) ON DELETE CASCADE,
+    neutered     BOOLEAN NOT NULL,
+    neutered_on  DATE,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Relatório de vacinas vencidas/a vencer, recalculado periodicamente pelo job reports.vaccinations_due.
+-- Cada execução grava uma "run"; a API lê sempre a mais recente e as antigas são descartadas.
+CREATE TABLE IF NOT EXISTS vaccinations_due_runs (
+    id            BIGSERIAL PRIMARY KEY,
+    generated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    window_days   INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vaccinations_due (
+    run_id          BIGINT NOT NULL REFERENCES vaccinations_due_runs(id) ON DELETE CASCADE,
+    cat_id          BIGINT NOT NULL,
+    cat_name        TEXT NOT NULL,
+    vaccination_id  BIGINT NOT NULL,
+    vaccine         TEXT NOT NULL,
+    due_on          DATE NOT NULL,
+    status          TEXT NOT NULL CHECK (status IN ('overdue', 'upcoming'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_vaccinations_due_run ON vaccinations_due(run_id, due_on);
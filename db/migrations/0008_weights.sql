@@ -0,0 +1,111 @@
+-- Histórico de peso: cada medição vira uma linha; cats.weight_kg continua sendo a mais recente.
+CREATE TABLE IF NOT EXISTS cat_weights (
+    id           BIGSERIAL PRIMARY KEY,
+    cat_id       BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    weight_kg    NUMERIC(5,2) NOT NULL CHECK (weight_kg > 0),
+    measured_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    source       TEXT NOT NULL DEFAULT 'measurement' CHECK (source IN ('measurement', 'profile')),
+    notes        TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_weights_cat ON cat_weights(cat_id, measured_at DESC, id DESC);
+
+-- O peso atual de cada gato vira a primeira medição do histórico.
+INSERT INTO cat_weights (cat_id, weight_kg, measured_at, source)
+SELECT id, weight_kg, updated_at, 'profile' FROM cats
+WHERE weight_kg > 0
+  AND NOT EXISTS (SELECT 1 FROM cat_weights w WHERE w.cat_id = cats.id);
+
+-- Alterar weight_kg pelo cadastro (POST/PUT /cats, lote, importação) também registra uma medição.
+-- POST /cats/{id}/weights grava a medição ele mesmo e sincroniza weight_kg com
+-- cat_api.weight_sync = 'on' (SET LOCAL), para a troca não ser registrada duas vezes.
+CREATE OR REPLACE FUNCTION cats_record_weight()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.weight_kg IS NULL OR NEW.weight_kg <= 0
+     OR coalesce(current_setting('cat_api.weight_sync', true), '') = 'on' THEN
+    RETURN NULL;
+  END IF;
+  IF TG_OP = 'UPDATE' AND NEW.weight_kg IS NOT DISTINCT FROM OLD.weight_kg THEN
+    RETURN NULL;
+  END IF;
+  INSERT INTO cat_weights (cat_id, weight_kg, source) VALUES (NEW.id, NEW.weight_kg, 'profile');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS record_weight ON cats;
+CREATE TRIGGER record_weight
+AFTER INSERT OR UPDATE OF weight_kg ON cats
+FOR EACH ROW
+EXECUTE PROCEDURE cats_record_weight();
+
+-- Regras de alerta: variação de pelo menos threshold_pct % em relação à medição de referência
+-- dos últimos window_days dias (o maior peso para 'loss', o menor para 'gain').
+CREATE TABLE IF NOT EXISTS weight_alert_rules (
+    kind           TEXT PRIMARY KEY CHECK (kind IN ('loss', 'gain')),
+    threshold_pct  NUMERIC(5,2) NOT NULL CHECK (threshold_pct > 0),
+    window_days    INT NOT NULL CHECK (window_days > 0)
+);
+
+INSERT INTO weight_alert_rules (kind, threshold_pct, window_days) VALUES
+    ('loss', 10, 30),
+    ('gain', 20, 30)
+ON CONFLICT (kind) DO NOTHING;
+
+-- Alertas gerados pelas medições. Os pesos e datas são copiados para o alerta continuar
+-- legível mesmo se a medição de referência for apagada; apagar a medição que disparou
+-- o alerta apaga o alerta.
+CREATE TABLE IF NOT EXISTS cat_weight_alerts (
+    id               BIGSERIAL PRIMARY KEY,
+    cat_id           BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    weight_id        BIGINT NOT NULL REFERENCES cat_weights(id) ON DELETE CASCADE,
+    kind             TEXT NOT NULL CHECK (kind IN ('loss', 'gain')),
+    change_pct       NUMERIC(7,2) NOT NULL,
+    threshold_pct    NUMERIC(5,2) NOT NULL,
+    window_days      INT NOT NULL,
+    from_kg          NUMERIC(5,2) NOT NULL,
+    from_at          TIMESTAMPTZ NOT NULL,
+    to_kg            NUMERIC(5,2) NOT NULL,
+    to_at            TIMESTAMPTZ NOT NULL,
+    acknowledged_at  TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (weight_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_weight_alerts_created_at ON cat_weight_alerts(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_cat_weight_alerts_cat ON cat_weight_alerts(cat_id, created_at DESC);
+
+-- Cada nova medição é comparada com as dos window_days dias anteriores, para cada regra.
+CREATE OR REPLACE FUNCTION cat_weights_check_alerts()
+RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO cat_weight_alerts (cat_id, weight_id, kind, change_pct, threshold_pct, window_days, from_kg, from_at, to_kg, to_at)
+  SELECT NEW.cat_id, NEW.id, r.kind,
+         round((NEW.weight_kg - ref.weight_kg) / ref.weight_kg * 100, 2),
+         r.threshold_pct, r.window_days, ref.weight_kg, ref.measured_at, NEW.weight_kg, NEW.measured_at
+  FROM weight_alert_rules r
+  CROSS JOIN LATERAL (
+      SELECT w.weight_kg, w.measured_at
+      FROM cat_weights w
+      WHERE w.cat_id = NEW.cat_id
+        AND w.id <> NEW.id
+        AND w.measured_at < NEW.measured_at
+        AND w.measured_at >= NEW.measured_at - make_interval(days => r.window_days)
+      ORDER BY CASE WHEN r.kind = 'loss' THEN w.weight_kg END DESC NULLS LAST,
+               CASE WHEN r.kind = 'gain' THEN w.weight_kg END ASC NULLS LAST
+      LIMIT 1
+  ) ref
+  WHERE (r.kind = 'loss' AND NEW.weight_kg <= ref.weight_kg * (1 - r.threshold_pct / 100))
+     OR (r.kind = 'gain' AND NEW.weight_kg >= ref.weight_kg * (1 + r.threshold_pct / 100))
+  ON CONFLICT (weight_id, kind) DO NOTHING;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS check_alerts ON cat_weights;
+CREATE TRIGGER check_alerts
+AFTER INSERT ON cat_weights
+FOR EACH ROW
+EXECUTE PROCEDURE cat_weights_check_alerts();
@@ -0,0 +1,20 @@
+-- A idade deixa de ser gravada: guardamos a data de nascimento (e a precisão dela)
+-- e a aplicação calcula age_years/age_months a cada leitura.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS birth_date DATE;
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS birth_date_precision TEXT NOT NULL DEFAULT 'estimated'
+    CHECK (birth_date_precision IN ('exact', 'month', 'year', 'estimated'));
+
+-- Gatos existentes: a idade informada valia na data do cadastro, então a estimativa
+-- é created_at menos age_years anos.
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'cats' AND column_name = 'age_years') THEN
+    UPDATE cats
+    SET birth_date = (created_at::date - make_interval(years => age_years))::date,
+        birth_date_precision = 'estimated'
+    WHERE birth_date IS NULL;
+  END IF;
+END $$;
+
+ALTER TABLE cats ALTER COLUMN birth_date SET NOT NULL;
+ALTER TABLE cats DROP COLUMN IF EXISTS age_years;
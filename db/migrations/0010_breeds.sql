@@ -0,0 +1,120 @@
+-- Catálogo de raças: nome canônico, traduções e apelidos ("SRD", "srd", "Vira-lata" -> mesma raça).
+
+-- breed_key normaliza um nome para comparação: minúsculas, sem acentos e com qualquer
+-- sequência de pontuação/espaços trocada por um espaço ("Vira-Lata!" -> "vira lata").
+CREATE OR REPLACE FUNCTION breed_key(s TEXT)
+RETURNS TEXT AS $$
+  SELECT btrim(regexp_replace(
+      translate(lower(s), 'áàâãäåéèêëíìîïóòôõöúùûüýÿçñ', 'aaaaaaeeeeiiiiooooouuuuyycn'),
+      '[^a-z0-9]+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS breeds (
+    id          BIGSERIAL PRIMARY KEY,
+    slug        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL, -- nome canônico (gravado em cats.breed)
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS breed_translations (
+    breed_id  BIGINT NOT NULL REFERENCES breeds(id) ON DELETE CASCADE,
+    lang      TEXT NOT NULL, -- "en", "pt", ...
+    name      TEXT NOT NULL,
+    PRIMARY KEY (breed_id, lang)
+);
+
+CREATE TABLE IF NOT EXISTS breed_aliases (
+    id        BIGSERIAL PRIMARY KEY,
+    breed_id  BIGINT NOT NULL REFERENCES breeds(id) ON DELETE CASCADE,
+    alias     TEXT NOT NULL,
+    key       TEXT GENERATED ALWAYS AS (breed_key(alias)) STORED UNIQUE
+);
+
+-- Todos os nomes pelos quais uma raça é reconhecida. kind ordena a preferência
+-- quando o mesmo texto aparece mais de uma vez (nome canônico primeiro).
+CREATE OR REPLACE VIEW breed_names AS
+    SELECT id AS breed_id, name, breed_key(name) AS key, 1 AS kind FROM breeds
+    UNION ALL
+    SELECT breed_id, name, breed_key(name), 2 FROM breed_translations
+    UNION ALL
+    SELECT breed_id, alias, key, 3 FROM breed_aliases;
+
+-- Raças iniciais
+INSERT INTO breeds (slug, name) VALUES
+    ('srd', 'SRD'),
+    ('siames', 'Siamês'),
+    ('persa', 'Persa'),
+    ('maine-coon', 'Maine Coon'),
+    ('ragdoll', 'Ragdoll'),
+    ('bengal', 'Bengal'),
+    ('sphynx', 'Sphynx'),
+    ('british-shorthair', 'British Shorthair'),
+    ('angora-turco', 'Angorá Turco'),
+    ('himalaio', 'Himalaio'),
+    ('abissinio', 'Abissínio'),
+    ('azul-russo', 'Azul Russo'),
+    ('scottish-fold', 'Scottish Fold'),
+    ('noruegues-da-floresta', 'Norueguês da Floresta'),
+    ('exotico', 'Exótico')
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO breed_translations (breed_id, lang, name)
+SELECT b.id, t.lang, t.name
+FROM (VALUES
+    ('srd', 'en', 'Mixed breed'),
+    ('siames', 'en', 'Siamese'),
+    ('persa', 'en', 'Persian'),
+    ('maine-coon', 'en', 'Maine Coon'),
+    ('ragdoll', 'en', 'Ragdoll'),
+    ('bengal', 'en', 'Bengal'),
+    ('sphynx', 'en', 'Sphynx'),
+    ('british-shorthair', 'en', 'British Shorthair'),
+    ('angora-turco', 'en', 'Turkish Angora'),
+    ('himalaio', 'en', 'Himalayan'),
+    ('abissinio', 'en', 'Abyssinian'),
+    ('azul-russo', 'en', 'Russian Blue'),
+    ('scottish-fold', 'en', 'Scottish Fold'),
+    ('noruegues-da-floresta', 'en', 'Norwegian Forest Cat'),
+    ('exotico', 'en', 'Exotic Shorthair')
+) AS t(slug, lang, name)
+JOIN breeds b ON b.slug = t.slug
+ON CONFLICT (breed_id, lang) DO NOTHING;
+
+INSERT INTO breed_aliases (breed_id, alias)
+SELECT b.id, a.alias
+FROM (VALUES
+    ('srd', 'Sem raça definida'),
+    ('srd', 'Vira-lata'),
+    ('srd', 'Viralata'),
+    ('srd', 'Mestiço'),
+    ('srd', 'Mixed'),
+    ('siames', 'Siames'),
+    ('bengal', 'Bengalês'),
+    ('sphynx', 'Esfinge'),
+    ('sphynx', 'Gato pelado'),
+    ('british-shorthair', 'Britânico de pelo curto'),
+    ('angora-turco', 'Angorá'),
+    ('azul-russo', 'Russo azul'),
+    ('noruegues-da-floresta', 'Norueguês'),
+    ('exotico', 'Exotic')
+) AS a(slug, alias)
+JOIN breeds b ON b.slug = a.slug
+ON CONFLICT (key) DO NOTHING;
+
+-- Gatos: breed continua guardando o texto (o nome canônico, quando reconhecido);
+-- breed_id aponta para o catálogo. breed_unrecognized marca os que ficaram sem raça do catálogo.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS breed_id BIGINT REFERENCES breeds(id) ON DELETE SET NULL;
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS breed_unrecognized BOOLEAN
+    GENERATED ALWAYS AS (breed IS NOT NULL AND breed_id IS NULL) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_cats_breed_id ON cats(breed_id);
+
+-- Normaliza os gatos existentes
+UPDATE cats c
+SET breed_id = m.breed_id, breed = m.name
+FROM (
+    SELECT DISTINCT ON (n.key) n.key, b.id AS breed_id, b.name
+    FROM breed_names n JOIN breeds b ON b.id = n.breed_id
+    ORDER BY n.key, n.kind
+) m
+WHERE c.breed_id IS NULL AND breed_key(c.breed) = m.key;
@@ -0,0 +1,84 @@
+-- Pelagem estruturada: cores (até 3) e padrão, de vocabulário controlado.
+-- coat_color continua como descrição livre.
+
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS coat_colors TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS coat_pattern TEXT;
+
+ALTER TABLE cats DROP CONSTRAINT IF EXISTS cats_coat_colors_check;
+ALTER TABLE cats ADD CONSTRAINT cats_coat_colors_check CHECK (
+    coat_colors <@ ARRAY['black','white','gray','orange','cream','brown','cinnamon','lilac','fawn','silver']
+    AND cardinality(coat_colors) <= 3
+);
+ALTER TABLE cats DROP CONSTRAINT IF EXISTS cats_coat_pattern_check;
+ALTER TABLE cats ADD CONSTRAINT cats_coat_pattern_check CHECK (
+    coat_pattern IN ('solid','tabby','tortoiseshell','calico','bicolor','pointed')
+);
+
+-- coat=black,white usa o operador @> (contém), atendido pelo índice GIN
+CREATE INDEX IF NOT EXISTS idx_cats_coat_colors ON cats USING GIN (coat_colors);
+CREATE INDEX IF NOT EXISTS idx_cats_coat_pattern ON cats(coat_pattern);
+
+/*
+Conversão best-effort de coat_color: procura palavras conhecidas (pt e en) no
+texto normalizado por breed_key (minúsculas, sem acentos, pontuação vira espaço).
+O que não for reconhecido fica como estava: coat_colors vazio e coat_pattern NULL.
+*/
+
+-- Cores, na ordem em que aparecem no texto
+UPDATE cats c
+SET coat_colors = ARRAY(
+    SELECT v.color
+    FROM (VALUES
+        ('black',    'preto|preta|pretos|negro|negra|black'),
+        ('white',    'branco|branca|brancos|white'),
+        ('gray',     'cinza|cinzento|cinzenta|azul|gray|grey|blue'),
+        ('orange',   'laranja|ruivo|ruiva|amarelo|amarela|ginger|orange|red'),
+        ('cream',    'creme|cream'),
+        ('brown',    'marrom|chocolate|castanho|castanha|brown'),
+        ('cinnamon', 'canela|cinnamon'),
+        ('lilac',    'lilas|lilac'),
+        ('fawn',     'bege|fawn'),
+        ('silver',   'prata|prateado|prateada|silver')
+    ) AS v(color, words)
+    WHERE breed_key(c.coat_color) ~ ('\m(' || v.words || ')\M')
+    ORDER BY position(substring(breed_key(c.coat_color) FROM ('\m(' || v.words || ')\M')) IN breed_key(c.coat_color))
+    LIMIT 3
+)
+WHERE c.coat_color IS NOT NULL AND c.coat_colors = '{}';
+
+-- Padrão: a primeira palavra reconhecida, na ordem de prioridade da lista
+UPDATE cats c
+SET coat_pattern = (
+    SELECT v.pattern
+    FROM (VALUES
+        (1, 'calico',        'calico|tricolor|tricolore'),
+        (2, 'tortoiseshell', 'escaminha|tartaruga|tortie|tortoiseshell'),
+        (3, 'pointed',       'pointed|colorpoint|point|siames|siamesa'),
+        (4, 'tabby',         'tigrado|tigrada|rajado|rajada|listrado|listrada|malhado|malhada|tabby'),
+        (5, 'bicolor',       'bicolor|frajola|tuxedo'),
+        (6, 'solid',         'solido|solida|liso|lisa|solid')
+    ) AS v(priority, pattern, words)
+    WHERE breed_key(c.coat_color) ~ ('\m(' || v.words || ')\M')
+    ORDER BY v.priority
+    LIMIT 1
+)
+WHERE c.coat_color IS NOT NULL AND c.coat_pattern IS NULL;
+
+-- Frajola/tuxedo é preto e branco; calico sem cores reconhecidas é branco, preto e laranja
+UPDATE cats SET coat_colors = ARRAY['black','white']
+WHERE coat_colors = '{}' AND breed_key(coat_color) ~ '\m(frajola|tuxedo)\M';
+UPDATE cats SET coat_colors = ARRAY['white','black','orange']
+WHERE coat_colors = '{}' AND coat_pattern = 'calico';
+
+-- Uma cor só, sem padrão no texto: sólido
+UPDATE cats SET coat_pattern = 'solid'
+WHERE coat_pattern IS NULL AND cardinality(coat_colors) = 1;
+
+-- Combinações que a API recusaria (ex: "bicolor" com três cores) ficam só com as cores
+UPDATE cats SET coat_pattern = NULL
+WHERE cardinality(coat_colors) > 0 AND (
+       (coat_pattern = 'solid' AND cardinality(coat_colors) <> 1)
+    OR (coat_pattern = 'bicolor' AND cardinality(coat_colors) <> 2)
+    OR (coat_pattern = 'tortoiseshell' AND cardinality(coat_colors) < 2)
+    OR (coat_pattern = 'calico' AND (cardinality(coat_colors) <> 3 OR NOT 'white' = ANY(coat_colors)))
+);
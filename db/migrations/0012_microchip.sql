@@ -0,0 +1,23 @@
+-- Microchip (ISO 11784/11785, 15 dígitos), único por gato. A validação completa fica na API
+-- (domain.NormalizeMicrochip); o banco garante o formato e a unicidade.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS microchip TEXT;
+ALTER TABLE cats DROP CONSTRAINT IF EXISTS cats_microchip_check;
+ALTER TABLE cats ADD CONSTRAINT cats_microchip_check CHECK (microchip ~ '^[0-9]{15}$');
+CREATE UNIQUE INDEX IF NOT EXISTS cats_microchip_key ON cats(microchip);
+
+-- Registro de toda consulta por microchip (GET /cats/lookup), para revisão de privacidade.
+-- cat_id não tem FK: o registro sobrevive à remoção do gato.
+CREATE TABLE IF NOT EXISTS microchip_lookups (
+    id          BIGSERIAL PRIMARY KEY,
+    microchip   TEXT NOT NULL,
+    cat_id      BIGINT,
+    result      TEXT NOT NULL CHECK (result IN ('found','not_found','invalid')),
+    ip          TEXT NOT NULL,
+    user_agent  TEXT NOT NULL,
+    request_id  TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_microchip_lookups_created ON microchip_lookups(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_microchip_lookups_microchip ON microchip_lookups(microchip);
+CREATE INDEX IF NOT EXISTS idx_microchip_lookups_cat ON microchip_lookups(cat_id);
@@ -0,0 +1,79 @@
+-- Genealogia: pai (sire), mãe (dam) e ninhada (litter) de cada gato.
+-- Ninhadas agrupam os filhotes de um mesmo parto; o gato que entra numa ninhada
+-- herda dela o pai e a mãe que não foram informados.
+CREATE TABLE IF NOT EXISTS litters (
+    id          BIGSERIAL PRIMARY KEY,
+    sire_id     BIGINT REFERENCES cats(id) ON DELETE SET NULL,
+    dam_id      BIGINT REFERENCES cats(id) ON DELETE SET NULL,
+    birth_date  DATE,
+    notes       TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT litters_parents_check CHECK (sire_id <> dam_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_litters_sire ON litters(sire_id);
+CREATE INDEX IF NOT EXISTS idx_litters_dam ON litters(dam_id);
+
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS sire_id BIGINT REFERENCES cats(id) ON DELETE SET NULL;
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS dam_id BIGINT REFERENCES cats(id) ON DELETE SET NULL;
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS litter_id BIGINT REFERENCES litters(id) ON DELETE SET NULL;
+ALTER TABLE cats DROP CONSTRAINT IF EXISTS cats_parents_check;
+ALTER TABLE cats ADD CONSTRAINT cats_parents_check CHECK (sire_id <> id AND dam_id <> id AND sire_id <> dam_id);
+
+-- descendentes (GET /cats/{id}/descendants) percorrem a genealogia a partir dos pais
+CREATE INDEX IF NOT EXISTS idx_cats_sire ON cats(sire_id);
+CREATE INDEX IF NOT EXISTS idx_cats_dam ON cats(dam_id);
+CREATE INDEX IF NOT EXISTS idx_cats_litter ON cats(litter_id);
+
+-- cats_lineage confere a genealogia a cada escrita:
+--   * quem entra numa ninhada herda o pai/a mãe dela, e não pode ter pais diferentes dos da ninhada;
+--   * um novo pai/mãe não pode ser o próprio gato nem um descendente dele (ciclo).
+-- A checagem de ciclo roda sob um advisory lock: duas escritas concorrentes (A filho de B
+-- e B filho de A) não passam as duas pela checagem antes de uma enxergar a outra.
+-- Os erros usam o nome de uma constraint, que a API traduz (storage.catWriteErr).
+CREATE OR REPLACE FUNCTION cats_check_lineage() RETURNS trigger AS $$
+DECLARE
+    l_sire BIGINT;
+    l_dam  BIGINT;
+BEGIN
+    IF NEW.litter_id IS NOT NULL THEN
+        SELECT sire_id, dam_id INTO l_sire, l_dam FROM litters WHERE id = NEW.litter_id;
+        IF TG_OP = 'INSERT' OR NEW.litter_id IS DISTINCT FROM OLD.litter_id THEN
+            NEW.sire_id := COALESCE(NEW.sire_id, l_sire);
+            NEW.dam_id := COALESCE(NEW.dam_id, l_dam);
+        END IF;
+        IF (NEW.sire_id <> l_sire) OR (NEW.dam_id <> l_dam) THEN
+            RAISE EXCEPTION 'parents of cat % differ from litter %', NEW.id, NEW.litter_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'cats_litter_parents';
+        END IF;
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND (
+        (NEW.sire_id IS NOT NULL AND NEW.sire_id IS DISTINCT FROM OLD.sire_id) OR
+        (NEW.dam_id IS NOT NULL AND NEW.dam_id IS DISTINCT FROM OLD.dam_id)
+    ) THEN
+        PERFORM pg_advisory_xact_lock(hashtext('cats_lineage'));
+        IF EXISTS (
+            WITH RECURSIVE ancestors(id) AS (
+                SELECT p.id FROM unnest(ARRAY[NEW.sire_id, NEW.dam_id]) AS p(id) WHERE p.id IS NOT NULL
+                UNION
+                SELECT p.id
+                FROM ancestors a
+                JOIN cats c ON c.id = a.id
+                CROSS JOIN LATERAL unnest(ARRAY[c.sire_id, c.dam_id]) AS p(id)
+                WHERE p.id IS NOT NULL
+            )
+            SELECT 1 FROM ancestors WHERE id = NEW.id
+        ) THEN
+            RAISE EXCEPTION 'cat % would be its own ancestor', NEW.id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'cats_lineage_cycle';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS cats_lineage ON cats;
+CREATE TRIGGER cats_lineage
+    BEFORE INSERT OR UPDATE OF sire_id, dam_id, litter_id ON cats
+    FOR EACH ROW EXECUTE FUNCTION cats_check_lineage();
@@ -0,0 +1,37 @@
+-- Etiquetas livres ("shy", "FIV+", "indoor-only") ligadas aos gatos (N:N).
+-- key é o nome sem diferenciar maiúsculas: "Shy" e "shy" são a mesma etiqueta.
+CREATE TABLE IF NOT EXISTS tags (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 50 AND position(',' IN name) = 0),
+    key         TEXT GENERATED ALWAYS AS (lower(name)) STORED,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_key_key ON tags(key);
+
+CREATE TABLE IF NOT EXISTS cat_tags (
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    tag_id      BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (cat_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_tags_tag ON cat_tags(tag_id);
+
+-- Atributos personalizados (chave/valor) sem mudar o schema. As chaves aceitas e o
+-- tipo de cada uma são definidos por tenant (X-Tenant-ID) em attribute_definitions;
+-- a API confere os valores na escrita.
+ALTER TABLE cats ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE cats DROP CONSTRAINT IF EXISTS cats_attributes_check;
+ALTER TABLE cats ADD CONSTRAINT cats_attributes_check CHECK (jsonb_typeof(attributes) = 'object');
+CREATE INDEX IF NOT EXISTS idx_cats_attributes ON cats USING GIN (attributes);
+
+CREATE TABLE IF NOT EXISTS attribute_definitions (
+    tenant       TEXT NOT NULL,
+    key          TEXT NOT NULL CHECK (key ~ '^[a-z][a-z0-9_]{0,63}$'),
+    type         TEXT NOT NULL CHECK (type IN ('string','number','integer','boolean','date')),
+    description  TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant, key)
+);
@@ -0,0 +1,26 @@
+-- Anotações dos voluntários sobre cada gato (texto em markdown). Notas fixadas
+-- (pinned) aparecem primeiro na listagem.
+CREATE TABLE IF NOT EXISTS cat_notes (
+    id          BIGSERIAL PRIMARY KEY,
+    cat_id      BIGINT NOT NULL REFERENCES cats(id) ON DELETE CASCADE,
+    author      TEXT NOT NULL CHECK (length(author) BETWEEN 1 AND 100),
+    body        TEXT NOT NULL CHECK (length(body) > 0),
+    pinned      BOOLEAN NOT NULL DEFAULT false,
+    version     INT NOT NULL DEFAULT 1,
+    edited_by   TEXT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cat_notes_cat ON cat_notes(cat_id, created_at DESC);
+
+-- Histórico de edição: cada versão do texto de uma nota (a 1 é a original, do autor).
+CREATE TABLE IF NOT EXISTS cat_note_revisions (
+    id          BIGSERIAL PRIMARY KEY,
+    note_id     BIGINT NOT NULL REFERENCES cat_notes(id) ON DELETE CASCADE,
+    version     INT NOT NULL,
+    body        TEXT NOT NULL,
+    editor      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (note_id, version)
+);
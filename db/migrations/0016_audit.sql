@@ -0,0 +1,100 @@
+-- Auditoria: toda criação, alteração e remoção de gatos, tutores, notas e etiquetas vira uma
+-- linha em audit_events, gravada pelo trigger audit_row() na mesma transação da mudança (não
+-- há como alterar uma linha auditada sem deixar registro). Quem fez a mudança vem de variáveis locais da transação
+-- (SET LOCAL cat_api.actor, cat_api.request_id, cat_api.ip, cat_api.user_agent), preenchidas
+-- pela API; alterações feitas direto no banco ficam registradas sem esses campos.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id          BIGSERIAL PRIMARY KEY,
+    entity      TEXT NOT NULL,
+    entity_id   BIGINT NOT NULL,
+    action      TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    actor       TEXT,
+    request_id  TEXT,
+    ip          TEXT,
+    user_agent  TEXT,
+    before      JSONB, -- NULL na criação; na alteração, só as colunas que mudaram
+    after       JSONB, -- NULL na remoção; na alteração, só as colunas que mudaram
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity, entity_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request ON audit_events(request_id);
+
+-- audit_row registra a mudança da linha; o nome da entidade vem do argumento do trigger.
+CREATE OR REPLACE FUNCTION audit_row()
+RETURNS TRIGGER AS $$
+DECLARE
+  old_row  JSONB;
+  new_row  JSONB;
+  row_id   BIGINT;
+  k        TEXT;
+BEGIN
+  IF TG_OP <> 'INSERT' THEN
+    old_row := to_jsonb(OLD) - 'updated_at';
+    row_id := (old_row ->> 'id')::bigint;
+  END IF;
+  IF TG_OP <> 'DELETE' THEN
+    new_row := to_jsonb(NEW) - 'updated_at';
+    row_id := (new_row ->> 'id')::bigint;
+  END IF;
+
+  IF TG_OP = 'UPDATE' THEN
+    -- diff: fica só o que mudou
+    FOR k IN SELECT jsonb_object_keys(old_row) LOOP
+      IF old_row -> k IS NOT DISTINCT FROM new_row -> k THEN
+        old_row := old_row - k;
+        new_row := new_row - k;
+      END IF;
+    END LOOP;
+    IF old_row = '{}'::jsonb AND new_row = '{}'::jsonb THEN
+      RETURN NULL;
+    END IF;
+  END IF;
+
+  INSERT INTO audit_events (entity, entity_id, action, actor, request_id, ip, user_agent, before, after)
+  VALUES (
+    TG_ARGV[0], row_id,
+    CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END,
+    nullif(current_setting('cat_api.actor', true), ''),
+    nullif(current_setting('cat_api.request_id', true), ''),
+    nullif(current_setting('cat_api.ip', true), ''),
+    nullif(current_setting('cat_api.user_agent', true), ''),
+    old_row, new_row
+  );
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- audit_table liga audit_row a uma tabela (com coluna id) como a entidade informada, no trigger
+-- audit_<tabela>. É a forma de auditar uma entidade nova: na migração que cria a tabela,
+-- SELECT audit_table('tabela', 'entidade'), e a entidade em domain.AuditEntities; as escritas
+-- na tabela passam por beginAudited (internal/storage) para registrar quem as fez.
+CREATE OR REPLACE FUNCTION audit_table(tbl regclass, entity text)
+RETURNS void AS $$
+DECLARE
+  trg TEXT := 'audit_' || (SELECT relname FROM pg_class WHERE oid = tbl);
+BEGIN
+  EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', trg, tbl);
+  EXECUTE format('CREATE TRIGGER %I AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE PROCEDURE audit_row(%L)',
+                 trg, tbl, entity);
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT audit_table('cats', 'cat');
+SELECT audit_table('owners', 'owner');
+SELECT audit_table('cat_notes', 'note');
+SELECT audit_table('tags', 'tag');
+
+-- O registro só cresce: UPDATE, DELETE e TRUNCATE em audit_events são recusados.
+CREATE OR REPLACE FUNCTION audit_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_events
+FOR EACH STATEMENT
+EXECUTE PROCEDURE audit_events_append_only();
@@ -0,0 +1,53 @@
+-- Histórico de versões: cada versão de um gato fica guardada inteira em cats_history,
+-- gravada pelo trigger cats_record_history() na mesma transação da mudança.
+-- Diferente de audit_events (só o que mudou, com quem mudou), aqui está a linha completa,
+-- o que permite ler o gato como ele era em qualquer instante (GET /cats/{id}?as_of=).
+--
+-- valid_from/valid_to delimitam o período em que a versão foi a atual (valid_to NULL = atual).
+-- Não há FK para cats: o histórico sobrevive à remoção do gato, que só fecha a última versão.
+CREATE TABLE IF NOT EXISTS cats_history (
+    cat_id      BIGINT NOT NULL,
+    version     BIGINT NOT NULL,
+    data        JSONB NOT NULL, -- to_jsonb da linha de cats
+    valid_from  TIMESTAMPTZ NOT NULL,
+    valid_to    TIMESTAMPTZ,
+    PRIMARY KEY (cat_id, version),
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cats_history_period ON cats_history(cat_id, valid_from DESC);
+
+CREATE OR REPLACE FUNCTION cats_record_history()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    UPDATE cats_history SET valid_to = now() WHERE cat_id = OLD.id AND valid_to IS NULL;
+    RETURN NULL;
+  END IF;
+
+  IF TG_OP = 'UPDATE' THEN
+    IF NEW.version = OLD.version THEN
+      -- alteração feita direto no banco, sem incrementar a versão: corrige a versão atual
+      UPDATE cats_history SET data = to_jsonb(NEW) WHERE cat_id = NEW.id AND version = NEW.version;
+      RETURN NULL;
+    END IF;
+    UPDATE cats_history SET valid_to = NEW.updated_at WHERE cat_id = NEW.id AND valid_to IS NULL;
+  END IF;
+
+  INSERT INTO cats_history (cat_id, version, data, valid_from)
+  VALUES (NEW.id, NEW.version, to_jsonb(NEW), NEW.updated_at)
+  ON CONFLICT (cat_id, version) DO UPDATE SET data = EXCLUDED.data, valid_from = EXCLUDED.valid_from, valid_to = NULL;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS cats_record_history ON cats;
+CREATE TRIGGER cats_record_history
+AFTER INSERT OR UPDATE OR DELETE ON cats
+FOR EACH ROW
+EXECUTE PROCEDURE cats_record_history();
+
+-- Gatos que já existiam entram com a versão atual, válida desde a última alteração
+INSERT INTO cats_history (cat_id, version, data, valid_from)
+SELECT c.id, c.version, to_jsonb(c), c.updated_at FROM cats c
+ON CONFLICT (cat_id, version) DO NOTHING;
@@ -0,0 +1,57 @@
+-- Outbox transacional: cada criação, alteração e remoção de gato grava um evento
+-- (cat.created, cat.updated, cat.deleted) em outbox, pelo trigger cats_outbox(), na mesma
+-- transação da mudança. Se a mudança for desfeita, o evento também é; se for confirmada,
+-- o evento fica gravado até o relay (internal/outbox) entregá-lo a todos os destinos.
+--
+-- A ordem por agregado vem do id: duas mudanças no mesmo gato disputam o lock da linha
+-- (o UPDATE/DELETE em cats), então a segunda só grava o seu evento depois que a primeira foi
+-- confirmada. Quem grava eventos de um gato em outra tabela precisa travar a linha do gato
+-- antes (SELECT ... FOR NO KEY UPDATE, como CatRepository.AddThumbnail): a chave estrangeira
+-- só pega KEY SHARE, que não conflita com o UPDATE.
+--
+-- A entrega é pelo menos uma vez: outbox_deliveries registra o que cada destino confirmou,
+-- mas um destino pode receber o evento de novo se o relay cair entre a entrega e o registro.
+CREATE TABLE IF NOT EXISTS outbox (
+    id               BIGSERIAL PRIMARY KEY,
+    aggregate        TEXT NOT NULL,
+    aggregate_id     BIGINT NOT NULL,
+    event_type       TEXT NOT NULL,
+    payload          JSONB NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at     TIMESTAMPTZ,                       -- entregue a todos os destinos
+    attempts         INT NOT NULL DEFAULT 0,            -- tentativas que falharam
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(), -- backoff depois de uma falha
+    last_error       TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_published ON outbox(published_at) WHERE published_at IS NOT NULL;
+
+-- Entregas já feitas, por destino: um evento que falhou em um destino é reenviado só para ele.
+CREATE TABLE IF NOT EXISTS outbox_deliveries (
+    event_id      BIGINT NOT NULL REFERENCES outbox(id) ON DELETE CASCADE,
+    sink          TEXT NOT NULL,
+    delivered_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (event_id, sink)
+);
+
+CREATE OR REPLACE FUNCTION cats_outbox()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO outbox (aggregate, aggregate_id, event_type, payload)
+    VALUES ('cat', OLD.id, 'cat.deleted', jsonb_build_object('id', OLD.id, 'version', OLD.version));
+    RETURN NULL;
+  END IF;
+
+  INSERT INTO outbox (aggregate, aggregate_id, event_type, payload)
+  VALUES ('cat', NEW.id, CASE TG_OP WHEN 'INSERT' THEN 'cat.created' ELSE 'cat.updated' END, to_jsonb(NEW));
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS cats_outbox ON cats;
+CREATE TRIGGER cats_outbox
+AFTER INSERT OR UPDATE OR DELETE ON cats
+FOR EACH ROW
+EXECUTE PROCEDURE cats_outbox();
@@ -0,0 +1,53 @@
+-- Webhooks: integradores assinam tipos de evento (cat.created, cat.updated, cat.deleted)
+-- e recebem um POST assinado (HMAC-SHA256) a cada evento publicado pela outbox.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                    BIGSERIAL PRIMARY KEY,
+    url                   TEXT NOT NULL,
+    events                TEXT[] NOT NULL CHECK (cardinality(events) > 0),
+    secret                TEXT NOT NULL, -- chave do HMAC (precisa do valor original para assinar)
+    active                BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures  INT NOT NULL DEFAULT 0, -- tentativas seguidas que falharam
+    disabled_at           TIMESTAMPTZ,            -- desativado automaticamente (ou pela API)
+    disabled_reason       TEXT,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+DROP TRIGGER IF EXISTS set_timestamp ON webhook_subscriptions;
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON webhook_subscriptions
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Uma entrega é um evento para uma assinatura; as tentativas atualizam a mesma linha
+-- (attempts, último código de resposta, último erro). Reenviar cria uma entrega nova.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id         BIGINT NOT NULL, -- id do evento na outbox (que pode já ter sido apagado)
+    event_type       TEXT NOT NULL,
+    payload          JSONB NOT NULL,  -- corpo enviado (o evento)
+    status           TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    claim_token      BIGINT NOT NULL DEFAULT 0, -- muda a cada reserva: o resultado de uma reserva vencida é descartado
+    response_code    INT,
+    response_body    TEXT,  -- início da resposta da última tentativa
+    error            TEXT,  -- erro da última tentativa (rede, timeout, status fora de 2xx)
+    duration_ms      INT,
+    delivered_at     TIMESTAMPTZ,
+    redelivery_of    BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- O relay da outbox pode entregar o mesmo evento mais de uma vez: uma entrega por evento e assinatura
+CREATE UNIQUE INDEX IF NOT EXISTS uq_webhook_deliveries_event ON webhook_deliveries(subscription_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, id DESC);
+
+DROP TRIGGER IF EXISTS set_timestamp ON webhook_deliveries;
+CREATE TRIGGER set_timestamp
+BEFORE UPDATE ON webhook_deliveries
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
@@ -0,0 +1,24 @@
+-- Miniatura pronta também é um evento da outbox (cat.thumbnail_ready), gravado na mesma transação
+-- da miniatura e entregue pelo relay como os demais eventos do gato: o stream SSE (GET /cats/stream)
+-- o recebe pelo destino Notify, como cat.created, cat.updated e cat.deleted.
+-- A ordem em relação aos outros eventos do gato depende do lock que AddThumbnail pega na linha
+-- do gato antes do INSERT (ver 0018_outbox.sql).
+CREATE OR REPLACE FUNCTION cat_thumbnails_outbox()
+RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO outbox (aggregate, aggregate_id, event_type, payload)
+  VALUES ('cat', NEW.cat_id, 'cat.thumbnail_ready',
+          jsonb_build_object('id', NEW.id, 'cat_id', NEW.cat_id, 'path', NEW.path, 'created_at', NEW.created_at));
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS cat_thumbnails_outbox ON cat_thumbnails;
+CREATE TRIGGER cat_thumbnails_outbox
+AFTER INSERT ON cat_thumbnails
+FOR EACH ROW
+EXECUTE PROCEDURE cat_thumbnails_outbox();
+
+-- O stream retoma do Last-Event-ID na ordem em que o destino Notify recebeu os eventos
+-- (ver OutboxRepository.DeliveredAfter).
+CREATE INDEX IF NOT EXISTS idx_outbox_deliveries_sink ON outbox_deliveries(sink, delivered_at, event_id);
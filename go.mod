@@ -5,6 +5,9 @@ go 1.25.0
 require (
 	// chi: framework leve para rotas HTTP
 	github.com/go-chi/chi/v5 v5.2.2
+	// locales + universal-translator: mensagens de validação em pt-BR e en
+	github.com/go-playground/locales v0.14.1
+	github.com/go-playground/universal-translator v0.18.1
 	// validator: validação de structs e campos
 	github.com/go-playground/validator/v10 v10.27.0
 	// pgx: driver PostgreSQL para Go
@@ -13,8 +16,6 @@ require (
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
-	github.com/go-playground/locales v0.14.1 // indirect
-	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
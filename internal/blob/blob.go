@@ -0,0 +1,116 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Pacote blob: armazenamento de arquivos gerados pela API (uploads de importação,
+relatórios, exportações). A interface Store permite trocar o disco local por
+um object storage (S3, GCS...) sem mexer nos serviços.
+*/
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store guarda e lê arquivos identificados por uma chave ("imports/12/input.csv").
+type Store interface {
+	// Create abre um arquivo para escrita; o conteúdo só fica visível após Close.
+	Create(ctx context.Context, key string) (io.WriteCloser, error)
+	// Open abre o arquivo para leitura. Retorna ErrNotFound se a chave não existir.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete remove o arquivo (sem erro se já não existir).
+	Delete(ctx context.Context, key string) error
+}
+
+// Put grava todo o conteúdo de r na chave informada.
+func Put(ctx context.Context, s Store, key string, r io.Reader) (int64, error) {
+	w, err := s.Create(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w, r)
+	if err != nil {
+		_ = w.Close()
+		return n, err
+	}
+	return n, w.Close()
+}
+
+// Local implementa Store em um diretório do disco.
+type Local struct {
+	dir string
+}
+
+// NewLocal cria o diretório base (se necessário) e devolve o Store.
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Local{dir: dir}, nil
+}
+
+// path resolve a chave dentro do diretório base, impedindo "../" de escapar dele.
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if strings.Contains(key, "..") || clean == "/" {
+		return "", errors.New("blob: chave inválida")
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
+
+func (l *Local) Create(ctx context.Context, key string) (io.WriteCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	// Escreve em um arquivo temporário e renomeia no Close: leitores nunca veem arquivo pela metade
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &localWriter{File: tmp, final: path}, nil
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+type localWriter struct {
+	*os.File
+	final string
+}
+
+func (w *localWriter) Close() error {
+	if err := w.File.Close(); err != nil {
+		_ = os.Remove(w.File.Name())
+		return err
+	}
+	return os.Rename(w.File.Name(), w.final)
+}
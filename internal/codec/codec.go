@@ -0,0 +1,93 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+/*
+Pacote codec: serializa respostas e lê requisições em MessagePack e XML
+usando apenas a biblioteca padrão.
+
+Os dois formatos partem da mesma representação do JSON: o valor é convertido
+com encoding/json (respeitando as tags `json`, omitempty, datas RFC 3339, etc.)
+e só então escrito no formato final. Assim, um gato tem exatamente os mesmos
+campos em JSON, MessagePack e XML, sem precisar de tags extras nas structs.
+
+Na leitura o caminho é o inverso: o corpo vira um valor genérico, que é
+remontado como JSON e decodificado com DisallowUnknownFields — as mesmas
+regras de campos desconhecidos do corpo JSON.
+*/
+
+// member é um par chave/valor de um objeto, preservando a ordem dos campos da struct.
+type member struct {
+	key string
+	val any
+}
+
+// object é um objeto JSON com a ordem dos campos preservada.
+type object []member
+
+// tree converte v na árvore genérica do JSON: nil, bool, json.Number, string, []any ou object.
+func tree(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return readValue(dec)
+}
+
+func readValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := object{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				val, err := readValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, member{key: key.(string), val: val})
+			}
+			_, err := dec.Token() // '}'
+			return obj, err
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				val, err := readValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, val)
+			}
+			_, err := dec.Token() // ']'
+			return arr, err
+		}
+		return nil, fmt.Errorf("codec: delimitador inesperado %v", t)
+	default:
+		return t, nil // nil, bool, json.Number ou string
+	}
+}
+
+// fromTree decodifica o valor genérico em v como se fosse um corpo JSON.
+func fromTree(t any, v any) error {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,199 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sample cobre os tipos que os recursos da API usam.
+type sample struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Breed     *string           `json:"breed,omitempty"`
+	Weight    float64           `json:"weight"`
+	Neutered  bool              `json:"neutered"`
+	Offset    int               `json:"offset"`
+	Tags      []string          `json:"tags"`
+	Owner     *sample           `json:"owner,omitempty"`
+	Extra     map[string]string `json:"extra,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func samples() map[string]sample {
+	breed := "Siamês"
+	created := time.Date(2026, 10, 18, 12, 30, 5, 123000000, time.UTC)
+	return map[string]sample{
+		"vazio":      {Tags: []string{}},
+		"completo":   {ID: 42, Name: "Mingau", Breed: &breed, Weight: 4.25, Neutered: true, Tags: []string{"dócil", "<arisco & cia>"}, CreatedAt: created},
+		"negativos":  {ID: -1, Offset: -40000, Weight: -0.5, Tags: []string{}},
+		"inteiros":   {ID: 1 << 40, Offset: 65536, Tags: []string{}},
+		"aninhado":   {ID: 1, Name: "Frajola", Tags: []string{"a"}, Owner: &sample{ID: 2, Name: "Ana", Tags: []string{}}, Extra: map[string]string{"k": "v"}},
+		"texto8":     {Name: strings.Repeat("gato ", 20), Tags: []string{}},
+		"texto16":    {Name: strings.Repeat("x", 300), Tags: []string{}},
+		"lista16":    {Tags: strings.Split(strings.Repeat("t,", 19)+"t", ",")},
+		"com espaço": {Name: "  Mingau  ", Tags: []string{" "}},
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	for name, in := range samples() {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeMsgpack(&buf, in); err != nil {
+				t.Fatal(err)
+			}
+			var out sample
+			if err := DecodeMsgpack(&buf, &out); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(in, out) {
+				t.Errorf("round trip:\n got %+v\nwant %+v", out, in)
+			}
+		})
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	for name, in := range samples() {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeXML(&buf, "cat", in); err != nil {
+				t.Fatal(err)
+			}
+			var out sample
+			if err := DecodeXML(&buf, &out); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(in, out) {
+				t.Errorf("round trip:\n got %+v\nwant %+v", out, in)
+			}
+		})
+	}
+}
+
+func TestEncodeMsgpack(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string // hex
+	}{
+		{"nil", nil, "c0"},
+		{"true", true, "c3"},
+		{"fixint", 5, "05"},
+		{"fixint negativo", -3, "fd"},
+		{"uint8", 200, "ccc8"},
+		{"int8", -100, "d09c"},
+		{"uint16", 1000, "cd03e8"},
+		{"int32", -70000, "d2fffeee90"},
+		{"float", 1.5, "cb3ff8000000000000"},
+		{"fixstr", "oi", "a26f69"},
+		{"lista", []int{1, 2}, "920102"},
+		{"objeto na ordem dos campos", struct {
+			B int `json:"b"`
+			A int `json:"a"`
+		}{1, 2}, "82a16201a16102"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := EncodeMsgpack(&buf, tt.v); err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(buf.Bytes()); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMsgpack(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		want    sample
+		wantErr error // nil: sucesso; ErrMsgpack: corpo malformado; errAny: recusado pelo encoding/json
+	}{
+		{"mapa", "82a2696401a46e616d65a24f69", sample{ID: 1, Name: "Oi"}, nil},
+		{"timestamp 32 bits", "81aa637265617465645f6174d6ff6a00b400", sample{CreatedAt: time.Unix(0x6a00b400, 0).UTC()}, nil},
+		{"campo desconhecido", "81a3666f6f01", sample{}, errAny},
+		{"tipo errado", "81a26964a178", sample{}, errAny},
+		{"bytes sobrando", "80c0", sample{}, ErrMsgpack},
+		{"truncado", "82a26964", sample{}, ErrMsgpack},
+		{"chave não string", "810101", sample{}, ErrMsgpack},
+		{"extensão desconhecida", "d40501", sample{}, ErrMsgpack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tt.hex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got sample
+			err = DecodeMsgpack(bytes.NewReader(b), &got)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("err = %v", err)
+			case tt.wantErr == errAny && err == nil, tt.wantErr == ErrMsgpack && !errors.Is(err, ErrMsgpack):
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// errAny marca os casos em que qualquer erro serve.
+var errAny = errors.New("any error")
+
+func TestDecodeXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    sample
+		wantErr error
+	}{
+		{"raiz com outro nome", "<gato><id>7</id><neutered>true</neutered></gato>", sample{ID: 7, Neutered: true}, nil},
+		{"espaços em números", "<cat><id> 7 </id><weight>\n4.5\n</weight></cat>", sample{ID: 7, Weight: 4.5}, nil},
+		{"lista", "<cat><tags><item>a</item><item>b</item></tags></cat>", sample{Tags: []string{"a", "b"}}, nil},
+		{"número inválido", "<cat><id>sete</id></cat>", sample{}, ErrXML},
+		{"booleano inválido", "<cat><neutered>sim</neutered></cat>", sample{}, ErrXML},
+		{"campo desconhecido", "<cat><foo>1</foo></cat>", sample{}, errAny},
+		{"duas raízes", "<cat></cat><cat></cat>", sample{}, ErrXML},
+		{"malformado", "<cat><id>1</cat>", sample{}, ErrXML},
+		{"vazio", "", sample{}, ErrXML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sample
+			err := DecodeXML(strings.NewReader(tt.body), &got)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("err = %v", err)
+			case tt.wantErr == errAny && err == nil, tt.wantErr == ErrXML && !errors.Is(err, ErrXML):
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeXML(t *testing.T) {
+	var buf bytes.Buffer
+	in := sample{ID: 1, Name: "A & B", Tags: []string{"x"}}
+	if err := EncodeXML(&buf, "cat", in); err != nil {
+		t.Fatal(err)
+	}
+	want := `<cat><id>1</id><name>A &amp; B</name><weight>0</weight><neutered>false</neutered><offset>0</offset>` +
+		`<tags><item>x</item></tags><created_at>0001-01-01T00:00:00Z</created_at></cat>`
+	if got := strings.TrimPrefix(buf.String(), `<?xml version="1.0" encoding="UTF-8"?>`+"\n"); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,367 @@
+package codec
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MsgpackContentType é o media type registrado para MessagePack.
+const MsgpackContentType = "application/msgpack"
+
+// ErrMsgpack indica um corpo MessagePack malformado ou com tipos não suportados.
+var ErrMsgpack = errors.New("msgpack inválido")
+
+// maxMsgpackLen limita arrays, maps e strings lidos de um corpo MessagePack,
+// para que um cabeçalho forjado não faça o servidor alocar gigabytes.
+const maxMsgpackLen = 1 << 20
+
+/*** escrita ***/
+
+// EncodeMsgpack escreve v em MessagePack (https://msgpack.org/), com os mesmos campos do JSON.
+// Inteiros usam a menor representação possível; datas saem como string RFC 3339, igual ao JSON.
+func EncodeMsgpack(w io.Writer, v any) error {
+	t, err := tree(v)
+	if err != nil {
+		return err
+	}
+	var buf []byte
+	buf, err = appendMsgpack(buf, t)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+func appendMsgpack(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return appendInt(b, i), nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return binary.BigEndian.AppendUint64(append(b, 0xcf), u), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f)), nil
+	case string:
+		return appendString(b, v), nil
+	case []any:
+		b = appendLen(b, len(v), 0x90, 0xdc, 0xdd)
+		for _, item := range v {
+			var err error
+			if b, err = appendMsgpack(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case object:
+		b = appendLen(b, len(v), 0x80, 0xde, 0xdf)
+		for _, m := range v {
+			b = appendString(b, m.key)
+			var err error
+			if b, err = appendMsgpack(b, m.val); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("codec: tipo %T sem representação em msgpack", v)
+}
+
+func appendInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= 127:
+		return append(b, byte(i)) // positive fixint
+	case i < 0 && i >= -32:
+		return append(b, byte(int8(i))) // negative fixint
+	case i >= 0 && i <= math.MaxUint8:
+		return append(b, 0xcc, byte(i))
+	case i >= 0 && i <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(i))
+	case i >= 0 && i <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(i))
+	case i >= 0:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), uint64(i))
+	case i >= math.MinInt8:
+		return append(b, 0xd0, byte(int8(i)))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(int16(i)))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(int32(i)))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+	}
+}
+
+func appendString(b []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n <= 31:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// appendLen escreve o cabeçalho de array/map: formato "fix" (até 15 itens), 16 ou 32 bits.
+func appendLen(b []byte, n int, fix, b16, b32 byte) []byte {
+	switch {
+	case n <= 15:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, b16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, b32), uint32(n))
+	}
+}
+
+/*** leitura ***/
+
+// DecodeMsgpack lê um corpo MessagePack em v, com as mesmas regras do corpo JSON
+// (nomes das tags `json`, campos desconhecidos rejeitados).
+// Binários viram string base64 e o timestamp (extensão -1) vira string RFC 3339.
+func DecodeMsgpack(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	d := &msgpackReader{data: data}
+	t, err := d.value(0)
+	if err != nil {
+		return err
+	}
+	if d.pos != len(d.data) {
+		return fmt.Errorf("%w: %d bytes após o valor", ErrMsgpack, len(d.data)-d.pos)
+	}
+	return fromTree(t, v)
+}
+
+type msgpackReader struct {
+	data []byte
+	pos  int
+}
+
+func (d *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("%w: fim inesperado", ErrMsgpack)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *msgpackReader) uint(size int) (uint64, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// length lê um tamanho de size bytes e recusa valores acima de maxMsgpackLen.
+func (d *msgpackReader) length(size int) (int, error) {
+	n, err := d.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxMsgpackLen {
+		return 0, fmt.Errorf("%w: tamanho %d excede o limite", ErrMsgpack, n)
+	}
+	return int(n), nil
+}
+
+// value lê um valor; depth evita estouro de pilha com aninhamento malicioso.
+func (d *msgpackReader) value(depth int) (any, error) {
+	if depth > 64 {
+		return nil, fmt.Errorf("%w: aninhamento profundo demais", ErrMsgpack)
+	}
+	head, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := head[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return d.str(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.array(int(c&0x0f), depth)
+	case c&0xf0 == 0x80:
+		return d.object(int(c&0x0f), depth)
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.uint(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return u, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		u, err := d.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*size // estende o sinal
+		return int64(u<<shift) >> shift, nil
+	case 0xca:
+		u, err := d.uint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(u))), nil
+	case 0xcb:
+		u, err := d.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(u), nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.length(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.str(n)
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.length(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case 0xdc, 0xdd:
+		n, err := d.length(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.array(n, depth)
+	case 0xde, 0xdf:
+		n, err := d.length(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.object(n, depth)
+	case 0xd6, 0xd7:
+		return d.ext(4<<(c-0xd6), c)
+	case 0xc7:
+		n, err := d.length(1)
+		if err != nil {
+			return nil, err
+		}
+		return d.ext(n, c)
+	}
+	return nil, fmt.Errorf("%w: tipo 0x%02x não suportado", ErrMsgpack, c)
+}
+
+func (d *msgpackReader) str(n int) (any, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *msgpackReader) array(n, depth int) (any, error) {
+	arr := make([]any, 0, min(n, 1024))
+	for range n {
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (d *msgpackReader) object(n, depth int) (any, error) {
+	obj := make(map[string]any, min(n, 1024))
+	for range n {
+		k, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: chave de map deve ser string", ErrMsgpack)
+		}
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = v
+	}
+	return obj, nil
+}
+
+// ext lê uma extensão; só o timestamp (tipo -1, 32/64/96 bits) é suportado.
+func (d *msgpackReader) ext(n int, c byte) (any, error) {
+	typ, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(typ[0]) != -1 {
+		return nil, fmt.Errorf("%w: extensão %d não suportada", ErrMsgpack, int8(typ[0]))
+	}
+	var t time.Time
+	switch n {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(b)), 0)
+	case 8:
+		v := binary.BigEndian.Uint64(b)
+		t = time.Unix(int64(v&0x3ffffffff), int64(v>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(b[4:])), int64(binary.BigEndian.Uint32(b[:4])))
+	default:
+		return nil, fmt.Errorf("%w: timestamp 0x%02x com %d bytes", ErrMsgpack, c, n)
+	}
+	return t.UTC().Format(time.RFC3339Nano), nil
+}
@@ -0,0 +1,234 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// XMLContentType é o media type usado nas respostas XML.
+const XMLContentType = "application/xml"
+
+// ErrXML indica um corpo XML malformado ou que não corresponde ao tipo esperado.
+var ErrXML = errors.New("xml inválido")
+
+// xmlItem é o nome dos elementos de uma lista: {"items":[1,2]} -> <items><item>1</item><item>2</item></items>.
+const xmlItem = "item"
+
+/*** escrita ***/
+
+// EncodeXML escreve v como XML com os nomes de campo do JSON:
+//
+//	<cat><id>1</id><name>Mingau</name><breed>SRD</breed>...</cat>
+//
+// root é o nome do elemento raiz. Campos null são omitidos e listas usam elementos <item>.
+func EncodeXML(w io.Writer, root string, v any) error {
+	t, err := tree(v)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := encodeXMLValue(enc, root, t); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func encodeXMLValue(enc *xml.Encoder, name string, v any) error {
+	if v == nil {
+		return nil
+	}
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case object:
+		for _, m := range v {
+			if err := encodeXMLValue(enc, m.key, m.val); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := encodeXMLValue(enc, xmlItem, item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := enc.EncodeToken(xml.CharData(fmt.Sprint(v))); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+/*** leitura ***/
+
+// xmlNode é um elemento lido do corpo: texto (folha) ou filhos.
+type xmlNode struct {
+	name     string
+	text     string
+	children []*xmlNode
+}
+
+// DecodeXML lê um corpo XML em v. O nome do elemento raiz é livre (ex: <cat>);
+// cada filho corresponde a um campo pela tag `json`. Como XML só tem texto,
+// os valores são convertidos pelo tipo do campo de destino (número, booleano, ...)
+// e então decodificados com as mesmas regras do corpo JSON.
+func DecodeXML(r io.Reader, v any) error {
+	root, err := readXML(xml.NewDecoder(r))
+	if err != nil {
+		return err
+	}
+	t, err := xmlToTree(root, reflect.TypeOf(v), root.name)
+	if err != nil {
+		return err
+	}
+	return fromTree(t, v)
+}
+
+// readXML lê o documento inteiro e devolve o elemento raiz.
+func readXML(dec *xml.Decoder) (*xmlNode, error) {
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrXML, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, fmt.Errorf("%w: mais de um elemento raiz", ErrXML)
+			}
+			if len(stack) > 64 {
+				return nil, fmt.Errorf("%w: aninhamento profundo demais", ErrXML)
+			}
+			n := &xmlNode{name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w: documento vazio", ErrXML)
+	}
+	return root, nil
+}
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// xmlToTree converte o elemento no valor genérico esperado por encoding/json para o tipo t.
+// path identifica o elemento nas mensagens de erro (ex: "cat.age_years").
+func xmlToTree(n *xmlNode, t reflect.Type, path string) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	text := strings.TrimSpace(n.text)
+
+	// tipos com representação em texto (ex: time.Time) seguem como string
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return text, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return n.text, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s deve ser true ou false", ErrXML, path)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, fmt.Errorf("%w: %s deve ser um número", ErrXML, path)
+		}
+		return json.Number(text), nil
+	case reflect.Slice, reflect.Array:
+		arr := make([]any, 0, len(n.children))
+		for i, c := range n.children {
+			v, err := xmlToTree(c, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case reflect.Map:
+		obj := make(map[string]any, len(n.children))
+		for _, c := range n.children {
+			v, err := xmlToTree(c, t.Elem(), path+"."+c.name)
+			if err != nil {
+				return nil, err
+			}
+			obj[c.name] = v
+		}
+		return obj, nil
+	case reflect.Struct:
+		fields := jsonFields(t)
+		obj := make(map[string]any, len(n.children))
+		for _, c := range n.children {
+			ft, ok := fields[c.name]
+			if !ok {
+				// deixa o campo para o encoding/json rejeitar (DisallowUnknownFields)
+				obj[c.name] = c.text
+				continue
+			}
+			v, err := xmlToTree(c, ft, path+"."+c.name)
+			if err != nil {
+				return nil, err
+			}
+			obj[c.name] = v
+		}
+		return obj, nil
+	case reflect.Interface:
+		return n.text, nil
+	}
+	return nil, fmt.Errorf("%w: tipo %s não suportado", ErrXML, t)
+}
+
+// jsonFields mapeia o nome JSON de cada campo exportado da struct para o seu tipo.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
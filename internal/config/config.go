@@ -13,7 +13,30 @@ type Config struct {
 	DBMinConns        int32         // Número mínimo de conexões abertas no banco
 	DBMaxIdleTime     time.Duration // Tempo máximo que uma conexão pode ficar ociosa
 	WorkerConcurrency int32         // Quantidade de workers para processar tarefas em paralelo
+	ThumbnailQueue    int32         // Miniaturas aguardando geração; com a fila cheia, ficam para a varredura
+	ThumbnailSweep    time.Duration // Intervalo da varredura que agenda as miniaturas que faltam (0 desliga)
 	RequestTimeout    time.Duration // Tempo limite para cada requisição HTTP
+	ValidateResponses bool          // Valida (e loga) respostas contra o contrato OpenAPI
+	BodyMaxBytes      int64         // Tamanho máximo do corpo JSON validado pelo contrato (maior = 413)
+	BatchMaxOps       int32         // Máximo de operações aceitas em POST /cats:batch
+	BlobDir           string        // Diretório do blob store local (uploads, relatórios, exportações)
+	JobTimeout        time.Duration // Tempo máximo de execução de um job em background
+	ImportMaxBytes    int64         // Tamanho máximo do arquivo em POST /cats/import
+	VaccinesDueEvery  time.Duration // Intervalo do recálculo do relatório de vacinas a vencer (0 desliga)
+	VaccinesDueDays   int32         // Janela do relatório: reforços que vencem nos próximos N dias
+	BreedPolicy       string        // Raças fora do catálogo: "flag" (aceita e marca) ou "reject" (recusa)
+	OutboxEvery       time.Duration // Intervalo em que o relay procura eventos pendentes na outbox (0 desliga)
+	OutboxBatch       int32         // Eventos lidos da outbox por consulta
+	OutboxRetention   time.Duration // Eventos publicados há mais tempo são apagados (0 mantém)
+	OutboxWebhookURL  string        // Destino webhook dos eventos (vazio desliga)
+	OutboxFile        string        // Arquivo NDJSON onde os eventos são acrescentados (vazio desliga)
+	OutboxChannel     string        // Canal do Postgres NOTIFY dos eventos (vazio desliga, e com ele o stream SSE)
+	WebhookEvery      time.Duration // Intervalo em que as entregas de webhooks pendentes são enviadas (0 desliga)
+	WebhookTimeout    time.Duration // Tempo máximo de cada envio de webhook
+	WebhookBatch      int32         // Entregas reservadas por rodada
+	WebhookAttempts   int32         // Tentativas de cada entrega antes de marcá-la como falha
+	WebhookFailLimit  int32         // Falhas seguidas que desativam a assinatura (0 = nunca)
+	StreamHeartbeat   time.Duration // Intervalo dos comentários de heartbeat em GET /cats/stream
 }
 
 func getEnvString(key, def string) string {
@@ -28,6 +51,16 @@ func getEnvInt32(key, def string) int32 {
 	return int32(val)
 }
 
+func getEnvInt64(key, def string) int64 {
+	val, _ := strconv.ParseInt(getEnvString(key, def), 10, 64)
+	return val
+}
+
+func getEnvBool(key, def string) bool {
+	val, _ := strconv.ParseBool(getEnvString(key, def))
+	return val
+}
+
 func getEnvDuration(key, def string) time.Duration {
 	val, _ := time.ParseDuration(getEnvString(key, def))
 	return val
@@ -41,6 +74,29 @@ func MustLoad() Config {
 		DBMinConns:        getEnvInt32("DB_MIN_CONNS", "2"),
 		DBMaxIdleTime:     getEnvDuration("DB_MAX_IDLE_TIME", "30s"),
 		WorkerConcurrency: getEnvInt32("WORKER_CONCURRENCY", "4"),
+		ThumbnailQueue:    getEnvInt32("THUMBNAIL_QUEUE", "1000"),
+		ThumbnailSweep:    getEnvDuration("THUMBNAIL_SWEEP_EVERY", "1m"),
 		RequestTimeout:    getEnvDuration("REQUEST_TIMEOUT", "10s"),
+		ValidateResponses: getEnvBool("OPENAPI_VALIDATE_RESPONSES", "false"),
+		BodyMaxBytes:      getEnvInt64("BODY_MAX_BYTES", "4194304"), // 4MB
+		BatchMaxOps:       getEnvInt32("BATCH_MAX_OPS", "500"),
+		BlobDir:           getEnvString("BLOB_DIR", "./data/blobs"),
+		JobTimeout:        getEnvDuration("JOB_TIMEOUT", "30m"),
+		ImportMaxBytes:    getEnvInt64("IMPORT_MAX_BYTES", "52428800"), // 50MB
+		VaccinesDueEvery:  getEnvDuration("VACCINES_DUE_EVERY", "1h"),
+		VaccinesDueDays:   getEnvInt32("VACCINES_DUE_DAYS", "30"),
+		BreedPolicy:       getEnvString("BREED_POLICY", "flag"),
+		OutboxEvery:       getEnvDuration("OUTBOX_EVERY", "1s"),
+		OutboxBatch:       getEnvInt32("OUTBOX_BATCH", "100"),
+		OutboxRetention:   getEnvDuration("OUTBOX_RETENTION", "168h"), // 7 dias
+		OutboxWebhookURL:  getEnvString("OUTBOX_WEBHOOK_URL", ""),
+		OutboxFile:        getEnvString("OUTBOX_FILE", ""),
+		OutboxChannel:     getEnvString("OUTBOX_CHANNEL", "cat_events"),
+		WebhookEvery:      getEnvDuration("WEBHOOK_EVERY", "1s"),
+		WebhookTimeout:    getEnvDuration("WEBHOOK_TIMEOUT", "10s"),
+		WebhookBatch:      getEnvInt32("WEBHOOK_BATCH", "50"),
+		WebhookAttempts:   getEnvInt32("WEBHOOK_MAX_ATTEMPTS", "8"),
+		WebhookFailLimit:  getEnvInt32("WEBHOOK_DISABLE_AFTER", "20"),
+		StreamHeartbeat:   getEnvDuration("STREAM_HEARTBEAT", "15s"),
 	}
 }
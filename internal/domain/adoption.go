@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidStatus            = errors.New("invalid cat status transition")           // transição fora da máquina de estados
+	ErrApplicationNotFound      = errors.New("adoption application not found")          // candidatura não existe
+	ErrApplicationDecided       = errors.New("adoption application already decided")    // aprovar/rejeitar uma candidatura que não está pendente
+	ErrApplicationExists        = errors.New("owner already has a pending application") // mesmo tutor com duas candidaturas pendentes para o gato
+	ErrNotAcceptingApplications = errors.New("cat is not accepting applications")       // gato adotado, devolvido ou não adotável
+)
+
+// Status de adoção do gato (coluna cats.status).
+const (
+	StatusAvailable       = "available"        // disponível para adoção (padrão)
+	StatusReserved        = "reserved"         // reservado, ainda sem candidatura formal
+	StatusAdoptionPending = "adoption_pending" // há candidaturas em análise
+	StatusAdopted         = "adopted"          // adotado (candidatura aprovada)
+	StatusReturned        = "returned"         // devolvido pelo tutor após a adoção
+	StatusNotAdoptable    = "not_adoptable"    // fora da adoção (saúde, comportamento, ...)
+)
+
+// CatStatuses lista os status válidos, na ordem do fluxo.
+var CatStatuses = []string{StatusAvailable, StatusReserved, StatusAdoptionPending, StatusAdopted, StatusReturned, StatusNotAdoptable}
+
+// statusTransitions é a máquina de estados da adoção: status atual -> próximos permitidos.
+// adopted só é alcançado a partir de adoption_pending (normalmente pela aprovação de uma candidatura).
+var statusTransitions = map[string][]string{
+	StatusAvailable:       {StatusReserved, StatusAdoptionPending, StatusNotAdoptable},
+	StatusReserved:        {StatusAvailable, StatusAdoptionPending, StatusNotAdoptable},
+	StatusAdoptionPending: {StatusAvailable, StatusReserved, StatusAdopted},
+	StatusAdopted:         {StatusReturned},
+	StatusReturned:        {StatusAvailable, StatusNotAdoptable},
+	StatusNotAdoptable:    {StatusAvailable},
+}
+
+// NextStatuses devolve os status para os quais o gato pode ir a partir de from.
+func NextStatuses(from string) []string {
+	return slices.Clone(statusTransitions[from])
+}
+
+// TransitionError é devolvido quando a transição não é permitida.
+// Allowed traz os próximos status válidos (a API devolve a lista no 409).
+type TransitionError struct {
+	From, To string
+	Allowed  []string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s (allowed: %s)", ErrInvalidStatus, e.From, e.To, strings.Join(e.Allowed, ", "))
+}
+
+// Unwrap permite errors.Is(err, ErrInvalidStatus).
+func (e *TransitionError) Unwrap() error { return ErrInvalidStatus }
+
+// CheckTransition valida a mudança de status from -> to contra a máquina de estados.
+func CheckTransition(from, to string) error {
+	if !slices.Contains(statusTransitions[from], to) {
+		return &TransitionError{From: from, To: to, Allowed: NextStatuses(from)}
+	}
+	return nil
+}
+
+// StatusChange muda o status do gato manualmente (POST /cats/{id}/status).
+type StatusChange struct {
+	Status string  `json:"status" validate:"required,oneof=available reserved adoption_pending adopted returned not_adoptable"`
+	Reason *string `json:"reason" validate:"omitempty,max=512"`
+}
+
+// StatusTransition é uma linha do histórico de status do gato.
+// ApplicationID aponta a candidatura que causou a mudança, quando houver.
+type StatusTransition struct {
+	ID            int64     `json:"id"`
+	CatID         int64     `json:"cat_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Reason        *string   `json:"reason,omitempty"`
+	ApplicationID *int64    `json:"application_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Status de uma candidatura de adoção.
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationRejected = "rejected"
+)
+
+// AdoptionApplication é a candidatura de um tutor (owners) para adotar um gato.
+type AdoptionApplication struct {
+	ID             int64      `json:"id"`
+	CatID          int64      `json:"cat_id"`
+	OwnerID        int64      `json:"owner_id"`
+	Status         string     `json:"status"`
+	Message        *string    `json:"message,omitempty"`
+	DecisionReason *string    `json:"decision_reason,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ApplicationCreate registra uma candidatura (POST /cats/{id}/applications).
+type ApplicationCreate struct {
+	OwnerID int64   `json:"owner_id" validate:"required,gt=0"`
+	Message *string `json:"message" validate:"omitempty,max=2000"`
+}
+
+// ApplicationDecision é o corpo de approve/reject (o motivo é opcional).
+type ApplicationDecision struct {
+	Reason *string `json:"reason" validate:"omitempty,max=512"`
+}
+
+// ApplicationFilter são os filtros da listagem de candidaturas. Campos nil não filtram.
+type ApplicationFilter struct {
+	CatID   *int64
+	OwnerID *int64
+	Status  *string
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{StatusAvailable, StatusReserved, true},
+		{StatusAvailable, StatusAdoptionPending, true},
+		{StatusAvailable, StatusNotAdoptable, true},
+		{StatusAvailable, StatusAdopted, false}, // adopted só a partir de adoption_pending
+		{StatusAvailable, StatusAvailable, false},
+		{StatusReserved, StatusAvailable, true},
+		{StatusReserved, StatusAdopted, false},
+		{StatusAdoptionPending, StatusAdopted, true},
+		{StatusAdoptionPending, StatusNotAdoptable, false},
+		{StatusAdopted, StatusReturned, true},
+		{StatusAdopted, StatusAvailable, false},
+		{StatusReturned, StatusAvailable, true},
+		{StatusReturned, StatusAdopted, false},
+		{StatusNotAdoptable, StatusAvailable, true},
+		{StatusNotAdoptable, StatusReserved, false},
+		{"lost", StatusAvailable, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			err := CheckTransition(tt.from, tt.to)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("err = %v", err)
+				}
+				return
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) || !errors.Is(err, ErrInvalidStatus) {
+				t.Fatalf("err = %v, want *TransitionError", err)
+			}
+			if te.From != tt.from || te.To != tt.to || !slices.Equal(te.Allowed, NextStatuses(tt.from)) {
+				t.Errorf("TransitionError = %+v", te)
+			}
+		})
+	}
+}
+
+// Todo status é alcançável e tem saída: nenhum gato fica preso.
+func TestStatusTransitionsCoverAllStatuses(t *testing.T) {
+	reached := map[string]bool{StatusAvailable: true}
+	for _, from := range CatStatuses {
+		next := NextStatuses(from)
+		if len(next) == 0 {
+			t.Errorf("%s has no way out", from)
+		}
+		for _, to := range next {
+			if !slices.Contains(CatStatuses, to) {
+				t.Errorf("%s -> unknown status %s", from, to)
+			}
+			reached[to] = true
+		}
+	}
+	for _, s := range CatStatuses {
+		if !reached[s] {
+			t.Errorf("%s is unreachable", s)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+/*
+Auditoria: toda criação, alteração e remoção de gatos, tutores, notas e etiquetas fica
+em audit_events, gravada por trigger na mesma transação da mudança. Cada evento guarda
+quem fez (Requester, levado pelo contexto da requisição até a transação) e o
+antes/depois: a linha inteira na criação e na remoção, só as colunas que mudaram na
+alteração.
+*/
+
+// Requester identifica quem fez uma requisição (registrado nas consultas por microchip e na auditoria).
+type Requester struct {
+	Actor     string // header X-Actor (vazio se não informado)
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type requesterKey struct{}
+
+// WithRequester devolve um contexto com quem fez a requisição (o middleware HTTP preenche).
+func WithRequester(ctx context.Context, r Requester) context.Context {
+	return context.WithValue(ctx, requesterKey{}, r)
+}
+
+// RequesterFrom devolve quem fez a requisição (vazio fora de uma requisição, ex: jobs agendados).
+func RequesterFrom(ctx context.Context) Requester {
+	r, _ := ctx.Value(requesterKey{}).(Requester)
+	return r
+}
+
+// Ações registradas na auditoria.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// Entidades auditadas (o argumento de audit_table em cada migração).
+const (
+	AuditEntityCat   = "cat"   // cats
+	AuditEntityOwner = "owner" // owners
+	AuditEntityNote  = "note"  // cat_notes
+	AuditEntityTag   = "tag"   // tags
+)
+
+// AuditEntities lista as entidades auditadas (filtro entity de GET /audit).
+var AuditEntities = []string{AuditEntityCat, AuditEntityOwner, AuditEntityNote, AuditEntityTag}
+
+// AuditEvent é uma mudança registrada em audit_events.
+type AuditEvent struct {
+	ID        int64          `json:"id"`
+	Entity    string         `json:"entity"`
+	EntityID  int64          `json:"entity_id"`
+	Action    string         `json:"action"`
+	Actor     *string        `json:"actor,omitempty"`
+	RequestID *string        `json:"request_id,omitempty"`
+	IP        *string        `json:"ip,omitempty"`
+	UserAgent *string        `json:"user_agent,omitempty"`
+	Before    map[string]any `json:"before,omitempty"` // ausente na criação
+	After     map[string]any `json:"after,omitempty"`  // ausente na remoção
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AuditFilter filtra os eventos de auditoria; campos nil não filtram.
+type AuditFilter struct {
+	Entity    *string
+	EntityID  *int64
+	Action    *string
+	Actor     *string
+	RequestID *string
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+}
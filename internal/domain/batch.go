@@ -0,0 +1,56 @@
+package domain
+
+import "fmt"
+
+// Operações em lote sobre gatos (POST /cats:batch).
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+
+	// BatchAtomic executa tudo em uma transação: qualquer falha desfaz todas as operações.
+	BatchAtomic = "atomic"
+	// BatchPartial executa cada operação de forma independente e reporta o resultado de cada uma.
+	BatchPartial = "partial"
+)
+
+// CatBatchOp é uma operação do lote.
+// - create: usa Cat.
+// - update: usa ID, Changes e opcionalmente Version (0 = sem checagem otimista).
+// - delete: usa ID e opcionalmente Version.
+type CatBatchOp struct {
+	Op      string     `json:"op" validate:"required,oneof=create update delete"`
+	ID      int64      `json:"id,omitempty" validate:"required_unless=Op create,omitempty,gte=1"`
+	Version int64      `json:"version,omitempty" validate:"omitempty,gte=1"`
+	Cat     *CatCreate `json:"cat,omitempty" validate:"required_if=Op create"`
+	Changes *CatUpdate `json:"changes,omitempty" validate:"required_if=Op update"`
+}
+
+type CatBatchRequest struct {
+	Mode       string       `json:"mode" validate:"omitempty,oneof=atomic partial"` // padrão: atomic
+	Operations []CatBatchOp `json:"operations" validate:"required,min=1,dive"`
+}
+
+// CatBatchResult é o resultado de uma operação, na mesma posição (Index) do pedido.
+// Err é nil em caso de sucesso; Cat vem preenchido em create/update bem-sucedidos.
+type CatBatchResult struct {
+	Index int
+	Op    string
+	Cat   *Cat
+	Err   error
+}
+
+// BatchError indica qual operação de um lote atômico falhou (e desfez o lote inteiro).
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operação %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
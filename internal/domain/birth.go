@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidBirthDate indica uma data de nascimento no futuro, acima da idade máxima
+// ou uma precisão informada sem a data.
+var ErrInvalidBirthDate = errors.New("invalid birth date")
+
+/*
+A idade do gato não é mais gravada: o banco guarda birth_date e a precisão dela,
+e age_years/age_months são calculados a cada leitura. Quem só sabe a idade
+continua enviando age_years; a data vira uma estimativa (hoje menos N anos).
+*/
+
+// Precisão da data de nascimento.
+const (
+	BirthExact     = "exact"     // dia conhecido
+	BirthMonth     = "month"     // só mês e ano (gravada no dia 1º)
+	BirthYear      = "year"      // só o ano (gravada em 1º de janeiro)
+	BirthEstimated = "estimated" // aproximada, derivada de age_years
+)
+
+// BirthPrecisions lista as precisões aceitas.
+var BirthPrecisions = []string{BirthExact, BirthMonth, BirthYear, BirthEstimated}
+
+// MaxAgeYears é a idade máxima aceita, a mesma regra lte=40 de age_years.
+const MaxAgeYears = 40
+
+// AgeOn calcula a idade em anos completos e em meses completos (total) na data today.
+// Datas no futuro resultam em idade zero.
+func AgeOn(birth, today Date) (years, months int) {
+	by, bm, bd := birth.t.Date()
+	ty, tm, td := today.t.Date()
+	months = (ty-by)*12 + int(tm-bm)
+	if td < bd {
+		months--
+	}
+	if months < 0 {
+		return 0, 0
+	}
+	return months / 12, months
+}
+
+// EstimateBirthDate devolve a data de nascimento estimada de um gato com ageYears anos em today.
+func EstimateBirthDate(ageYears int, today Date) Date {
+	return Date{today.t.AddDate(-ageYears, 0, 0)}
+}
+
+// resolveBirth ajusta a data à precisão (dia 1º para month, 1º de janeiro para year)
+// e confere se a idade resultante fica entre 0 e MaxAgeYears.
+func resolveBirth(birth Date, precision *string, today Date) (Date, string, error) {
+	p := BirthExact
+	if precision != nil {
+		p = *precision
+	}
+	y, m, _ := birth.t.Date()
+	switch p {
+	case BirthMonth:
+		birth = Date{time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)}
+	case BirthYear:
+		birth = Date{time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	if birth.After(today) {
+		return Date{}, "", fmt.Errorf("%w: birth_date no futuro", ErrInvalidBirthDate)
+	}
+	if years, _ := AgeOn(birth, today); years > MaxAgeYears {
+		return Date{}, "", fmt.Errorf("%w: idade acima de %d anos", ErrInvalidBirthDate, MaxAgeYears)
+	}
+	return birth, p, nil
+}
+
+// ResolveBirth devolve a data de nascimento e a precisão a gravar na criação:
+// birth_date (ajustada à precisão) quando informada, senão a estimativa a partir de age_years.
+func (in CatCreate) ResolveBirth(today Date) (Date, string, error) {
+	if in.BirthDate != nil {
+		return resolveBirth(*in.BirthDate, in.BirthDatePrecision, today)
+	}
+	if in.BirthDatePrecision != nil {
+		return Date{}, "", fmt.Errorf("%w: birth_date_precision sem birth_date", ErrInvalidBirthDate)
+	}
+	return EstimateBirthDate(in.AgeYears, today), BirthEstimated, nil
+}
+
+// ResolveBirth devolve a data de nascimento e a precisão a gravar na atualização
+// (nil mantém as atuais): birth_date tem prioridade sobre age_years.
+func (in CatUpdate) ResolveBirth(today Date) (*Date, *string, error) {
+	switch {
+	case in.BirthDate != nil:
+		birth, p, err := resolveBirth(*in.BirthDate, in.BirthDatePrecision, today)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &birth, &p, nil
+	case in.BirthDatePrecision != nil:
+		return nil, nil, fmt.Errorf("%w: birth_date_precision sem birth_date", ErrInvalidBirthDate)
+	case in.AgeYears != nil:
+		birth, p := EstimateBirthDate(*in.AgeYears, today), BirthEstimated
+		return &birth, &p, nil
+	}
+	return nil, nil, nil
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func date(t *testing.T, s string) Date {
+	t.Helper()
+	d, err := ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestAgeOn(t *testing.T) {
+	tests := []struct {
+		name          string
+		birth, today  string
+		years, months int
+	}{
+		{"aniversário", "2020-03-15", "2026-03-15", 6, 72},
+		{"véspera do aniversário", "2020-03-15", "2026-03-14", 5, 71},
+		{"mesmo dia", "2026-03-15", "2026-03-15", 0, 0},
+		{"mês seguinte mais curto", "2026-01-31", "2026-02-28", 0, 0},
+		{"29 de fevereiro em ano não bissexto", "2024-02-29", "2025-02-28", 0, 11},
+		{"29 de fevereiro, 1º de março", "2024-02-29", "2025-03-01", 1, 12},
+		{"no futuro", "2027-01-01", "2026-03-15", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			years, months := AgeOn(date(t, tt.birth), date(t, tt.today))
+			if years != tt.years || months != tt.months {
+				t.Errorf("AgeOn = %d anos, %d meses; want %d, %d", years, months, tt.years, tt.months)
+			}
+		})
+	}
+}
+
+func TestCatCreateResolveBirth(t *testing.T) {
+	today := date(t, "2026-10-18")
+	precision := func(p string) *string { return &p }
+	birth := func(s string) *Date { d := date(t, s); return &d }
+
+	tests := []struct {
+		name      string
+		in        CatCreate
+		want      string
+		precision string
+		wantErr   bool
+	}{
+		{"data exata", CatCreate{BirthDate: birth("2020-05-10")}, "2020-05-10", BirthExact, false},
+		{"só mês", CatCreate{BirthDate: birth("2020-05-10"), BirthDatePrecision: precision(BirthMonth)}, "2020-05-01", BirthMonth, false},
+		{"só ano", CatCreate{BirthDate: birth("2020-05-10"), BirthDatePrecision: precision(BirthYear)}, "2020-01-01", BirthYear, false},
+		{"ano atual com data adiante", CatCreate{BirthDate: birth("2026-12-01"), BirthDatePrecision: precision(BirthYear)}, "2026-01-01", BirthYear, false},
+		{"data tem prioridade sobre a idade", CatCreate{AgeYears: 9, BirthDate: birth("2020-05-10")}, "2020-05-10", BirthExact, false},
+		{"estimada pela idade", CatCreate{AgeYears: 3}, "2023-10-18", BirthEstimated, false},
+		{"idade máxima", CatCreate{BirthDate: birth("1986-10-18")}, "1986-10-18", BirthExact, false},
+		{"acima da idade máxima", CatCreate{BirthDate: birth("1985-10-18")}, "", "", true},
+		{"no futuro", CatCreate{BirthDate: birth("2026-10-19")}, "", "", true},
+		{"precisão sem data", CatCreate{AgeYears: 3, BirthDatePrecision: precision(BirthMonth)}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, p, err := tt.in.ResolveBirth(today)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBirthDate) {
+					t.Fatalf("err = %v, want ErrInvalidBirthDate", err)
+				}
+				return
+			}
+			if err != nil || got.String() != tt.want || p != tt.precision {
+				t.Errorf("ResolveBirth = %s, %q, %v; want %s, %q", got, p, err, tt.want, tt.precision)
+			}
+		})
+	}
+}
+
+func TestCatUpdateResolveBirth(t *testing.T) {
+	today := date(t, "2026-10-18")
+	age := 2
+	month := BirthMonth
+	birth := date(t, "2021-07-20")
+
+	tests := []struct {
+		name      string
+		in        CatUpdate
+		want      string // vazio: mantém a data atual
+		precision string
+		wantErr   bool
+	}{
+		{"sem mudança", CatUpdate{}, "", "", false},
+		{"estimada pela idade", CatUpdate{AgeYears: &age}, "2024-10-18", BirthEstimated, false},
+		{"data tem prioridade sobre a idade", CatUpdate{AgeYears: &age, BirthDate: &birth, BirthDatePrecision: &month}, "2021-07-01", BirthMonth, false},
+		{"precisão sem data", CatUpdate{BirthDatePrecision: &month}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, p, err := tt.in.ResolveBirth(today)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBirthDate) {
+					t.Fatalf("err = %v, want ErrInvalidBirthDate", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if got != nil || p != nil {
+					t.Errorf("ResolveBirth = %v, %v; want nil, nil", got, p)
+				}
+				return
+			}
+			if got == nil || p == nil || got.String() != tt.want || *p != tt.precision {
+				t.Errorf("ResolveBirth = %v, %v; want %s, %q", got, p, tt.want, tt.precision)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "errors"
+
+var (
+	ErrBreedNotFound = errors.New("breed not found")
+	ErrUnknownBreed  = errors.New("unknown breed")
+)
+
+/*
+Catálogo de raças (tabela breeds). Cada raça tem um nome canônico, traduções
+e apelidos; "srd", "Vira-lata" e "sem raça definida" levam todos à raça "SRD".
+A comparação ignora maiúsculas, acentos e pontuação (função breed_key no banco).
+
+Na criação/atualização de gatos, o breed informado é trocado pelo nome canônico
+e o gato passa a apontar para o catálogo (breed_id). O que fazer com raças que
+não estão no catálogo depende da política configurada (BREED_POLICY).
+*/
+
+// Políticas para raças fora do catálogo.
+const (
+	BreedPolicyFlag   = "flag"   // aceita o texto como veio; o gato fica com breed_unrecognized=true
+	BreedPolicyReject = "reject" // recusa o gato (ErrUnknownBreed)
+)
+
+// Breed é uma raça do catálogo.
+type Breed struct {
+	ID           int64             `json:"id"`
+	Slug         string            `json:"slug"`
+	Name         string            `json:"name"`                   // nome canônico, gravado em cats.breed
+	DisplayName  string            `json:"display_name,omitempty"` // nome no idioma do Accept-Language
+	Match        string            `json:"match,omitempty"`        // nome/apelido que casou com o prefixo da busca
+	Translations map[string]string `json:"translations"`           // idioma -> nome
+	Aliases      []string          `json:"aliases"`
+}
+
+// BreedCount é a quantidade de gatos de uma raça do catálogo.
+type BreedCount struct {
+	BreedID     int64    `json:"breed_id"`
+	Name        string   `json:"name"`
+	Cats        int      `json:"cats"`
+	Available   int      `json:"available"`               // gatos com status available
+	AvgWeightKG *float64 `json:"avg_weight_kg,omitempty"` // nil quando nenhum gato tem peso
+}
+
+// UnrecognizedBreed agrupa gatos cuja raça não foi reconhecida (candidatas a novos apelidos).
+type UnrecognizedBreed struct {
+	Breed string `json:"breed"`
+	Cats  int    `json:"cats"`
+}
+
+// BreedStats resume a distribuição dos gatos por raça.
+type BreedStats struct {
+	Breeds       []BreedCount        `json:"breeds"`       // raças do catálogo, da mais comum para a menos comum
+	Unrecognized []UnrecognizedBreed `json:"unrecognized"` // textos fora do catálogo
+	WithoutBreed int                 `json:"without_breed"`
+}
@@ -12,30 +12,112 @@ omitempty: omite o campo no JSON se estiver vazio/nulo.
 */
 
 type Cat struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name" validate:"required,min=2,max=64"`
+	BirthDate          Date      `json:"birth_date"`
+	BirthDatePrecision string    `json:"birth_date_precision"` // exact, month, year ou estimated
+	AgeYears           int       `json:"age_years"`            // calculada a partir de birth_date na leitura
+	AgeMonths          int       `json:"age_months"`           // idade total em meses, também calculada
+	Breed              *string   `json:"breed,omitempty"`
+	BreedID            *int64    `json:"breed_id,omitempty"`           // raça do catálogo (nil se não reconhecida)
+	BreedUnrecognized  bool      `json:"breed_unrecognized,omitempty"` // breed informado fora do catálogo
+	CoatColor          *string   `json:"coat_color,omitempty"`         // descrição livre
+	CoatColors         []string  `json:"coat_colors,omitempty"`        // cores de CoatColors
+	CoatPattern        *string   `json:"coat_pattern,omitempty"`       // padrão de CoatPatterns
+	WeightKG           *float64  `json:"weight_kg,omitempty" validate:"omitempty,gte=0,lte=50"`
+	Microchip          *string   `json:"microchip,omitempty"` // 15 dígitos (ISO 11784/11785), único
+	SireID             *int64    `json:"sire_id,omitempty"`   // pai (ver pedigree.go)
+	DamID              *int64    `json:"dam_id,omitempty"`    // mãe
+	LitterID           *int64    `json:"litter_id,omitempty"` // ninhada
+	Status             string    `json:"status"`              // status de adoção (ver CatStatuses); muda só por transições
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Version            int64     `json:"version"` // incrementada a cada atualização (usada no ETag)
+
+	Attributes map[string]any `json:"attributes,omitempty"` // atributos personalizados (ver tag.go)
+
+	Thumbnails []CatThumbnail `json:"thumbnails,omitempty"` // preenchido apenas com include=thumbnails
+	Tags       []string       `json:"tags,omitempty"`       // preenchido apenas com include=tags
+}
+
+// CatThumbnail é uma miniatura gerada para o gato (tabela cat_thumbnails).
+type CatThumbnail struct {
 	ID        int64     `json:"id"`
-	Name      string    `json:"name" validate:"required,min=2,max=64"`
-	AgeYears  int       `json:"age_years" validate:"gte=0,lte=40"`
-	Breed     *string   `json:"breed,omitempty"`
-	CoatColor *string   `json:"coat_color,omitempty"`
-	WeightKG  *float64  `json:"weight_kg,omitempty" validate:"omitempty,gte=0,lte=50"`
+	Path      string    `json:"path"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CatFields são os campos que podem ser escolhidos com fields= (sparse fieldsets), na ordem padrão.
+var CatFields = []string{"id", "name", "birth_date", "birth_date_precision", "age_years", "age_months", "breed", "breed_id", "breed_unrecognized", "coat_color", "coat_colors", "coat_pattern", "weight_kg", "microchip", "sire_id", "dam_id", "litter_id", "attributes", "status", "created_at", "updated_at", "version"}
+
+// Relações que podem ser embutidas na resposta com include=.
+const (
+	CatIncludeThumbnails = "thumbnails" // miniaturas do gato
+	CatIncludeTags       = "tags"       // nomes das etiquetas do gato
+)
+
+// CatProjection escolhe o que é lido do banco: as colunas (fields=) e as relações embutidas (include=).
+// O valor zero lê todos os campos e nenhuma relação.
+type CatProjection struct {
+	Fields     []string // vazio = todos os campos de CatFields
+	Thumbnails bool     // include=thumbnails
+	Tags       bool     // include=tags
 }
 
 // Para criação/atualização parciais
 
+// A data de nascimento vem de birth_date (com a precisão, padrão exact) ou, na falta dela,
+// é estimada a partir de age_years (ver ResolveBirth).
 type CatCreate struct {
-	Name      string   `json:"name" validate:"required,min=2,max=64"`
-	AgeYears  int      `json:"age_years" validate:"gte=0,lte=40"`
-	Breed     *string  `json:"breed"`
-	CoatColor *string  `json:"coat_color"`
-	WeightKG  *float64 `json:"weight_kg" validate:"omitempty,gte=0,lte=50"`
+	Name               string   `json:"name" validate:"required,min=2,max=64"`
+	AgeYears           int      `json:"age_years" validate:"gte=0,lte=40"`
+	BirthDate          *Date    `json:"birth_date"`
+	BirthDatePrecision *string  `json:"birth_date_precision" validate:"omitempty,oneof=exact month year estimated"`
+	Breed              *string  `json:"breed"`
+	BreedID            *int64   `json:"-"` // preenchido pelo serviço ao normalizar breed
+	CoatColor          *string  `json:"coat_color"`
+	CoatColors         []string `json:"coat_colors" validate:"omitempty,max=3,unique,dive,oneof=black white gray orange cream brown cinnamon lilac fawn silver"`
+	CoatPattern        *string  `json:"coat_pattern" validate:"omitempty,oneof=solid tabby tortoiseshell calico bicolor pointed"`
+	WeightKG           *float64 `json:"weight_kg" validate:"omitempty,gte=0,lte=50"`
+	Microchip          *string  `json:"microchip"` // aceita espaços, hífens e pontos (ver NormalizeMicrochip)
+	SireID             *int64   `json:"sire_id" validate:"omitempty,gte=1"`
+	DamID              *int64   `json:"dam_id" validate:"omitempty,gte=1"`
+	LitterID           *int64   `json:"litter_id" validate:"omitempty,gte=1"` // pais não informados vêm da ninhada
+
+	Attributes map[string]any `json:"attributes"` // conferidos contra o esquema do tenant (AttributeSchema.Check)
 }
 
+// CatUpdate usa ponteiros: campos ausentes (nil) mantêm o valor atual no banco.
 type CatUpdate struct {
-	Name      *string  `json:"name" validate:"required,min=2,max=64"`
-	AgeYears  *int     `json:"age_years" validate:"gte=0,lte=40"`
-	Breed     *string  `json:"breed"`
-	CoatColor *string  `json:"coat_color"`
-	WeightKG  *float64 `json:"weight_kg" validate:"omitempty,gte=0,lte=50"`
+	Name               *string  `json:"name" validate:"omitempty,min=2,max=64"`
+	AgeYears           *int     `json:"age_years" validate:"omitempty,gte=0,lte=40"`
+	BirthDate          *Date    `json:"birth_date"`
+	BirthDatePrecision *string  `json:"birth_date_precision" validate:"omitempty,oneof=exact month year estimated"`
+	Breed              *string  `json:"breed"`
+	BreedID            *int64   `json:"-"` // preenchido pelo serviço ao normalizar breed
+	CoatColor          *string  `json:"coat_color"`
+	CoatColors         []string `json:"coat_colors" validate:"omitempty,max=3,unique,dive,oneof=black white gray orange cream brown cinnamon lilac fawn silver"` // nil mantém; [] limpa
+	CoatPattern        *string  `json:"coat_pattern" validate:"omitempty,oneof=solid tabby tortoiseshell calico bicolor pointed"`
+	WeightKG           *float64 `json:"weight_kg" validate:"omitempty,gte=0,lte=50"`
+	Microchip          *string  `json:"microchip"`                            // aceita espaços, hífens e pontos (ver NormalizeMicrochip)
+	SireID             *int64   `json:"sire_id" validate:"omitempty,gte=0"`   // 0 remove o pai
+	DamID              *int64   `json:"dam_id" validate:"omitempty,gte=0"`    // 0 remove a mãe
+	LitterID           *int64   `json:"litter_id" validate:"omitempty,gte=0"` // 0 tira da ninhada
+
+	Attributes map[string]any `json:"attributes"` // mescla com os atuais; null remove a chave
+}
+
+// CatFilter são os filtros aceitos na listagem e na exportação de gatos.
+// Campos nil não filtram.
+type CatFilter struct {
+	Name   *string // busca parcial, sem diferenciar maiúsculas/minúsculas
+	Breed  *string // raça exata (nome, tradução ou apelido do catálogo), sem diferenciar maiúsculas/minúsculas
+	Status *string // status de adoção exato
+
+	Coat    []string // cores da pelagem: o gato precisa ter todas
+	Pattern []string // padrões da pelagem: qualquer um deles
+
+	Tags       []string          // etiquetas, sem diferenciar maiúsculas
+	TagMatch   string            // TagMatchAny (padrão: qualquer uma das etiquetas) ou TagMatchAll (todas)
+	Attributes []AttributeFilter // atributos: o gato precisa atender a todos
 }
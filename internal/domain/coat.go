@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidCoat indica cores incompatíveis com o padrão da pelagem (ex: solid com duas cores).
+var ErrInvalidCoat = errors.New("invalid coat")
+
+/*
+Pelagem estruturada: até três cores (coat_colors) e um padrão (coat_pattern),
+ambos de vocabulário controlado, para que a busca seja confiável.
+coat_color continua existindo como descrição livre ("tigrado laranja com peito branco").
+*/
+
+// Cores da pelagem.
+const (
+	CoatBlack    = "black"
+	CoatWhite    = "white"
+	CoatGray     = "gray" // inclui o "azul" (diluição do preto)
+	CoatOrange   = "orange"
+	CoatCream    = "cream"
+	CoatBrown    = "brown"
+	CoatCinnamon = "cinnamon"
+	CoatLilac    = "lilac"
+	CoatFawn     = "fawn"
+	CoatSilver   = "silver"
+)
+
+// CoatColors lista as cores aceitas.
+var CoatColors = []string{CoatBlack, CoatWhite, CoatGray, CoatOrange, CoatCream, CoatBrown, CoatCinnamon, CoatLilac, CoatFawn, CoatSilver}
+
+// Padrões da pelagem.
+const (
+	PatternSolid         = "solid"         // uma cor só
+	PatternTabby         = "tabby"         // tigrado/rajado
+	PatternTortoiseshell = "tortoiseshell" // escaminha: duas cores mescladas
+	PatternCalico        = "calico"        // tricolor: branco com duas outras cores
+	PatternBicolor       = "bicolor"       // duas cores (ex: frajola)
+	PatternPointed       = "pointed"       // extremidades mais escuras (ex: siamês)
+)
+
+// CoatPatterns lista os padrões aceitos.
+var CoatPatterns = []string{PatternSolid, PatternTabby, PatternTortoiseshell, PatternCalico, PatternBicolor, PatternPointed}
+
+// checkCoat confere se as cores combinam com o padrão. Sem cores ou sem padrão não há o que conferir.
+func checkCoat(colors []string, pattern *string) error {
+	if len(colors) == 0 || pattern == nil {
+		return nil
+	}
+	switch *pattern {
+	case PatternSolid:
+		if len(colors) != 1 {
+			return fmt.Errorf("%w: %s tem exatamente uma cor", ErrInvalidCoat, *pattern)
+		}
+	case PatternBicolor:
+		if len(colors) != 2 {
+			return fmt.Errorf("%w: %s tem exatamente duas cores", ErrInvalidCoat, *pattern)
+		}
+	case PatternTortoiseshell:
+		if len(colors) < 2 {
+			return fmt.Errorf("%w: %s tem pelo menos duas cores", ErrInvalidCoat, *pattern)
+		}
+	case PatternCalico:
+		if len(colors) != 3 || !slices.Contains(colors, CoatWhite) {
+			return fmt.Errorf("%w: %s tem três cores, uma delas white", ErrInvalidCoat, *pattern)
+		}
+	}
+	return nil
+}
+
+// CheckCoat confere a combinação de coat_colors e coat_pattern na criação.
+func (in CatCreate) CheckCoat() error {
+	return checkCoat(in.CoatColors, in.CoatPattern)
+}
+
+// CheckCoat confere a combinação quando a atualização traz cores e padrão juntos.
+func (in CatUpdate) CheckCoat() error {
+	return checkCoat(in.CoatColors, in.CoatPattern)
+}
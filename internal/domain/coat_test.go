@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCoat(t *testing.T) {
+	pat := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		colors  []string
+		pattern *string
+		wantErr bool
+	}{
+		{"sem cores", nil, pat(PatternCalico), false},
+		{"sem padrão", []string{CoatBlack, CoatWhite, CoatOrange}, nil, false},
+
+		{"solid com uma cor", []string{CoatBlack}, pat(PatternSolid), false},
+		{"solid com duas cores", []string{CoatBlack, CoatWhite}, pat(PatternSolid), true},
+
+		{"bicolor com duas cores", []string{CoatBlack, CoatWhite}, pat(PatternBicolor), false},
+		{"bicolor com uma cor", []string{CoatBlack}, pat(PatternBicolor), true},
+		{"bicolor com três cores", []string{CoatBlack, CoatWhite, CoatGray}, pat(PatternBicolor), true},
+
+		{"tortoiseshell com duas cores", []string{CoatBlack, CoatOrange}, pat(PatternTortoiseshell), false},
+		{"tortoiseshell com três cores", []string{CoatBlack, CoatOrange, CoatCream}, pat(PatternTortoiseshell), false},
+		{"tortoiseshell com uma cor", []string{CoatBlack}, pat(PatternTortoiseshell), true},
+
+		{"calico com três cores e white", []string{CoatWhite, CoatBlack, CoatOrange}, pat(PatternCalico), false},
+		{"calico com white em qualquer posição", []string{CoatOrange, CoatBlack, CoatWhite}, pat(PatternCalico), false},
+		{"calico com três cores sem white", []string{CoatBlack, CoatOrange, CoatCream}, pat(PatternCalico), true},
+		{"calico com duas cores", []string{CoatWhite, CoatOrange}, pat(PatternCalico), true},
+
+		{"tabby não confere cores", []string{CoatOrange, CoatCream, CoatWhite}, pat(PatternTabby), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCoat(tt.colors, tt.pattern)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCoat) {
+					t.Fatalf("err = %v, want ErrInvalidCoat", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("checkCoat(%v) = %v", tt.colors, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout é o formato de Date no JSON e na query string (ISO 8601, sem horário).
+const DateLayout = "2006-01-02"
+
+// Date é uma data sem horário (coluna DATE do Postgres), serializada como "2006-01-02".
+// Usada em períodos (ex: início e fim de uma posse), onde o horário não tem significado.
+type Date struct {
+	t time.Time // sempre meia-noite UTC
+}
+
+// NewDate trunca t para a data (no fuso de t).
+func NewDate(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today é a data atual.
+func Today() Date {
+	return NewDate(time.Now())
+}
+
+// ParseDate lê uma data no formato "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("data inválida %q (use AAAA-MM-DD)", s)
+	}
+	return Date{t}, nil
+}
+
+func (d Date) Time() time.Time        { return d.t }
+func (d Date) IsZero() bool           { return d.t.IsZero() }
+func (d Date) Before(o Date) bool     { return d.t.Before(o.t) }
+func (d Date) After(o Date) bool      { return d.t.After(o.t) }
+func (d Date) AddDays(n int) Date     { return Date{d.t.AddDate(0, 0, n)} }
+func (d Date) String() string         { return d.t.Format(DateLayout) }
+func (Date) JSONSchemaFormat() string { return "date" } // o contrato OpenAPI descreve Date como string "date"
+
+// MarshalText/UnmarshalText cobrem XML e query strings; MarshalJSON/UnmarshalJSON, o JSON.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(b []byte) error {
+	parsed, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("data deve ser uma string AAAA-MM-DD")
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Scan e Value permitem ler e gravar Date direto em colunas DATE (database/sql e pgx).
+func (d *Date) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("domain.Date: tipo %T não suportado", src)
+	}
+	*d = NewDate(t)
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.t, nil
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// Erros de domínio compartilhados entre as camadas.
+// O repositório traduz erros do banco (ex: pgx.ErrNoRows) para estes valores,
+// e o handler HTTP decide o status code a partir deles com errors.Is.
+var (
+	ErrNotFound        = errors.New("cat not found")        // registro não existe
+	ErrVersionMismatch = errors.New("cat version mismatch") // versão informada (If-Match) não é a atual
+)
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrHealthRecordNotFound = errors.New("health record not found")      // registro de saúde não existe (ou é de outro gato)
+	ErrHealthRecordExists   = errors.New("health record already exists") // ex: alergia ao mesmo alérgeno
+	ErrHealthDates          = errors.New("health record dates conflict") // ex: próxima dose antes da aplicação
+)
+
+/*
+Prontuário do gato: vacinas, consultas, medicamentos, alergias e castração.
+Cada tipo de registro tem sua tabela (cat_vaccinations, cat_vet_visits, ...)
+e suas rotas aninhadas em /cats/{id}.
+*/
+
+// Vaccination é uma dose aplicada. NextDueOn é a data do reforço, quando houver.
+type Vaccination struct {
+	ID             int64     `json:"id"`
+	CatID          int64     `json:"cat_id"`
+	Vaccine        string    `json:"vaccine"`
+	AdministeredOn Date      `json:"administered_on"`
+	Batch          *string   `json:"batch,omitempty"` // lote da vacina
+	NextDueOn      *Date     `json:"next_due_on,omitempty"`
+	Vet            *string   `json:"vet,omitempty"`
+	Notes          *string   `json:"notes,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type VaccinationCreate struct {
+	Vaccine        string  `json:"vaccine" validate:"required,min=2,max=128"`
+	AdministeredOn *Date   `json:"administered_on" validate:"required"`
+	Batch          *string `json:"batch" validate:"omitempty,max=64"`
+	NextDueOn      *Date   `json:"next_due_on"`
+	Vet            *string `json:"vet" validate:"omitempty,max=128"`
+	Notes          *string `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// VetVisit é uma consulta veterinária.
+type VetVisit struct {
+	ID        int64     `json:"id"`
+	CatID     int64     `json:"cat_id"`
+	VisitedOn Date      `json:"visited_on"`
+	Reason    string    `json:"reason"`
+	Clinic    *string   `json:"clinic,omitempty"`
+	Vet       *string   `json:"vet,omitempty"`
+	Diagnosis *string   `json:"diagnosis,omitempty"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type VetVisitCreate struct {
+	VisitedOn *Date   `json:"visited_on" validate:"required"`
+	Reason    string  `json:"reason" validate:"required,min=2,max=256"`
+	Clinic    *string `json:"clinic" validate:"omitempty,max=128"`
+	Vet       *string `json:"vet" validate:"omitempty,max=128"`
+	Diagnosis *string `json:"diagnosis" validate:"omitempty,max=2000"`
+	Notes     *string `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// Medication é um tratamento. EndDate nil significa tratamento em curso (ou contínuo).
+type Medication struct {
+	ID        int64     `json:"id"`
+	CatID     int64     `json:"cat_id"`
+	Name      string    `json:"name"`
+	Dosage    *string   `json:"dosage,omitempty"`    // ex: 5 mg
+	Frequency *string   `json:"frequency,omitempty"` // ex: a cada 12h
+	StartDate Date      `json:"start_date"`
+	EndDate   *Date     `json:"end_date,omitempty"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MedicationCreate struct {
+	Name      string  `json:"name" validate:"required,min=2,max=128"`
+	Dosage    *string `json:"dosage" validate:"omitempty,max=64"`
+	Frequency *string `json:"frequency" validate:"omitempty,max=64"`
+	StartDate *Date   `json:"start_date" validate:"required"`
+	EndDate   *Date   `json:"end_date"`
+	Notes     *string `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// Gravidade de uma alergia.
+const (
+	AllergyMild     = "mild"
+	AllergyModerate = "moderate"
+	AllergySevere   = "severe"
+)
+
+// Allergy é uma alergia conhecida (um registro por alérgeno).
+type Allergy struct {
+	ID        int64     `json:"id"`
+	CatID     int64     `json:"cat_id"`
+	Allergen  string    `json:"allergen"`
+	Severity  string    `json:"severity"`
+	Reaction  *string   `json:"reaction,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AllergyCreate struct {
+	Allergen string  `json:"allergen" validate:"required,min=2,max=128"`
+	Severity string  `json:"severity" validate:"required,oneof=mild moderate severe"`
+	Reaction *string `json:"reaction" validate:"omitempty,max=512"`
+}
+
+// Neutering é a situação de castração do gato. Neutered nil = não informado.
+type Neutering struct {
+	Neutered   *bool      `json:"neutered,omitempty"`
+	NeuteredOn *Date      `json:"neutered_on,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// NeuteringUpdate informa a castração (PUT /cats/{id}/neutering).
+type NeuteringUpdate struct {
+	Neutered   *bool `json:"neutered" validate:"required"`
+	NeuteredOn *Date `json:"neutered_on"`
+}
+
+// HealthSummary é o resumo do prontuário (GET /cats/{id}/health).
+type HealthSummary struct {
+	CatID             int64         `json:"cat_id"`
+	Neutering         Neutering     `json:"neutering"`
+	Allergies         []Allergy     `json:"allergies"`
+	ActiveMedications []Medication  `json:"active_medications"`
+	Vaccinations      []Vaccination `json:"vaccinations"` // dose mais recente de cada vacina
+	LastVisit         *VetVisit     `json:"last_visit,omitempty"`
+}
+
+// Situação de uma vacina no relatório de vencimentos.
+const (
+	VaccinationOverdue  = "overdue"  // reforço já venceu
+	VaccinationUpcoming = "upcoming" // reforço vence dentro da janela do relatório
+)
+
+// VaccinationDue é uma linha do relatório de vacinas a vencer/vencidas.
+type VaccinationDue struct {
+	CatID         int64  `json:"cat_id"`
+	CatName       string `json:"cat_name"`
+	VaccinationID int64  `json:"vaccination_id"`
+	Vaccine       string `json:"vaccine"`
+	DueOn         Date   `json:"due_on"`
+	Status        string `json:"status"`
+	DaysUntilDue  int    `json:"days_until_due"` // negativo quando vencida
+}
+
+// VaccinationsDueReport é o relatório calculado periodicamente pelo job reports.vaccinations_due.
+// GeneratedAt nil significa que o relatório ainda não foi calculado.
+type VaccinationsDueReport struct {
+	GeneratedAt *time.Time       `json:"generated_at,omitempty"`
+	WindowDays  int              `json:"window_days"`
+	Items       []VaccinationDue `json:"items"`
+}
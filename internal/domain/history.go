@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCatVersionNotFound = errors.New("cat version not found")
+
+/*
+Histórico de versões.
+
+Cada versão de um gato (o número que vai no ETag) fica guardada inteira em
+cats_history, com o período em que foi a atual. Dá para listar as versões,
+ler o gato como ele era em um instante (as_of) e reverter para uma versão
+antiga, o que grava uma versão nova com o conteúdo dela (o histórico não é
+reescrito).
+*/
+
+// CatVersion é uma versão guardada de um gato.
+type CatVersion struct {
+	Version   int64      `json:"version"`
+	ValidFrom time.Time  `json:"valid_from"`         // quando passou a ser a versão atual
+	ValidTo   *time.Time `json:"valid_to,omitempty"` // quando deixou de ser (nil = atual)
+	Cat       Cat        `json:"cat"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+// Status de um job em background.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job é uma tarefa de longa duração executada no worker pool (importação, exportação...).
+// O resultado (relatório, arquivo exportado) fica no blob store em ResultKey.
+type Job struct {
+	ID         int64          `json:"id"`
+	Kind       string         `json:"kind"` // ex: cats.import
+	Status     string         `json:"status"`
+	Params     map[string]any `json:"params,omitempty"`
+	Processed  int            `json:"processed"` // itens lidos até agora
+	Succeeded  int            `json:"succeeded"` // itens aceitos
+	Failed     int            `json:"failed"`    // itens rejeitados
+	Error      *string        `json:"error,omitempty"`
+	ResultKey  *string        `json:"-"`
+	ResultType *string        `json:"-"`                    // Content-Type do resultado
+	ResultURL  string         `json:"result_url,omitempty"` // preenchido pela camada HTTP
+	CreatedAt  time.Time      `json:"created_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// JobProgress são os contadores atualizados durante a execução.
+type JobProgress struct {
+	Processed int
+	Succeeded int
+	Failed    int
+}
+
+// ImportRequest configura uma importação de gatos (POST /cats/import).
+type ImportRequest struct {
+	Format  string            `json:"format"`            // csv ou ndjson
+	DryRun  bool              `json:"dry_run"`           // só valida, não grava
+	Mapping map[string]string `json:"mapping,omitempty"` // coluna de origem -> campo (ou "-" para ignorar)
+}
+
+// ExportRequest configura uma exportação de gatos (GET /cats/export).
+type ExportRequest struct {
+	Format  string    // csv, ndjson ou xlsx
+	Columns []string  // colunas selecionadas (vazio = todas)
+	Filter  CatFilter // mesmos filtros da listagem
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMicrochip  = errors.New("invalid microchip")
+	ErrMicrochipTaken    = errors.New("microchip already registered")
+	ErrMicrochipNotFound = errors.New("no cat with this microchip")
+)
+
+/*
+Microchip no padrão ISO 11784/11785 (FDX-B), lido como 15 dígitos decimais:
+os 3 primeiros são o código do país (ISO 3166 numérico) ou do fabricante (900-998)
+e os 12 restantes o número de identificação.
+
+O número decimal não tem dígito verificador; a checagem confere o que o código
+binário do transponder permite: o código ocupa 10 bits e o número 38 bits, então
+o número não passa de 2^38-1 (274877906943). Código 000, número zero e o código
+999 (transponders de teste) são recusados.
+*/
+
+const (
+	microchipDigits = 15
+	microchipMaxID  = 1<<38 - 1
+	microchipTest   = 999
+)
+
+// NormalizeMicrochip tira espaços, hífens e pontos ("985 141 000 123 456") e valida o número.
+func NormalizeMicrochip(raw string) (string, error) {
+	chip := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.':
+			return -1
+		}
+		return r
+	}, raw)
+	if len(chip) != microchipDigits {
+		return "", fmt.Errorf("%w: %q deve ter %d dígitos", ErrInvalidMicrochip, raw, microchipDigits)
+	}
+	for _, r := range chip {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q deve ter só dígitos", ErrInvalidMicrochip, raw)
+		}
+	}
+	code, _ := strconv.Atoi(chip[:3])
+	id, _ := strconv.ParseInt(chip[3:], 10, 64)
+	switch {
+	case code == 0:
+		return "", fmt.Errorf("%w: código de país/fabricante 000", ErrInvalidMicrochip)
+	case code == microchipTest:
+		return "", fmt.Errorf("%w: código 999 é de transponder de teste", ErrInvalidMicrochip)
+	case id == 0 || id > microchipMaxID:
+		return "", fmt.Errorf("%w: número de identificação fora do intervalo ISO 11784", ErrInvalidMicrochip)
+	}
+	return chip, nil
+}
+
+// MicrochipConflictError indica que o microchip já pertence a outro gato.
+// CatID é zero quando o conflito é com outra operação do mesmo lote.
+type MicrochipConflictError struct {
+	Microchip string
+	CatID     int64
+}
+
+func (e *MicrochipConflictError) Error() string {
+	if e.CatID == 0 {
+		return fmt.Sprintf("%s: %s", ErrMicrochipTaken, e.Microchip)
+	}
+	return fmt.Sprintf("%s: %s (cat %d)", ErrMicrochipTaken, e.Microchip, e.CatID)
+}
+
+// Unwrap permite errors.Is(err, ErrMicrochipTaken).
+func (e *MicrochipConflictError) Unwrap() error { return ErrMicrochipTaken }
+
+// MicrochipLookup é o registro de uma consulta por microchip (GET /cats/lookup), para revisão de privacidade.
+type MicrochipLookup struct {
+	ID        int64     `json:"id"`
+	Microchip string    `json:"microchip"`        // como consultado (normalizado, se válido)
+	CatID     *int64    `json:"cat_id,omitempty"` // gato encontrado
+	Result    string    `json:"result"`           // found, not_found ou invalid
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Resultados de uma consulta por microchip.
+const (
+	LookupFound    = "found"
+	LookupNotFound = "not_found"
+	LookupInvalid  = "invalid"
+)
+
+// MicrochipLookupFilter filtra o registro de consultas. Campos nil não filtram.
+type MicrochipLookupFilter struct {
+	Microchip *string
+	CatID     *int64
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeMicrochip(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"só dígitos", "985141000123456", "985141000123456", false},
+		{"com espaços", "985 141 000 123 456", "985141000123456", false},
+		{"com hífens e pontos", "985-141.000-123.456", "985141000123456", false},
+		{"código de país", "076000000000001", "076000000000001", false},
+		{"maior número ISO 11784", "900274877906943", "900274877906943", false},
+		{"curto", "98514100012345", "", true},
+		{"longo", "9851410001234567", "", true},
+		{"vazio", "", "", true},
+		{"letras", "98514100012345A", "", true},
+		{"código 000", "000141000123456", "", true},
+		{"transponder de teste", "999141000123456", "", true},
+		{"número zero", "985000000000000", "", true},
+		{"número acima de 38 bits", "900274877906944", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMicrochip(tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMicrochip) {
+					t.Fatalf("err = %v, want ErrInvalidMicrochip", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("NormalizeMicrochip(%q) = %q, %v; want %q", tt.raw, got, err, tt.want)
+			}
+		})
+	}
+}
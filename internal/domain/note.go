@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoteNotFound          = errors.New("note not found")
+	ErrInvalidTimelineCursor = errors.New("invalid timeline cursor")
+)
+
+/*
+Anotações e linha do tempo.
+
+Notas são textos livres (markdown, guardado como enviado) que os voluntários
+deixam sobre um gato. Editar o texto gera uma nova versão; as anteriores ficam
+no histórico da nota (GET /cats/{id}/notes/{noteId}/history).
+
+A linha do tempo junta, em ordem cronológica (mais recente primeiro), o que
+aconteceu com o gato a partir das tabelas que já existem: cadastro, última
+alteração, notas e edições, fotos, pesagens e mudanças de status.
+*/
+
+// Note é uma anotação sobre um gato.
+type Note struct {
+	ID        int64     `json:"id"`
+	CatID     int64     `json:"cat_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"` // markdown
+	Pinned    bool      `json:"pinned"`
+	Version   int       `json:"version"`             // 1 + quantidade de edições do texto
+	EditedBy  *string   `json:"edited_by,omitempty"` // quem fez a última edição do texto
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NoteCreate cria uma nota.
+type NoteCreate struct {
+	Author string `json:"author" validate:"required,max=100"`
+	Body   string `json:"body" validate:"required,max=20000"`
+	Pinned bool   `json:"pinned"`
+}
+
+// NoteUpdate altera uma nota; campos nil ficam como estão.
+// Trocar o texto exige Editor (fica no histórico); fixar/desafixar não.
+type NoteUpdate struct {
+	Editor string  `json:"editor" validate:"required_with=Body,max=100"`
+	Body   *string `json:"body" validate:"omitempty,min=1,max=20000"`
+	Pinned *bool   `json:"pinned"`
+}
+
+// NoteRevision é uma versão do texto de uma nota (a versão 1 é a original).
+type NoteRevision struct {
+	Version   int       `json:"version"`
+	Body      string    `json:"body"`
+	Editor    string    `json:"editor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tipos de evento da linha do tempo.
+const (
+	TimelineCatCreated = "cat_created" // cadastro do gato
+	TimelineCatUpdated = "cat_updated" // cada alteração do cadastro (id = versão)
+	TimelineNote       = "note"        // nota criada
+	TimelineNoteEdited = "note_edited" // texto de uma nota editado
+	TimelinePhoto      = "photo"       // foto/miniatura enviada
+	TimelineWeight     = "weight"      // pesagem (na data da medição)
+	TimelineStatus     = "status"      // mudança de status de adoção
+)
+
+// TimelineKinds são os tipos aceitos no filtro ?kinds=.
+var TimelineKinds = []string{
+	TimelineCatCreated, TimelineCatUpdated, TimelineNote, TimelineNoteEdited,
+	TimelinePhoto, TimelineWeight, TimelineStatus,
+}
+
+// TimelineEvent é um item da linha do tempo. ID é o da linha de origem (nota, foto,
+// medição...), ou o do gato em cat_created/cat_updated; Data traz os detalhes do tipo.
+type TimelineEvent struct {
+	Kind string         `json:"kind"`
+	At   time.Time      `json:"at"`
+	ID   int64          `json:"id"`
+	Data map[string]any `json:"data"`
+}
+
+// TimelineCursor é a posição depois do último evento de uma página.
+// Vários eventos podem ter o mesmo instante (ex: cadastro e primeira pesagem),
+// por isso o cursor leva também o tipo e o ID, e não só a data como nas outras listagens.
+type TimelineCursor struct {
+	At   time.Time
+	Kind string
+	ID   int64
+}
+
+// String codifica o cursor como "instante|tipo|id" (instante em RFC 3339 com nanossegundos).
+func (c TimelineCursor) String() string {
+	return c.At.UTC().Format(time.RFC3339Nano) + "|" + c.Kind + "|" + strconv.FormatInt(c.ID, 10)
+}
+
+// ParseTimelineCursor lê um cursor gerado por TimelineCursor.String.
+func ParseTimelineCursor(s string) (TimelineCursor, error) {
+	parts := strings.Split(s, "|")
+	if len(parts) != 3 {
+		return TimelineCursor{}, fmt.Errorf("%w: %q", ErrInvalidTimelineCursor, s)
+	}
+	at, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return TimelineCursor{}, fmt.Errorf("%w: %q", ErrInvalidTimelineCursor, s)
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return TimelineCursor{}, fmt.Errorf("%w: %q", ErrInvalidTimelineCursor, s)
+	}
+	return TimelineCursor{At: at, Kind: parts[1], ID: id}, nil
+}
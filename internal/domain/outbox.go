@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+/*
+Eventos de domínio (outbox transacional). Cada mudança em um gato (e cada miniatura
+gerada) grava um evento na tabela outbox, na mesma transação da mudança (triggers das
+migrações 0018 e 0020). O relay
+(internal/outbox) entrega os eventos aos destinos configurados na ordem em que foram
+gravados, por gato: um evento que falha segura os seguintes do mesmo gato.
+*/
+
+// Tipos de evento.
+const (
+	EventCatCreated = "cat.created" // data: o gato criado
+	EventCatUpdated = "cat.updated" // data: o gato depois da alteração
+	EventCatDeleted = "cat.deleted" // data: id e version do gato removido
+
+	EventCatThumbnailReady = "cat.thumbnail_ready" // data: a miniatura gerada (id, cat_id, path, created_at)
+)
+
+// EventTypes lista os tipos de evento publicados.
+var EventTypes = []string{EventCatCreated, EventCatUpdated, EventCatDeleted, EventCatThumbnailReady}
+
+// OutboxEvent é um evento gravado na outbox. O JSON é o formato entregue aos destinos.
+type OutboxEvent struct {
+	ID          int64           `json:"id"` // crescente: serve para descartar entregas repetidas
+	Type        string          `json:"type"`
+	Aggregate   string          `json:"aggregate"` // entidade do evento ("cat")
+	AggregateID int64           `json:"aggregate_id"`
+	Data        json.RawMessage `json:"data"`
+	CreatedAt   time.Time       `json:"created_at"`
+
+	Attempts      int       `json:"-"` // tentativas que falharam
+	NextAttemptAt time.Time `json:"-"` // não tentar de novo antes disto
+	Delivered     []string  `json:"-"` // destinos que já receberam o evento
+}
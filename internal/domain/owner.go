@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOwnerNotFound     = errors.New("owner not found")
+	ErrOwnerEmailTaken   = errors.New("owner email already in use")
+	ErrOwnerInUse        = errors.New("owner has ownership records")            // tutor com gatos/histórico não pode ser removido
+	ErrOwnershipNotFound = errors.New("ownership not found")                    // vínculo gato-tutor ativo não existe
+	ErrOwnershipConflict = errors.New("ownership conflicts with an active one") // vínculo ativo duplicado ou segundo tutor principal
+	ErrInvalidTransfer   = errors.New("invalid ownership transfer")             // ex: transferir para o próprio tutor principal
+	ErrOwnershipDates    = errors.New("ownership ends before it starts")        // data de fim anterior à de início
+)
+
+// Owner é o tutor (ou lar temporário) de um ou mais gatos.
+type Owner struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     *string   `json:"phone,omitempty"`
+	Address   *string   `json:"address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OwnerCreate struct {
+	Name    string  `json:"name" validate:"required,min=2,max=128"`
+	Email   string  `json:"email" validate:"required,email,max=254"`
+	Phone   *string `json:"phone" validate:"omitempty,min=8,max=32"`
+	Address *string `json:"address" validate:"omitempty,max=512"`
+}
+
+// OwnerUpdate usa ponteiros: campos ausentes (nil) mantêm o valor atual.
+type OwnerUpdate struct {
+	Name    *string `json:"name" validate:"omitempty,min=2,max=128"`
+	Email   *string `json:"email" validate:"omitempty,email,max=254"`
+	Phone   *string `json:"phone" validate:"omitempty,min=8,max=32"`
+	Address *string `json:"address" validate:"omitempty,max=512"`
+}
+
+// OwnerFilter são os filtros da listagem de tutores. Campos nil não filtram.
+type OwnerFilter struct {
+	Name  *string // busca parcial, sem diferenciar maiúsculas/minúsculas
+	Email *string // e-mail exato, sem diferenciar maiúsculas/minúsculas
+}
+
+// Papéis de um tutor em relação ao gato.
+const (
+	RolePrimary = "primary"  // tutor principal (no máximo um ativo por gato)
+	RoleCoOwner = "co-owner" // divide a tutela com o principal
+	RoleFoster  = "foster"   // lar temporário
+)
+
+// Ownership é o vínculo entre um gato e um tutor durante um período.
+// EndDate nil significa vínculo ativo.
+type Ownership struct {
+	ID        int64     `json:"id"`
+	CatID     int64     `json:"cat_id"`
+	OwnerID   int64     `json:"owner_id"`
+	Role      string    `json:"role"`
+	StartDate Date      `json:"start_date"`
+	EndDate   *Date     `json:"end_date,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OwnershipCreate vincula um tutor a um gato (POST /cats/{id}/owners).
+// StartDate ausente = hoje.
+type OwnershipCreate struct {
+	OwnerID   int64  `json:"owner_id" validate:"required,gt=0"`
+	Role      string `json:"role" validate:"required,oneof=primary co-owner foster"`
+	StartDate *Date  `json:"start_date"`
+}
+
+// CatOwner é um tutor do gato com o vínculo (GET /cats/{id}/owners).
+type CatOwner struct {
+	Owner     Owner     `json:"owner"`
+	Ownership Ownership `json:"ownership"`
+}
+
+// OwnedCat é um gato do tutor com o vínculo (GET /owners/{id}/cats).
+type OwnedCat struct {
+	Cat       Cat       `json:"cat"`
+	Ownership Ownership `json:"ownership"`
+}
+
+// TransferRequest transfere a tutela principal do gato para outro tutor (POST /cats/{id}/transfer).
+// O vínculo principal atual é encerrado em Date e o novo tutor passa a ser o principal.
+type TransferRequest struct {
+	ToOwnerID int64   `json:"to_owner_id" validate:"required,gt=0"`
+	Date      *Date   `json:"date"` // ausente = hoje
+	Reason    *string `json:"reason" validate:"omitempty,max=512"`
+}
+
+// Transfer é um registro do histórico de transferências de tutela.
+// FromOwnerID é nil quando o gato ainda não tinha tutor principal.
+type Transfer struct {
+	ID            int64     `json:"id"`
+	CatID         int64     `json:"cat_id"`
+	FromOwnerID   *int64    `json:"from_owner_id,omitempty"`
+	ToOwnerID     int64     `json:"to_owner_id"`
+	TransferredOn Date      `json:"transferred_on"`
+	Reason        *string   `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
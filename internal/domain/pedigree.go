@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrParentNotFound = errors.New("parent cat not found")
+	ErrLitterNotFound = errors.New("litter not found")
+	ErrInvalidLineage = errors.New("invalid lineage") // ex: gato pai de si mesmo, pais diferentes dos da ninhada
+	ErrLineageCycle   = errors.New("lineage cycle")   // o novo pai/mãe é descendente do gato
+)
+
+/*
+Genealogia: cada gato pode ter pai (sire_id), mãe (dam_id) e ninhada (litter_id).
+O banco recusa ciclos (um gato ancestral de si mesmo) e pais diferentes dos da
+ninhada; quem entra numa ninhada herda dela os pais não informados.
+
+O coeficiente de consanguinidade (COI, de Wright) é a probabilidade de os dois
+alelos de um gene do gato virem do mesmo ancestral. É calculado pelo método
+tabular: o COI de um gato é o parentesco (kinship) entre o pai e a mãe, e
+
+	kinship(a, a) = (1 + COI(a)) / 2
+	kinship(a, b) = (kinship(pai de a, b) + kinship(mãe de a, b)) / 2, com a o mais novo dos dois
+
+Ancestrais desconhecidos (ou além das gerações consultadas) contam como não aparentados.
+*/
+
+// Limites de gerações das consultas de genealogia.
+const (
+	DefaultGenerations = 4
+	MaxGenerations     = 10
+)
+
+// Litter é uma ninhada: filhotes de um mesmo parto.
+type Litter struct {
+	ID        int64     `json:"id"`
+	SireID    *int64    `json:"sire_id,omitempty"`
+	DamID     *int64    `json:"dam_id,omitempty"`
+	BirthDate *Date     `json:"birth_date,omitempty"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	COI       *float64  `json:"coi,omitempty"`     // COI dos filhotes (parentesco entre pai e mãe); ausente sem pai ou mãe
+	Kittens   []Cat     `json:"kittens,omitempty"` // gatos com litter_id desta ninhada
+}
+
+// LitterCreate registra uma ninhada.
+type LitterCreate struct {
+	SireID    *int64  `json:"sire_id" validate:"omitempty,gte=1"`
+	DamID     *int64  `json:"dam_id" validate:"omitempty,gte=1"`
+	BirthDate *Date   `json:"birth_date"`
+	Notes     *string `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// PedigreeNode é um gato na árvore de ancestrais, com o pai e a mãe (quando conhecidos).
+type PedigreeNode struct {
+	Cat  Cat           `json:"cat"`
+	Sire *PedigreeNode `json:"sire,omitempty"`
+	Dam  *PedigreeNode `json:"dam,omitempty"`
+}
+
+// Pedigree é a árvore de ancestrais de um gato até Generations gerações.
+type Pedigree struct {
+	Generations int          `json:"generations"`
+	COI         float64      `json:"coi"` // calculado sobre os ancestrais da árvore
+	Tree        PedigreeNode `json:"tree"`
+}
+
+// Descendant é um descendente de um gato (Generation 1 = filho, 2 = neto...).
+type Descendant struct {
+	Generation int `json:"generation"`
+	Cat        Cat `json:"cat"`
+}
+
+// MatingCOI é o COI previsto para os filhotes de um cruzamento.
+type MatingCOI struct {
+	SireID      int64   `json:"sire_id"`
+	DamID       int64   `json:"dam_id"`
+	Generations int     `json:"generations"`
+	COI         float64 `json:"coi"`
+}
+
+// Ancestry são gatos indexados pelo ID, com os pais (SireID, DamID) preenchidos.
+// Pais fora do mapa contam como desconhecidos.
+type Ancestry map[int64]Cat
+
+// Tree monta a árvore de ancestrais de id até generations gerações.
+func (a Ancestry) Tree(id int64, generations int) PedigreeNode {
+	n := PedigreeNode{Cat: a[id]}
+	if generations <= 0 {
+		return n
+	}
+	if p, ok := a.parent(n.Cat.SireID); ok {
+		sire := a.Tree(p, generations-1)
+		n.Sire = &sire
+	}
+	if p, ok := a.parent(n.Cat.DamID); ok {
+		dam := a.Tree(p, generations-1)
+		n.Dam = &dam
+	}
+	return n
+}
+
+func (a Ancestry) parent(id *int64) (int64, bool) {
+	if id == nil {
+		return 0, false
+	}
+	_, ok := a[*id]
+	return *id, ok
+}
+
+// COI devolve o coeficiente de consanguinidade do gato id.
+func (a Ancestry) COI(id int64) float64 {
+	c, ok := a[id]
+	if !ok {
+		return 0
+	}
+	return a.Kinship(c.SireID, c.DamID)
+}
+
+// Kinship devolve o parentesco entre dois gatos (o COI de um filhote deles).
+func (a Ancestry) Kinship(x, y *int64) float64 {
+	k := kinship{a: a, depth: map[int64]int{}, memo: map[[2]int64]float64{}}
+	sx, okx := a.parent(x)
+	sy, oky := a.parent(y)
+	if !okx || !oky {
+		return 0
+	}
+	return k.of(sx, sy)
+}
+
+// kinship guarda o cálculo: depth ordena os gatos (pais antes dos filhos) e memo evita
+// recalcular os pares que aparecem por vários caminhos.
+type kinship struct {
+	a     Ancestry
+	depth map[int64]int
+	memo  map[[2]int64]float64
+}
+
+// height é a distância do gato ao ancestral conhecido mais antigo: o pai e a mãe sempre têm height menor.
+func (k *kinship) height(id int64) int {
+	if h, ok := k.depth[id]; ok {
+		return h
+	}
+	h := 0
+	c := k.a[id]
+	for _, p := range []*int64{c.SireID, c.DamID} {
+		if pid, ok := k.a.parent(p); ok {
+			h = max(h, k.height(pid)+1)
+		}
+	}
+	k.depth[id] = h
+	return h
+}
+
+func (k *kinship) of(x, y int64) float64 {
+	if x > y {
+		x, y = y, x
+	}
+	key := [2]int64{x, y}
+	if v, ok := k.memo[key]; ok {
+		return v
+	}
+
+	var v float64
+	if x == y {
+		c := k.a[x]
+		v = (1 + k.pair(c.SireID, c.DamID)) / 2
+	} else {
+		// desce pelo mais novo: ele não pode ser ancestral do outro
+		young, other := x, y
+		if k.height(y) > k.height(x) {
+			young, other = y, x
+		}
+		c := k.a[young]
+		v = (k.pairWith(c.SireID, other) + k.pairWith(c.DamID, other)) / 2
+	}
+	k.memo[key] = v
+	return v
+}
+
+// pair é o parentesco entre pai e mãe (0 se algum for desconhecido).
+func (k *kinship) pair(sire, dam *int64) float64 {
+	s, oks := k.a.parent(sire)
+	d, okd := k.a.parent(dam)
+	if !oks || !okd {
+		return 0
+	}
+	return k.of(s, d)
+}
+
+func (k *kinship) pairWith(parent *int64, other int64) float64 {
+	p, ok := k.a.parent(parent)
+	if !ok {
+		return 0
+	}
+	return k.of(p, other)
+}
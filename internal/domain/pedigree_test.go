@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+// ancestry monta um Ancestry a partir de {id, pai, mãe}; 0 é pai desconhecido.
+func ancestry(cats ...[3]int64) Ancestry {
+	a := Ancestry{}
+	parent := func(id int64) *int64 {
+		if id == 0 {
+			return nil
+		}
+		return &id
+	}
+	for _, c := range cats {
+		a[c[0]] = Cat{ID: c[0], SireID: parent(c[1]), DamID: parent(c[2])}
+	}
+	return a
+}
+
+func TestAncestryCOI(t *testing.T) {
+	// 1 e 2 são os fundadores; 3 e 4 são irmãos (filhos de 1 e 2)
+	founders := [][3]int64{{1, 0, 0}, {2, 0, 0}, {3, 1, 2}, {4, 1, 2}}
+	with := func(extra ...[3]int64) Ancestry {
+		return ancestry(append(append([][3]int64{}, founders...), extra...)...)
+	}
+
+	tests := []struct {
+		name string
+		a    Ancestry
+		id   int64
+		want float64
+	}{
+		{"pais sem parentesco", with(), 3, 0},
+		{"gato fora do mapa", with(), 99, 0},
+		{"pai desconhecido", with([3]int64{10, 0, 3}), 10, 0},
+		{"pai fora do mapa", with([3]int64{10, 50, 3}), 10, 0},
+		{"irmãos", with([3]int64{10, 3, 4}), 10, 0.25},
+		{"pai e filha", with([3]int64{10, 1, 3}), 10, 0.25},
+		{"meio-irmãos", with([3]int64{5, 1, 0}, [3]int64{10, 3, 5}), 10, 0.125},
+		{"primos", with([3]int64{5, 0, 0}, [3]int64{6, 0, 0}, [3]int64{7, 3, 5}, [3]int64{8, 4, 6}, [3]int64{10, 7, 8}), 10, 0.0625},
+		// segunda geração de cruzamento entre irmãos (5 e 6 são filhos dos irmãos 3 e 4)
+		{"irmãos consanguíneos", with([3]int64{5, 3, 4}, [3]int64{6, 3, 4}, [3]int64{10, 5, 6}), 10, 0.375},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.COI(tt.id); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("COI(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
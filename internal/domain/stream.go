@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+/*
+Stream de eventos dos gatos (GET /cats/stream, Server-Sent Events): os eventos da outbox,
+repassados aos clientes conectados à medida que o relay os publica no destino Notify.
+Um cliente que reconecta retoma do Last-Event-ID enquanto o evento ainda estiver na outbox
+(ver OUTBOX_RETENTION).
+*/
+
+// ErrEventsMissed: o evento do Last-Event-ID já não está na outbox (ou nunca existiu), então não há
+// como saber o que veio depois dele.
+var ErrEventsMissed = errors.New("events after Last-Event-ID are no longer available")
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagNameTaken       = errors.New("tag name already in use") // renomear para o nome de outra etiqueta (use merge)
+	ErrInvalidTagName     = errors.New("invalid tag name")
+	ErrInvalidTagMerge    = errors.New("invalid tag merge") // ex: etiqueta mesclada nela mesma
+	ErrAttributeNotFound  = errors.New("attribute definition not found")
+	ErrInvalidAttributes  = errors.New("invalid attributes")
+	ErrInvalidAttributeID = errors.New("invalid attribute key")
+)
+
+/*
+Etiquetas (tags) e atributos personalizados.
+
+Etiquetas são rótulos livres, sem diferenciar maiúsculas ("Shy" e "shy" são a
+mesma), criados na primeira vez que um gato os recebe. Renomear para um nome já
+usado é recusado: juntar duas etiquetas é um merge, que move os gatos de uma
+para a outra.
+
+Atributos são pares chave/valor guardados em cats.attributes (JSONB). As chaves
+aceitas e o tipo de cada uma são definidos por tenant (header X-Tenant-ID, padrão
+"default"); na escrita, chaves fora do esquema e valores do tipo errado são recusados.
+*/
+
+// Tag é uma etiqueta, com a quantidade de gatos que a usam.
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Cats      int       `json:"cats"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CatTags substitui as etiquetas de um gato (PUT /cats/{id}/tags). Etiquetas novas são criadas.
+type CatTags struct {
+	Tags []string `json:"tags" validate:"max=20,dive,min=1,max=50,excludesall=0x2C"` // sem vírgula (usada no filtro ?tags=)
+}
+
+// TagRename renomeia uma etiqueta.
+type TagRename struct {
+	Name string `json:"name" validate:"required,min=1,max=50,excludesall=0x2C"`
+}
+
+// TagMerge junta a etiqueta na etiqueta Into: os gatos passam para Into e a original é apagada.
+type TagMerge struct {
+	Into int64 `json:"into" validate:"required,gte=1"`
+}
+
+// Filtro por etiquetas: o gato precisa ter qualquer uma (any) ou todas (all).
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// NormalizeTags tira espaços nas pontas e repetições (sem diferenciar maiúsculas), mantendo a ordem.
+func NormalizeTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// DefaultTenant é o tenant das requisições sem X-Tenant-ID.
+const DefaultTenant = "default"
+
+// Tipos aceitos nos atributos.
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeInteger = "integer"
+	AttributeBoolean = "boolean"
+	AttributeDate    = "date" // texto no formato 2006-01-02
+)
+
+// Limites dos atributos de um gato.
+const (
+	maxAttributes    = 50
+	maxAttributeText = 500
+)
+
+var attributeKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CheckAttributeKey valida o nome de uma chave (minúsculas, dígitos e _, começando por letra).
+func CheckAttributeKey(key string) error {
+	if !attributeKey.MatchString(key) {
+		return fmt.Errorf("%w: %q (use minúsculas, dígitos e _, começando por letra; até 64)", ErrInvalidAttributeID, key)
+	}
+	return nil
+}
+
+// AttributeDef define uma chave de atributo aceita pelo tenant.
+type AttributeDef struct {
+	Key         string    `json:"key"`
+	Type        string    `json:"type"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AttributeDefInput cria ou altera a definição de uma chave (PUT /attributes/{key}).
+type AttributeDefInput struct {
+	Type        string  `json:"type" validate:"required,oneof=string number integer boolean date"`
+	Description *string `json:"description" validate:"omitempty,max=500"`
+}
+
+// AttributeSchema são as definições de um tenant, indexadas pela chave.
+type AttributeSchema map[string]AttributeDef
+
+// AttributeFilter filtra gatos por atributo: com Value, o valor (como texto) precisa ser igual;
+// sem Value, basta o gato ter a chave.
+type AttributeFilter struct {
+	Key   string
+	Value *string
+}
+
+// Check confere os atributos contra o esquema e devolve os valores normalizados.
+// Como XML e CSV só têm texto, valores em texto são convertidos quando o tipo permite
+// ("3" para number, "true" para boolean). null é mantido: na atualização, remove a chave.
+func (s AttributeSchema) Check(attrs map[string]any) (map[string]any, error) {
+	if len(attrs) > maxAttributes {
+		return nil, fmt.Errorf("%w: no máximo %d atributos", ErrInvalidAttributes, maxAttributes)
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	out := make(map[string]any, len(attrs))
+	var problems []string
+	for _, k := range keys {
+		v := attrs[k]
+		if v == nil {
+			out[k] = nil // remoção vale até para chaves que saíram do esquema
+			continue
+		}
+		def, ok := s[k]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: chave não definida", k))
+			continue
+		}
+		nv, ok := convertAttribute(def.Type, v)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: deve ser %s", k, def.Type))
+			continue
+		}
+		out[k] = nv
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttributes, strings.Join(problems, "; "))
+	}
+	return out, nil
+}
+
+// convertAttribute converte v para o tipo do atributo (false se não for possível).
+func convertAttribute(typ string, v any) (any, bool) {
+	text, isText := v.(string)
+	switch typ {
+	case AttributeString:
+		return text, isText && len([]rune(text)) <= maxAttributeText
+	case AttributeNumber, AttributeInteger:
+		n, ok := attributeNumber(v)
+		if isText {
+			var err error
+			n, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
+			ok = err == nil
+		}
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+		if typ == AttributeInteger {
+			if n != math.Trunc(n) || math.Abs(n) > 1<<53 {
+				return nil, false
+			}
+			return int64(n), true
+		}
+		return n, true
+	case AttributeBoolean:
+		if b, ok := v.(bool); ok {
+			return b, true
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		return b, isText && err == nil
+	case AttributeDate:
+		if !isText {
+			return nil, false
+		}
+		d, err := ParseDate(strings.TrimSpace(text))
+		if err != nil {
+			return nil, false
+		}
+		return d.String(), true
+	}
+	return nil, false
+}
+
+// attributeNumber aceita os tipos numéricos dos decodificadores (JSON, MessagePack).
+func attributeNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case interface{ Float64() (float64, error) }: // json.Number
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
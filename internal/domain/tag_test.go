@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"vazio", nil, []string{}},
+		{"mantém a ordem", []string{"shy", "indoor"}, []string{"shy", "indoor"}},
+		{"tira espaços", []string{"  shy ", "indoor"}, []string{"shy", "indoor"}},
+		{"descarta vazias", []string{"", "   ", "shy"}, []string{"shy"}},
+		{"repetição sem diferenciar maiúsculas fica a primeira", []string{"Shy", "shy", "SHY "}, []string{"Shy"}},
+		{"repetição depois de tirar espaços", []string{"indoor", " Indoor"}, []string{"indoor"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTags(tt.tags); !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertAttribute(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		v      any
+		want   any
+		wantOK bool
+	}{
+		{"string", AttributeString, "calmo", "calmo", true},
+		{"string no limite", AttributeString, strings.Repeat("é", maxAttributeText), strings.Repeat("é", maxAttributeText), true},
+		{"string longa", AttributeString, strings.Repeat("a", maxAttributeText+1), nil, false},
+		{"string não é texto", AttributeString, 3.0, nil, false},
+
+		{"number", AttributeNumber, 4.5, 4.5, true},
+		{"number de inteiro", AttributeNumber, int64(3), 3.0, true},
+		{"number de json.Number", AttributeNumber, json.Number("2.5"), 2.5, true},
+		{"number de texto", AttributeNumber, " 4.5 ", 4.5, true},
+		{"number de texto inválido", AttributeNumber, "quatro", nil, false},
+		{"number NaN", AttributeNumber, math.NaN(), nil, false},
+		{"number +Inf", AttributeNumber, math.Inf(1), nil, false},
+		{"number NaN em texto", AttributeNumber, "NaN", nil, false},
+		{"number Inf em texto", AttributeNumber, "-Inf", nil, false},
+		{"number de booleano", AttributeNumber, true, nil, false},
+
+		{"integer", AttributeInteger, 3.0, int64(3), true},
+		{"integer de texto", AttributeInteger, "42", int64(42), true},
+		{"integer de texto com fração", AttributeInteger, "4.5", nil, false},
+		{"integer com fração", AttributeInteger, 4.5, nil, false},
+		{"integer em 2^53", AttributeInteger, float64(1 << 53), int64(1 << 53), true},
+		{"integer em -2^53", AttributeInteger, -float64(1 << 53), -int64(1 << 53), true},
+		{"integer acima de 2^53", AttributeInteger, float64(1<<53 + 2), nil, false},
+		{"integer acima de 2^53 em texto", AttributeInteger, "9007199254740994", nil, false},
+		{"integer NaN", AttributeInteger, math.NaN(), nil, false},
+		{"integer Inf", AttributeInteger, math.Inf(-1), nil, false},
+
+		{"boolean", AttributeBoolean, false, false, true},
+		{"boolean de texto", AttributeBoolean, " true ", true, true},
+		{"boolean de texto 0", AttributeBoolean, "0", false, true},
+		{"boolean de texto inválido", AttributeBoolean, "sim", nil, false},
+		{"boolean de número", AttributeBoolean, 1.0, nil, false},
+
+		{"date", AttributeDate, "2024-02-29", "2024-02-29", true},
+		{"date inexistente", AttributeDate, "2024-02-30", nil, false},
+		{"date não é texto", AttributeDate, 20240229.0, nil, false},
+
+		{"tipo desconhecido", "color", "black", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := convertAttribute(tt.typ, tt.v)
+			if ok != tt.wantOK {
+				t.Fatalf("convertAttribute(%s, %v) ok = %v, want %v", tt.typ, tt.v, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("convertAttribute(%s, %v) = %#v, want %#v", tt.typ, tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributeSchemaCheck(t *testing.T) {
+	schema := AttributeSchema{
+		"indoor":  {Key: "indoor", Type: AttributeBoolean},
+		"litters": {Key: "litters", Type: AttributeInteger},
+		"score":   {Key: "score", Type: AttributeNumber},
+	}
+	many := map[string]any{}
+	for i := range maxAttributes + 1 {
+		many[strings.Repeat("k", i+1)] = nil
+	}
+	tests := []struct {
+		name    string
+		attrs   map[string]any
+		want    map[string]any
+		wantErr string // trecho da mensagem ("" = sem erro)
+	}{
+		{"vazio", map[string]any{}, map[string]any{}, ""},
+		{"texto convertido pelo tipo", map[string]any{"indoor": "true", "litters": "2", "score": "7.5"},
+			map[string]any{"indoor": true, "litters": int64(2), "score": 7.5}, ""},
+		{"null remove a chave", map[string]any{"indoor": nil}, map[string]any{"indoor": nil}, ""},
+		{"null remove até chave fora do esquema", map[string]any{"antiga": nil}, map[string]any{"antiga": nil}, ""},
+		{"chave não definida", map[string]any{"cor": "preto"}, nil, "cor: chave não definida"},
+		{"tipo errado", map[string]any{"litters": 2.5}, nil, "litters: deve ser integer"},
+		{"integer acima de 2^53", map[string]any{"litters": float64(1<<53 + 2)}, nil, "litters: deve ser integer"},
+		{"NaN", map[string]any{"score": math.NaN()}, nil, "score: deve ser number"},
+		{"problemas em ordem de chave", map[string]any{"score": "x", "indoor": "talvez"}, nil,
+			"indoor: deve ser boolean; score: deve ser number"},
+		{"atributos demais", many, nil, "no máximo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := schema.Check(tt.attrs)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidAttributes) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want ErrInvalidAttributes com %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check(%v) = %#v, want %#v", tt.attrs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookDisabled  = errors.New("webhook is disabled")    // reenvio para uma assinatura desativada
+	ErrClaimExpired     = errors.New("delivery claim expired") // outra rodada reservou a entrega depois deste envio
+)
+
+/*
+Webhooks: assinaturas de eventos (outbox) entregues por POST a uma URL do integrador.
+
+Cada entrega é assinada com HMAC-SHA256 do "timestamp.corpo" usando o segredo da
+assinatura (ver internal/webhook). Entregas que falham são tentadas de novo com espera
+crescente; muitas falhas seguidas desativam a assinatura, que volta com active=true.
+*/
+
+// Webhook é uma assinatura de eventos.
+type Webhook struct {
+	ID                  int64      `json:"id"`
+	URL                 string     `json:"url"`
+	Events              []string   `json:"events"`
+	Secret              string     `json:"secret,omitempty"` // só na resposta da criação (ou da troca do segredo)
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      *string    `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookCreate cadastra uma assinatura. Sem secret, um segredo aleatório é gerado.
+type WebhookCreate struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=cat.created cat.updated cat.deleted"`
+	Secret *string  `json:"secret" validate:"omitempty,min=16,max=256"`
+}
+
+// WebhookUpdate usa ponteiros: campos ausentes (nil) mantêm o valor atual.
+// active=true reativa uma assinatura desativada e zera as falhas seguidas.
+type WebhookUpdate struct {
+	URL    *string  `json:"url" validate:"omitempty,http_url,max=2048"`
+	Events []string `json:"events" validate:"omitempty,min=1,unique,dive,oneof=cat.created cat.updated cat.deleted"`
+	Secret *string  `json:"secret" validate:"omitempty,min=16,max=256"`
+	Active *bool    `json:"active"`
+}
+
+// Situações de uma entrega.
+const (
+	DeliveryPending   = "pending"   // aguardando (nova tentativa agendada em next_attempt_at)
+	DeliverySucceeded = "succeeded" // o destino respondeu 2xx
+	DeliveryFailed    = "failed"    // esgotou as tentativas
+)
+
+// WebhookDelivery é a entrega de um evento a uma assinatura, com o resultado da última tentativa.
+type WebhookDelivery struct {
+	ID            int64      `json:"id"`
+	WebhookID     int64      `json:"webhook_id"`
+	EventID       int64      `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // só enquanto pendente
+	ResponseCode  *int       `json:"response_code,omitempty"`
+	ResponseBody  *string    `json:"response_body,omitempty"` // início da resposta
+	Error         *string    `json:"error,omitempty"`
+	DurationMS    *int       `json:"duration_ms,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	RedeliveryOf  *int64     `json:"redelivery_of,omitempty"` // entrega original, se for um reenvio
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// WebhookSend é uma entrega reservada para envio, com o necessário para fazê-la.
+type WebhookSend struct {
+	DeliveryID int64
+	WebhookID  int64
+	EventType  string
+	URL        string
+	Secret     string
+	Body       []byte
+	Attempts   int   // tentativas anteriores
+	ClaimToken int64 // identifica a reserva; Record só grava o resultado da reserva atual
+}
+
+// WebhookResult é o resultado de uma tentativa de entrega.
+type WebhookResult struct {
+	ResponseCode *int
+	ResponseBody *string
+	Error        *string // nil = sucesso
+	Duration     time.Duration
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWeightNotFound      = errors.New("weight measurement not found")
+	ErrWeightAlertNotFound = errors.New("weight alert not found")
+	ErrWeightRange         = errors.New("invalid weight range") // ex: from depois de to, medição no futuro
+)
+
+/*
+Histórico de peso: toda medição fica em cat_weights e Cat.WeightKG é sempre a
+mais recente. Alterar weight_kg pelo cadastro também gera uma medição
+(source "profile"); POST /cats/{id}/weights registra medições avulsas, inclusive
+retroativas (source "measurement").
+*/
+
+// Origem de uma medição.
+const (
+	WeightSourceMeasurement = "measurement" // POST /cats/{id}/weights
+	WeightSourceProfile     = "profile"     // weight_kg alterado pelo cadastro do gato
+)
+
+// Weight é uma medição de peso.
+type Weight struct {
+	ID         int64     `json:"id"`
+	CatID      int64     `json:"cat_id"`
+	WeightKG   float64   `json:"weight_kg"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Source     string    `json:"source"`
+	Notes      *string   `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WeightCreate registra uma medição. MeasuredAt nil = agora.
+type WeightCreate struct {
+	WeightKG   float64    `json:"weight_kg" validate:"required,gt=0,lte=50"`
+	MeasuredAt *time.Time `json:"measured_at"`
+	Notes      *string    `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// Agrupamentos aceitos na redução da série (date_trunc do Postgres).
+const (
+	WeightBucketDay   = "day"
+	WeightBucketWeek  = "week"
+	WeightBucketMonth = "month"
+)
+
+// WeightQuery filtra a série de um gato. Bucket vazio devolve as medições sem agrupar.
+type WeightQuery struct {
+	From   *Date // inclusive
+	To     *Date // inclusive
+	Bucket string
+}
+
+// WeightPoint é um ponto da série. Sem agrupamento, é uma medição (ID, Source e Notes preenchidos);
+// com agrupamento, MeasuredAt é o início do período, WeightKG a média e MinKG/MaxKG/Samples o resumo.
+type WeightPoint struct {
+	ID         *int64    `json:"id,omitempty"`
+	MeasuredAt time.Time `json:"measured_at"`
+	WeightKG   float64   `json:"weight_kg"`
+	Source     *string   `json:"source,omitempty"`
+	Notes      *string   `json:"notes,omitempty"`
+	MinKG      *float64  `json:"min_kg,omitempty"`
+	MaxKG      *float64  `json:"max_kg,omitempty"`
+	Samples    *int      `json:"samples,omitempty"`
+}
+
+// WeightSeries é a resposta de GET /cats/{id}/weights.
+type WeightSeries struct {
+	CatID  int64         `json:"cat_id"`
+	Bucket string        `json:"bucket,omitempty"`
+	Items  []WeightPoint `json:"items"`
+}
+
+// Tipos de alerta de peso.
+const (
+	WeightAlertLoss = "loss"
+	WeightAlertGain = "gain"
+)
+
+// WeightAlert é uma variação anormal: de FromKG (a referência dentro da janela) para ToKG
+// (a medição que disparou o alerta). As regras ficam na tabela weight_alert_rules.
+type WeightAlert struct {
+	ID             int64      `json:"id"`
+	CatID          int64      `json:"cat_id"`
+	WeightID       int64      `json:"weight_id"`
+	Kind           string     `json:"kind"`
+	ChangePct      float64    `json:"change_pct"` // negativo na perda
+	ThresholdPct   float64    `json:"threshold_pct"`
+	WindowDays     int        `json:"window_days"`
+	FromKG         float64    `json:"from_kg"`
+	FromAt         time.Time  `json:"from_at"`
+	ToKG           float64    `json:"to_kg"`
+	ToAt           time.Time  `json:"to_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WeightAlertFilter filtra a lista de alertas. Open = só os não reconhecidos.
+type WeightAlertFilter struct {
+	CatID *int64
+	Kind  *string
+	Open  bool
+}
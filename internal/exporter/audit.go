@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+// AuditFormats lista os formatos da exportação da auditoria (XLSX não: before/after são JSON).
+var AuditFormats = []string{FormatCSV, FormatNDJSON}
+
+// auditColumns são as colunas do CSV da auditoria; before e after vão como JSON em uma célula.
+var auditColumns = []string{"id", "entity", "entity_id", "action", "actor", "request_id", "ip", "user_agent", "before", "after", "created_at"}
+
+// AuditWriter escreve eventos de auditoria em um formato específico.
+type AuditWriter interface {
+	Write(e domain.AuditEvent) error
+	// Close finaliza o arquivo (flush do CSV). Não fecha o io.Writer de destino.
+	Close() error
+}
+
+// NewAuditWriter cria o writer do formato (csv ou ndjson).
+func NewAuditWriter(format string, w io.Writer) (AuditWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(auditColumns); err != nil {
+			return nil, err
+		}
+		return &auditCSVWriter{w: cw}, nil
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &auditNDJSONWriter{enc: enc}, nil
+	}
+	return nil, fmt.Errorf("formato não suportado: %q (disponíveis: %s)", format, strings.Join(AuditFormats, ", "))
+}
+
+type auditCSVWriter struct {
+	w *csv.Writer
+}
+
+func (a *auditCSVWriter) Write(e domain.AuditEvent) error {
+	values := []any{
+		e.ID, e.Entity, e.EntityID, e.Action, deref(e.Actor), deref(e.RequestID), deref(e.IP), deref(e.UserAgent),
+		attributes(e.Before), attributes(e.After), e.CreatedAt, // before/after: JSON na célula, como attributes
+	}
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = text(v)
+	}
+	return a.w.Write(record)
+}
+
+func (a *auditCSVWriter) Close() error {
+	a.w.Flush()
+	return a.w.Error()
+}
+
+type auditNDJSONWriter struct {
+	enc *json.Encoder
+}
+
+func (a *auditNDJSONWriter) Write(e domain.AuditEvent) error {
+	return a.enc.Encode(e) // Encode já escreve o "\n" ao final
+}
+
+func (a *auditNDJSONWriter) Close() error { return nil }
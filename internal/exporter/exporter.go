@@ -0,0 +1,200 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+Pacote exporter: escreve gatos em CSV, NDJSON ou XLSX linha a linha,
+para que a exportação possa ser transmitida enquanto é lida do banco.
+*/
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// Formats lista os formatos suportados.
+var Formats = []string{FormatCSV, FormatNDJSON, FormatXLSX}
+
+// column extrai o valor de uma coluna do gato (nil para campos vazios).
+type column func(c domain.Cat) any
+
+// columns são as colunas exportáveis, na ordem padrão.
+var columns = []struct {
+	name string
+	get  column
+}{
+	{"id", func(c domain.Cat) any { return c.ID }},
+	{"name", func(c domain.Cat) any { return c.Name }},
+	{"birth_date", func(c domain.Cat) any { return c.BirthDate }},
+	{"birth_date_precision", func(c domain.Cat) any { return c.BirthDatePrecision }},
+	{"age_years", func(c domain.Cat) any { return c.AgeYears }},
+	{"age_months", func(c domain.Cat) any { return c.AgeMonths }},
+	{"breed", func(c domain.Cat) any { return deref(c.Breed) }},
+	{"coat_color", func(c domain.Cat) any { return deref(c.CoatColor) }},
+	{"coat_colors", func(c domain.Cat) any { return joinList(c.CoatColors) }},
+	{"coat_pattern", func(c domain.Cat) any { return deref(c.CoatPattern) }},
+	{"weight_kg", func(c domain.Cat) any { return deref(c.WeightKG) }},
+	{"microchip", func(c domain.Cat) any { return deref(c.Microchip) }},
+	{"sire_id", func(c domain.Cat) any { return deref(c.SireID) }},
+	{"dam_id", func(c domain.Cat) any { return deref(c.DamID) }},
+	{"litter_id", func(c domain.Cat) any { return deref(c.LitterID) }},
+	{"attributes", func(c domain.Cat) any { return attributes(c.Attributes) }},
+	{"status", func(c domain.Cat) any { return c.Status }},
+	{"created_at", func(c domain.Cat) any { return c.CreatedAt }},
+	{"updated_at", func(c domain.Cat) any { return c.UpdatedAt }},
+	{"version", func(c domain.Cat) any { return c.Version }},
+}
+
+// Columns devolve os nomes de todas as colunas exportáveis.
+func Columns() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// joinList junta uma lista em uma célula ("black,white"), no formato aceito pela importação.
+func joinList(items []string) any {
+	if len(items) == 0 {
+		return nil
+	}
+	return strings.Join(items, ",")
+}
+
+// attributes devolve os atributos do gato (nil se não houver): no NDJSON viram um objeto,
+// no CSV e na planilha, o JSON em uma célula.
+func attributes(attrs map[string]any) any {
+	if len(attrs) == 0 {
+		return nil
+	}
+	return attrs
+}
+
+// Writer escreve gatos em um formato específico.
+type Writer interface {
+	Write(c domain.Cat) error
+	// Close finaliza o arquivo (rodapé do XLSX, flush do CSV). Não fecha o io.Writer de destino.
+	Close() error
+}
+
+// ContentType devolve o Content-Type do formato.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// NewWriter cria o writer do formato com as colunas selecionadas (vazio = todas).
+// Retorna erro para formato ou coluna desconhecidos.
+func NewWriter(format string, w io.Writer, selected []string) (Writer, error) {
+	if len(selected) == 0 {
+		selected = Columns()
+	}
+	cols := make([]column, len(selected))
+	for i, name := range selected {
+		found := false
+		for _, c := range columns {
+			if c.name == name {
+				cols[i], found = c.get, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("coluna desconhecida: %q (disponíveis: %s)", name, strings.Join(Columns(), ", "))
+		}
+	}
+
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(selected); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw, cols: cols}, nil
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &ndjsonWriter{enc: enc, names: selected, cols: cols}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, selected, cols)
+	}
+	return nil, fmt.Errorf("formato não suportado: %q (disponíveis: %s)", format, strings.Join(Formats, ", "))
+}
+
+// text formata um valor para CSV/planilha.
+func text(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case map[string]any:
+		b, _ := json.Marshal(val)
+		return string(b)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+type csvWriter struct {
+	w    *csv.Writer
+	cols []column
+}
+
+func (c *csvWriter) Write(cat domain.Cat) error {
+	record := make([]string, len(c.cols))
+	for i, get := range c.cols {
+		record[i] = text(get(cat))
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	enc   *json.Encoder
+	names []string
+	cols  []column
+}
+
+func (n *ndjsonWriter) Write(cat domain.Cat) error {
+	obj := make(map[string]any, len(n.cols))
+	for i, get := range n.cols {
+		obj[n.names[i]] = get(cat)
+	}
+	return n.enc.Encode(obj) // Encode já escreve o "\n" ao final
+}
+
+func (n *ndjsonWriter) Close() error { return nil }
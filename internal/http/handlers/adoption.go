@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// AdoptionHandler atende o fluxo de adoção: status do gato (/cats/{id}/status),
+// candidaturas (/cats/{id}/applications, /applications) e aprovação/rejeição.
+type AdoptionHandler struct {
+	svc       service.AdoptionService
+	validator *validator.Validate
+}
+
+func NewAdoptionHandler(svc service.AdoptionService) *AdoptionHandler {
+	return &AdoptionHandler{svc: svc, validator: newValidator()}
+}
+
+// adoptionError traduz os erros do serviço de adoção em status HTTP.
+// Transições inválidas respondem 409 com os próximos status permitidos (allowed_statuses).
+func adoptionError(w http.ResponseWriter, r *http.Request, err error) {
+	var te *domain.TransitionError
+	switch {
+	case errors.As(err, &te):
+		p := newProblem(r, http.StatusConflict, err.Error())
+		p.AllowedStatuses = te.Allowed
+		writeProblem(w, p)
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrApplicationNotFound), errors.Is(err, service.ErrOwnerNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrApplicationDecided), errors.Is(err, service.ErrApplicationExists),
+		errors.Is(err, service.ErrNotAcceptingApplications), errors.Is(err, service.ErrInvalidTransfer):
+		httpError(w, r, http.StatusConflict, err)
+	case errors.Is(err, service.ErrOwnershipDates):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+/*** status do gato ***/
+
+// ChangeStatus: POST /cats/{id}/status -> muda o status de adoção.
+// A resposta é o gato atualizado (nova versão no ETag); transição inválida -> 409.
+func (h *AdoptionHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.StatusChange
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	cat, err := h.svc.ChangeStatus(r.Context(), id, in)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", catETag(cat))
+	writeJSON(w, http.StatusOK, cat)
+}
+
+// StatusHistory: GET /cats/{id}/status/history -> transições, da mais antiga para a mais recente.
+func (h *AdoptionHandler) StatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.History(r.Context(), id)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+/*** candidaturas ***/
+
+// Apply: POST /cats/{id}/applications -> registra a candidatura de um tutor.
+// O gato passa para adoption_pending; gatos adotados, devolvidos ou não adotáveis -> 409.
+func (h *AdoptionHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.ApplicationCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	a, err := h.svc.Apply(r.Context(), id, in)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, a)
+}
+
+// CatApplications: GET /cats/{id}/applications -> candidaturas do gato (mesma paginação de GET /applications).
+func (h *AdoptionHandler) CatApplications(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	f := applicationFilter(r)
+	f.CatID = &id
+	h.list(w, r, f)
+}
+
+// Applications: GET /applications?status=pending&owner_id=... -> lista candidaturas.
+func (h *AdoptionHandler) Applications(w http.ResponseWriter, r *http.Request) {
+	f := applicationFilter(r)
+	if v := r.URL.Query().Get("owner_id"); v != "" {
+		if ownerID, err := strconv.ParseInt(v, 10, 64); err == nil {
+			f.OwnerID = &ownerID
+		}
+	}
+	h.list(w, r, f)
+}
+
+// applicationFilter lê ?status= da query string.
+func applicationFilter(r *http.Request) domain.ApplicationFilter {
+	var f domain.ApplicationFilter
+	if v := r.URL.Query().Get("status"); v != "" {
+		f.Status = &v
+	}
+	return f
+}
+
+// list responde uma página de candidaturas (limit/cursor como em GET /cats).
+func (h *AdoptionHandler) list(w http.ResponseWriter, r *http.Request, f domain.ApplicationFilter) {
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *time.Time
+	if t, err := time.Parse(time.RFC3339, q.Get("cursor")); err == nil {
+		cursor = &t
+	}
+	items, next, err := h.svc.Applications(r.Context(), f, limit, cursor)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.AdoptionApplication{}
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = next.Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdoptionHandler) GetApplication(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	a, err := h.svc.GetApplication(r.Context(), id)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
+
+// Approve: POST /applications/{id}/approve -> aprova a candidatura.
+// O gato vira adopted, o candidato passa a ser o tutor principal e as demais candidaturas pendentes são rejeitadas.
+func (h *AdoptionHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.svc.Approve)
+}
+
+// Reject: POST /applications/{id}/reject -> rejeita a candidatura.
+// Sem outras candidaturas pendentes, o gato volta para available.
+func (h *AdoptionHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.svc.Reject)
+}
+
+// decide lê o corpo opcional ({"reason": "..."}) e aplica a decisão.
+func (h *AdoptionHandler) decide(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error)) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.ApplicationDecision
+	if err := decodeJSON(r, &in); err != nil && !errors.Is(err, io.EOF) { // corpo vazio = sem motivo
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	a, err := apply(r.Context(), id, in)
+	if err != nil {
+		adoptionError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// AttributesHandler atende o esquema de atributos personalizados do tenant (/attributes).
+// O tenant vem do header X-Tenant-ID (ver Tenant).
+type AttributesHandler struct {
+	svc       service.AttributeService
+	validator *validator.Validate
+}
+
+func NewAttributesHandler(svc service.AttributeService) *AttributesHandler {
+	return &AttributesHandler{svc: svc, validator: newValidator()}
+}
+
+// attributeError traduz os erros do esquema de atributos em status HTTP.
+func attributeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrAttributeNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidAttributeID):
+		httpError(w, r, http.StatusBadRequest, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// List: GET /attributes -> chaves aceitas em attributes e o tipo de cada uma.
+func (h *AttributesHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.List(r.Context())
+	if err != nil {
+		attributeError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.AttributeDef{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// Put: PUT /attributes/{key} -> cria ou altera a definição da chave.
+func (h *AttributesHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var in domain.AttributeDefInput
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	d, err := h.svc.Put(r.Context(), chi.URLParam(r, "key"), in)
+	if err != nil {
+		attributeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+// Delete: DELETE /attributes/{key} -> remove a definição (os valores já gravados nos gatos ficam).
+func (h *AttributesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.Delete(r.Context(), chi.URLParam(r, "key")); err != nil {
+		attributeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
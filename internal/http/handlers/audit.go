@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// ActorHeader identifica quem faz a requisição (usuário, sistema), registrado na auditoria.
+// A API não autentica: o valor é informado pelo cliente (ou pelo proxy que autentica).
+const ActorHeader = "X-Actor"
+
+const maxActorLen = 100
+
+// requester identifica quem fez a requisição: X-Actor, IP (já ajustado pelo middleware RealIP),
+// User-Agent e ID da requisição.
+func requester(r *http.Request) domain.Requester {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return domain.Requester{
+		Actor:     r.Header.Get(ActorHeader),
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
+
+// Requester é o middleware que leva quem fez a requisição para o contexto (domain.WithRequester),
+// de onde o repositório o passa ao trigger de auditoria. Um X-Actor inválido responde 400.
+// Deve vir depois de middleware.RequestID e middleware.RealIP.
+func Requester(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := requester(r)
+		if len([]rune(req.Actor)) > maxActorLen || !printable(req.Actor) {
+			httpError(w, r, http.StatusBadRequest, errorf("actorHeader", ActorHeader, maxActorLen))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithRequester(r.Context(), req)))
+	})
+}
+
+func printable(s string) bool {
+	for _, c := range s {
+		if !unicode.IsPrint(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditHandler atende o registro de auditoria (/audit).
+type AuditHandler struct {
+	svc service.AuditService
+}
+
+func NewAuditHandler(svc service.AuditService) *AuditHandler {
+	return &AuditHandler{svc: svc}
+}
+
+// parseAuditFilter lê os filtros da auditoria (entity, id, action, actor, request_id, from, to).
+// Valores fora do formato já são recusados pelo contrato OpenAPI.
+func parseAuditFilter(r *http.Request) domain.AuditFilter {
+	q := r.URL.Query()
+	var f domain.AuditFilter
+	str := func(name string) *string {
+		if v := q.Get(name); v != "" {
+			return &v
+		}
+		return nil
+	}
+	f.Entity, f.Action, f.Actor, f.RequestID = str("entity"), str("action"), str("actor"), str("request_id")
+	if id, err := strconv.ParseInt(q.Get("id"), 10, 64); err == nil {
+		f.EntityID = &id
+	}
+	if t, err := time.Parse(time.RFC3339, q.Get("from")); err == nil {
+		f.From = &t
+	}
+	if t, err := time.Parse(time.RFC3339, q.Get("to")); err == nil {
+		f.To = &t
+	}
+	return f
+}
+
+// List: GET /audit?entity=cat&id=42&actor=&action=&from=&to= -> eventos, mais recentes primeiro.
+// O cursor é o id do último evento da página anterior (next_cursor).
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *int64
+	if c, err := strconv.ParseInt(q.Get("cursor"), 10, 64); err == nil {
+		cursor = &c
+	}
+	items, next, err := h.svc.List(r.Context(), parseAuditFilter(r), limit, cursor)
+	if err != nil {
+		httpError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = *next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Export: GET /audit/export?format=csv|ndjson&<filtros de GET /audit> -> todos os eventos do filtro,
+// em ordem cronológica, transmitidos enquanto são lidos do banco (como GET /cats/export).
+func (h *AuditHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exporter.FormatCSV
+	}
+	if err := h.svc.ValidateExport(format); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	flush := streamResponse(w)
+	filename := fmt.Sprintf("audit-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", exporter.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	abortStream(r, "audit export", h.svc.Export(r.Context(), parseAuditFilter(r), format, w, flush))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// BreedsHandler atende o catálogo de raças (/breeds).
+type BreedsHandler struct {
+	svc service.BreedService
+}
+
+func NewBreedsHandler(svc service.BreedService) *BreedsHandler {
+	return &BreedsHandler{svc: svc}
+}
+
+// breedError traduz os erros do catálogo de raças em status HTTP.
+func breedError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrBreedNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// localize preenche display_name no idioma do Accept-Language.
+// O nome canônico é o em português; os demais idiomas vêm das traduções.
+func localize(r *http.Request, b *domain.Breed) {
+	b.DisplayName = b.Name
+	if language(r) == langEN {
+		if name, ok := b.Translations["en"]; ok {
+			b.DisplayName = name
+		}
+	}
+}
+
+// Search: GET /breeds?prefix=vira&limit=10 -> raças cujo nome, tradução ou apelido começa com prefix
+// (sem diferenciar maiúsculas, acentos e pontuação). Sem prefix, lista o catálogo inteiro.
+func (h *BreedsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	items, err := h.svc.Search(r.Context(), q.Get("prefix"), limit)
+	if err != nil {
+		breedError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.Breed{}
+	}
+	for i := range items {
+		localize(r, &items[i])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// GetByID: GET /breeds/{id} -> raça com traduções e apelidos.
+func (h *BreedsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	b, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		breedError(w, r, err)
+		return
+	}
+	localize(r, &b)
+	writeJSON(w, http.StatusOK, b)
+}
+
+// Stats: GET /breeds/stats -> gatos por raça do catálogo, raças não reconhecidas e gatos sem raça.
+func (h *BreedsHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.svc.Stats(r.Context())
+	if err != nil {
+		breedError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Versions: GET /cats/{id}/versions?limit=20&cursor= -> versões guardadas do gato, da mais recente à mais antiga.
+// O cursor é o número da última versão da página anterior (next_cursor). Continua disponível depois da remoção.
+func (h *CatsHandler) Versions(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *int64
+	if c, err := strconv.ParseInt(q.Get("cursor"), 10, 64); err == nil {
+		cursor = &c
+	}
+	items, next, err := h.svc.Versions(r.Context(), id, limit, cursor)
+	if err != nil {
+		catError(w, r, err)
+		return
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = *next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Revert: POST /cats/{id}/revert?version=N -> restaura o cadastro da versão N como uma nova versão.
+// - Exige If-Match, como o PUT (428 se ausente, 412 se a versão atual mudou).
+// - 404 se o gato ou a versão não existirem; 409/422 se o conteúdo antigo não vale mais (ex.: microchip de outro gato).
+// - Retorna o gato com o novo ETag, no formato pedido em Accept.
+func (h *CatsHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	enc, ok := accept(w, r, false)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil || version <= 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "version"))
+		return
+	}
+	expected, ok := h.ifMatch(w, r, id)
+	if !ok {
+		return
+	}
+	cat, err := h.svc.Revert(r.Context(), id, version, expected)
+	if err != nil {
+		catError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", catETag(cat))
+	enc.write(w, http.StatusOK, cat)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -19,21 +20,32 @@ type CatsHandler struct {
 	validator *validator.Validate
 }
 
-// Construtor do handler. Recebe o serviço e cria o validador.
+// Construtor do handler. Recebe o serviço e cria o validador (com mensagens em pt-BR e en).
 func NewCatsHandler(svc service.CatService) *CatsHandler {
 	return &CatsHandler{
 		svc:       svc,
-		validator: validator.New(),
+		validator: newValidator(),
 	}
 }
 
 // List: lista gatos com paginação.
-// - Lê o parâmetro "limit" da URL, define limite de itens (padrão 20, máximo 100).
-// - Lê o parâmetro "cursor" da URL, converte para time.Time se existir.
-// - Chama o serviço para buscar os gatos.
-// - Se houver erro, retorna erro 500.
-// - Monta resposta JSON com os gatos e o próximo cursor (se existir).
+//   - Lê os filtros (name, breed, status, coat, pattern) e a projeção (fields, include) da URL.
+//   - Lê o parâmetro "limit" da URL, define limite de itens (padrão 20, máximo 100).
+//   - Lê o parâmetro "cursor" da URL, converte para time.Time se existir.
+//   - Chama o serviço para buscar os gatos.
+//   - Se houver erro, retorna erro 500.
+//   - Monta a resposta com os gatos e o próximo cursor (se existir) no formato pedido em Accept
+//     (JSON, CSV, MessagePack ou XML).
 func (h *CatsHandler) List(w http.ResponseWriter, r *http.Request) {
+	enc, ok := accept(w, r, true)
+	if !ok {
+		return
+	}
+	proj, err := parseProjection(r)
+	if err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
 	limit := 20
 	if lstr := r.URL.Query().Get("limit"); lstr != "" {
 		if l, err := strconv.Atoi(lstr); err == nil && l > 0 && l <= 100 {
@@ -47,44 +59,115 @@ func (h *CatsHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	cats, next, err := h.svc.List(r.Context(), limit, cursor)
+	cats, next, err := h.svc.List(r.Context(), parseCatFilter(r), proj, limit, cursor)
 	if err != nil {
-		httpError(w, http.StatusInternalServerError, err)
+		if errors.Is(err, service.ErrInvalidFields) {
+			httpError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		httpError(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
-	resp := map[string]any{
-		"items": cats,
-	}
+	page := catPage{Items: catViews(cats, proj), cats: cats, fields: proj.Fields}
 	if next != nil {
-		resp["next_cursor"] = next.Format(time.RFC3339)
+		page.NextCursor = next.Format(time.RFC3339)
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	// Se a página não mudou desde a última leitura do cliente, responde 304
+	if notModified(w, r, listETag(cats, page.NextCursor+"|"+projectionKey(proj))) {
+		return
+	}
+
+	enc.write(w, http.StatusOK, page)
+}
+
+// catPage é uma página da listagem de gatos (cada item só com os campos pedidos em fields=).
+type catPage struct {
+	Items      []catView `json:"items"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+
+	cats   []domain.Cat // itens originais, usados pelo CSV
+	fields []string     // colunas do CSV (vazio = todas)
+}
+
+// parseCatFilter lê os filtros da listagem/exportação da query string.
+// - name: busca parcial no nome.
+// - breed: raça exata (sem diferenciar maiúsculas/minúsculas).
+// - status: status de adoção exato (ex: status=available).
+// - coat: cores da pelagem separadas por vírgula; o gato precisa ter todas (ex: coat=black,white).
+// - pattern: padrões da pelagem separados por vírgula; vale qualquer um (ex: pattern=tabby,bicolor).
+// - tags: etiquetas separadas por vírgula; tag_match=any (padrão) pede qualquer uma, all pede todas.
+// - attr: repetível; attr=chave exige a chave, attr=chave:valor exige o valor (ex: attr=fiv:true&attr=sala).
+func parseCatFilter(r *http.Request) domain.CatFilter {
+	q := r.URL.Query()
+	var f domain.CatFilter
+	if v := q.Get("name"); v != "" {
+		f.Name = &v
+	}
+	if v := q.Get("breed"); v != "" {
+		f.Breed = &v
+	}
+	if v := q.Get("status"); v != "" {
+		f.Status = &v
+	}
+	if v := q.Get("coat"); v != "" {
+		f.Coat = strings.Split(v, ",")
+	}
+	if v := q.Get("pattern"); v != "" {
+		f.Pattern = strings.Split(v, ",")
+	}
+	if v := q.Get("tags"); v != "" {
+		f.Tags = strings.Split(v, ",")
+		f.TagMatch = q.Get("tag_match")
+	}
+	for _, v := range q["attr"] {
+		key, value, ok := strings.Cut(v, ":")
+		a := domain.AttributeFilter{Key: strings.TrimSpace(key)}
+		if ok {
+			a.Value = &value
+		}
+		if a.Key != "" {
+			f.Attributes = append(f.Attributes, a)
+		}
+	}
+	return f
 }
 
 // Create: cria um novo gato.
-// - Decodifica o corpo da requisição para struct CatCreate.
-// - Valida os dados recebidos.
-// - Chama o serviço para criar o gato.
-// - Se houver erro, retorna erro apropriado.
-// - Retorna o gato criado em JSON.
+//   - Escolhe o formato da resposta pelo Accept (406 se nenhum for aceitável).
+//   - Decodifica o corpo (JSON, MessagePack ou XML, conforme o Content-Type; 415 para outros)
+//     para struct CatCreate (campos desconhecidos são rejeitados).
+//   - Valida os dados recebidos.
+//   - Chama o serviço para criar o gato.
+//   - Se houver erro, retorna erro apropriado.
+//   - Retorna o gato criado.
 func (h *CatsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	enc, ok := accept(w, r, false)
+	if !ok {
+		return
+	}
 	var in domain.CatCreate
-	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		httpError(w, http.StatusBadRequest, err)
+	if err := decodeBody(r, &in); err != nil {
+		if errors.Is(err, errUnsupportedMediaType) {
+			httpError(w, r, http.StatusUnsupportedMediaType, err)
+			return
+		}
+		httpError(w, r, http.StatusBadRequest, err)
 		return
 	}
 	if err := h.validator.Struct(in); err != nil {
-		httpError(w, http.StatusUnprocessableEntity, err)
+		validationError(w, r, err)
 		return
 	}
 	cat, err := h.svc.Create(r.Context(), in)
 	if err != nil {
-		httpError(w, http.StatusInternalServerError, err)
+		catError(w, r, err)
 		return
 	}
-	writeJSON(w, http.StatusCreated, cat)
+	w.Header().Set("ETag", catETag(cat)) // o cliente já pode editar sem um GET antes
+	enc.write(w, http.StatusCreated, cat)
 }
 
 // GetByID: busca um gato pelo ID.
@@ -93,86 +176,156 @@ func (h *CatsHandler) Create(w http.ResponseWriter, r *http.Request) {
 // - Chama o serviço para buscar o gato.
 // - Se não encontrar, retorna erro 404.
 // - Se houver outro erro, retorna erro 500.
-// - Retorna o gato encontrado em JSON.
+// - fields= e include= escolhem os campos e as relações da resposta.
+// - as_of=RFC3339 busca o gato como estava naquele instante (sem include=).
+// - Envia o ETag; se bater com If-None-Match, retorna 304.
+// - Retorna o gato encontrado no formato pedido em Accept.
 func (h *CatsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	enc, ok := accept(w, r, false)
+	if !ok {
+		return
+	}
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		httpError(w, http.StatusBadRequest, errors.New("id inválido"))
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "id"))
+		return
+	}
+	proj, err := parseProjection(r)
+	if err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
 		return
 	}
-	cat, err := h.svc.GetByID(r.Context(), id)
+	var cat domain.Cat
+	if asOf := r.URL.Query().Get("as_of"); asOf != "" {
+		at, perr := time.Parse(time.RFC3339, asOf)
+		if perr != nil {
+			httpError(w, r, http.StatusBadRequest, errorf("asOf"))
+			return
+		}
+		cat, err = h.svc.GetAsOf(r.Context(), id, at, proj)
+	} else {
+		cat, err = h.svc.GetByID(r.Context(), id, proj)
+	}
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			httpError(w, http.StatusNotFound, err)
+			httpError(w, r, http.StatusNotFound, err)
 			return
 		}
-		httpError(w, http.StatusInternalServerError, err)
+		if errors.Is(err, service.ErrInvalidFields) {
+			httpError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		httpError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	// Só o gato completo tem o ETag forte "<id>-<versão>". Com fields= a representação é outra
+	// (e com miniaturas ou etiquetas embutidas o conteúdo muda sem mudar a versão): o ETag passa a
+	// ser fraco, com a projeção (como o da listagem), e não serve para If-Match.
+	etag := catETag(cat)
+	if len(proj.Fields) > 0 || proj.Thumbnails || proj.Tags {
+		etag = listETag([]domain.Cat{cat}, projectionKey(proj))
+	}
+	if notModified(w, r, etag) {
 		return
 	}
-	writeJSON(w, http.StatusOK, cat)
+	enc.write(w, http.StatusOK, catView{cat: cat, p: proj})
 }
 
-/*
+// Update: atualiza parcialmente um gato.
+// - Exige If-Match com o ETag recebido no GET (428 se ausente).
+// - Decodifica e valida o corpo (CatUpdate).
+// - Se a versão mudou desde o GET, retorna 412 Precondition Failed.
+// - Retorna o gato atualizado com o novo ETag, no formato pedido em Accept.
 func (h *CatsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	enc, ok := accept(w, r, false)
+	if !ok {
+		return
+	}
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		httpError(w, http.StatusBadRequest, errors.New("id inválido"))
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "id"))
+		return
+	}
+	version, ok := h.ifMatch(w, r, id)
+	if !ok {
 		return
 	}
-	var in domain.UpdateCatInput
-	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		httpError(w, http.StatusBadRequest, err)
+	var in domain.CatUpdate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
 		return
 	}
 	if err := h.validator.Struct(in); err != nil {
-		httpError(w, http.StatusUnprocessableEntity, err)
+		validationError(w, r, err)
 		return
 	}
-	cat, err := h.svc.Update(r.Context(), id, in)
+	cat, err := h.svc.Update(r.Context(), id, version, in)
 	if err != nil {
-		if errors.Is(err, service.ErrNotFound) {
-			httpError(w, http.StatusNotFound, err)
-			return
-		}
-		httpError(w, http.StatusInternalServerError, err)
+		// 404, 409 (microchip de outro gato), 412 (versão) ou 422
+		catError(w, r, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, cat)
+	w.Header().Set("ETag", catETag(cat))
+	enc.write(w, http.StatusOK, cat)
 }
 
+// Delete: remove um gato.
+// - Exige If-Match, assim como o Update.
+// - Retorna 404 se não existir, 412 se a versão divergir e 204 em caso de sucesso.
 func (h *CatsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil || id <= 0 {
-		httpError(w, http.StatusBadRequest, errors.New("id inválido"))
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "id"))
 		return
 	}
-	if err := h.svc.Delete(r.Context(), id); err != nil {
+	version, ok := h.ifMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id, version); err != nil {
 		if errors.Is(err, service.ErrNotFound) {
-			httpError(w, http.StatusNotFound, err)
+			httpError(w, r, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, service.ErrVersionMismatch) {
+			httpError(w, r, http.StatusPreconditionFailed, err)
 			return
 		}
-		httpError(w, http.StatusInternalServerError, err)
+		httpError(w, r, http.StatusInternalServerError, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
-*/
+
+// ifMatch lê a versão esperada do If-Match.
+// Sem o header responde 428 Precondition Required; com header malformado, 412.
+func (h *CatsHandler) ifMatch(w http.ResponseWriter, r *http.Request, id int64) (int64, bool) {
+	version, err := expectedVersion(r, id)
+	switch {
+	case errors.Is(err, errIfMatchRequired):
+		httpError(w, r, http.StatusPreconditionRequired, err)
+		return 0, false
+	case err != nil:
+		httpError(w, r, http.StatusPreconditionFailed, err)
+		return 0, false
+	}
+	return version, true
+}
 
 /*** helpers ***/
 // Helpers para resposta JSON e erro.
+// decodeJSON: decodifica o corpo rejeitando campos desconhecidos.
 // writeJSON: escreve resposta JSON com status.
-// httpError: escreve resposta de erro em JSON.
+// (as respostas de erro ficam em problem.go; outros formatos em negotiate.go)
+func decodeJSON(r *http.Request, v any) error {
+	return decodeJSONBody(r.Body, v)
+}
+
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(v)
 }
-
-func httpError(w http.ResponseWriter, status int, err error) {
-	writeJSON(w, status, map[string]any{
-		"error": err.Error(),
-	})
-}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// CatBatchItem é o resultado de uma operação do lote na resposta HTTP.
+type CatBatchItem struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	Status int         `json:"status"` // status HTTP equivalente à operação isolada (201, 200, 204, 404, 412...)
+	Cat    *domain.Cat `json:"cat,omitempty"`
+	Error  *Problem    `json:"error,omitempty"`
+}
+
+// CatBatchResponse é a resposta de POST /cats:batch.
+type CatBatchResponse struct {
+	Mode      string         `json:"mode"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Results   []CatBatchItem `json:"results"`
+}
+
+// Batch: executa criações, atualizações e remoções em lote.
+//   - mode=atomic (padrão): tudo ou nada; na primeira falha nada é gravado e a resposta
+//     usa o status da operação que falhou, apontando o índice em errors[].field.
+//   - mode=partial: cada operação é independente; a resposta (200) traz o resultado de cada uma.
+func (h *CatsHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var in domain.CatBatchRequest
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	if in.Mode == "" {
+		in.Mode = domain.BatchAtomic
+	}
+
+	results, err := h.svc.Batch(r.Context(), in)
+	if err != nil {
+		var batchErr *domain.BatchError
+		switch {
+		case errors.Is(err, service.ErrBatchTooLarge):
+			httpError(w, r, http.StatusRequestEntityTooLarge, err)
+		case errors.As(err, &batchErr):
+			status := errorStatus(batchErr.Err)
+			if status >= http.StatusInternalServerError {
+				httpError(w, r, status, err) // não expõe o erro do banco
+				return
+			}
+			p := catProblem(r, status, batchErr.Error(), batchErr.Err)
+			p.Errors = []FieldProblem{{
+				Field:   fmt.Sprintf("operations[%d]", batchErr.Index),
+				In:      "body",
+				Rule:    problemTypes[status],
+				Message: batchErr.Err.Error(),
+			}}
+			writeProblem(w, p)
+		default:
+			httpError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	resp := CatBatchResponse{Mode: in.Mode, Results: make([]CatBatchItem, 0, len(results))}
+	for _, res := range results {
+		item := CatBatchItem{Index: res.Index, Op: res.Op, Cat: res.Cat}
+		if res.Err != nil {
+			item.Status = errorStatus(res.Err)
+			p := catProblem(r, item.Status, res.Err.Error(), res.Err)
+			if item.Status >= http.StatusInternalServerError {
+				p.Detail = message(r, "internal")
+			}
+			item.Error = &p
+			resp.Failed++
+		} else {
+			item.Status = successStatus[res.Op]
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// successStatus é o status equivalente de cada operação bem-sucedida.
+var successStatus = map[string]int{
+	domain.BatchOpCreate: http.StatusCreated,
+	domain.BatchOpUpdate: http.StatusOK,
+	domain.BatchOpDelete: http.StatusNoContent,
+}
+
+// errorStatus mapeia os erros de domínio para o status HTTP correspondente.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrCatVersionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrInvalidBirthDate), errors.Is(err, service.ErrUnknownBreed), errors.Is(err, service.ErrInvalidCoat),
+		errors.Is(err, service.ErrInvalidMicrochip), errors.Is(err, service.ErrParentNotFound), errors.Is(err, service.ErrLitterNotFound),
+		errors.Is(err, service.ErrInvalidLineage), errors.Is(err, service.ErrLineageCycle), errors.Is(err, service.ErrInvalidAttributes):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrMicrochipTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// catProblem monta o problem+json de um erro de gato; microchip repetido traz o ID
+// do gato que já o usa (conflicting_cat_id).
+func catProblem(r *http.Request, status int, detail string, err error) Problem {
+	p := newProblem(r, status, detail)
+	var conflict *domain.MicrochipConflictError
+	if errors.As(err, &conflict) && conflict.CatID != 0 {
+		p.ConflictingCatID = &conflict.CatID
+	}
+	return p
+}
+
+// catError escreve o erro de criação/atualização de um gato com o status de errorStatus.
+func catError(w http.ResponseWriter, r *http.Request, err error) {
+	status := errorStatus(err)
+	if status >= http.StatusInternalServerError {
+		httpError(w, r, status, err)
+		return
+	}
+	writeProblem(w, catProblem(r, status, err.Error(), err))
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+ETag / If-Match / If-None-Match (controle de concorrência otimista):
+
+- GET /cats/{id} devolve o header ETag com a versão do gato.
+- PUT e DELETE exigem If-Match com esse ETag. Se outra pessoa alterou o gato
+  nesse meio tempo, a versão mudou e a resposta é 412 Precondition Failed.
+- Em leituras, o cliente pode mandar If-None-Match: se o conteúdo não mudou,
+  respondemos 304 Not Modified sem corpo.
+- Representações parciais (fields=, include=, listagem) têm ETag fraco (W/"..."):
+  servem para If-None-Match, mas If-Match usa comparação forte e as recusa (412).
+*/
+
+var (
+	errIfMatchRequired = errorf("ifMatchRequired")
+	errIfMatchInvalid  = errorf("ifMatchInvalid")
+	errIfMatchWeak     = errorf("ifMatchWeak")
+)
+
+// catETag gera um ETag forte no formato "<id>-<versão>".
+func catETag(c domain.Cat) string {
+	return fmt.Sprintf(`"%d-%d"`, c.ID, c.Version)
+}
+
+// listETag gera um ETag fraco para uma página da listagem,
+// derivado do id/versão de cada item (e das miniaturas embutidas, que não mudam a versão)
+// e de variant (próximo cursor, campos pedidos).
+func listETag(cats []domain.Cat, variant string) string {
+	h := sha1.New()
+	for _, c := range cats {
+		fmt.Fprintf(h, "%d-%d;", c.ID, c.Version)
+		for _, t := range c.Thumbnails {
+			fmt.Fprintf(h, "t%d;", t.ID)
+		}
+		for _, t := range c.Tags {
+			fmt.Fprintf(h, "g%s;", t)
+		}
+	}
+	h.Write([]byte(variant))
+	return `W/"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// expectedVersion lê o If-Match e devolve a versão esperada para o gato id.
+// "*" significa "qualquer versão" e é representado por 0 (sem checagem).
+func expectedVersion(r *http.Request, id int64) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, errIfMatchRequired
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	// Aceita apenas um ETag forte, no formato "<id>-<versão>".
+	if strings.HasPrefix(header, "W/") {
+		return 0, errIfMatchWeak
+	}
+	tag := strings.Trim(header, `"`)
+	idStr, verStr, ok := strings.Cut(tag, "-")
+	if !ok || idStr != strconv.FormatInt(id, 10) {
+		return 0, errIfMatchInvalid
+	}
+	v, err := strconv.ParseInt(verStr, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, errIfMatchInvalid
+	}
+	return v, nil
+}
+
+// notModified verifica o If-None-Match contra o ETag atual.
+// Se bater, escreve 304 e retorna true (o handler não deve escrever corpo).
+// O 304 leva o mesmo Vary: Accept da resposta completa, para que caches não misturem formatos.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	// If-None-Match usa comparação fraca: W/"x" e "x" são equivalentes
+	weak := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == weak {
+			w.Header().Add("Vary", "Accept")
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
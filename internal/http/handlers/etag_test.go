@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+func TestExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int64
+		wantErr error
+	}{
+		{"forte", `"42-3"`, 3, nil},
+		{"qualquer versão", "*", 0, nil},
+		{"ausente", "", 0, errIfMatchRequired},
+		{"fraco", `W/"42-3"`, 0, errIfMatchWeak},
+		{"fraco da projeção", `W/"9f86d081884c7d659a2f"`, 0, errIfMatchWeak},
+		{"outro gato", `"7-3"`, 0, errIfMatchInvalid},
+		{"versão inválida", `"42-x"`, 0, errIfMatchInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/cats/42", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			got, err := expectedVersion(r, 42)
+			if got != tt.want || !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("expectedVersion = %d, %v; want %d, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// fakeCatService devolve sempre o mesmo gato em GetByID.
+type fakeCatService struct {
+	service.CatService
+	cat domain.Cat
+}
+
+func (s fakeCatService) GetByID(ctx context.Context, id int64, p domain.CatProjection) (domain.Cat, error) {
+	return s.cat, nil
+}
+
+func TestGetByIDETag(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/cats/{id}", NewCatsHandler(fakeCatService{cat: domain.Cat{ID: 42, Name: "Mingau", Version: 3}}).GetByID)
+	get := func(target, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	full := get("/cats/42", "").Header().Get("ETag")
+	if full != `"42-3"` {
+		t.Fatalf("ETag = %s, want the strong version ETag", full)
+	}
+	names := get("/cats/42?fields=id,name", "").Header().Get("ETag")
+	ids := get("/cats/42?fields=id", "").Header().Get("ETag")
+	thumbs := get("/cats/42?include=thumbnails", "").Header().Get("ETag")
+	for _, etag := range []string{names, ids, thumbs} {
+		if !strings.HasPrefix(etag, `W/"`) {
+			t.Errorf("projected ETag %s is not weak", etag)
+		}
+	}
+	if names == ids || names == thumbs {
+		t.Errorf("different projections share an ETag: %s %s %s", names, ids, thumbs)
+	}
+
+	for _, tt := range []struct{ target, etag string }{{"/cats/42", full}, {"/cats/42?fields=id,name", names}} {
+		rec := get(tt.target, tt.etag)
+		if rec.Code != http.StatusNotModified {
+			t.Fatalf("%s: status = %d, want 304", tt.target, rec.Code)
+		}
+		if rec.Header().Get("Vary") != "Accept" {
+			t.Errorf("%s: 304 without Vary: Accept", tt.target)
+		}
+	}
+	if rec := get("/cats/42?fields=id", names); rec.Code != http.StatusOK {
+		t.Errorf("ETag of another projection answered %d", rec.Code)
+	}
+}
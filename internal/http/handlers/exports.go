@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+type ExportsHandler struct {
+	svc service.ExportService
+}
+
+func NewExportsHandler(svc service.ExportService) *ExportsHandler {
+	return &ExportsHandler{svc: svc}
+}
+
+// Export: exporta os gatos que casam com os filtros da listagem.
+// - format: csv (padrão), ndjson ou xlsx.
+// - columns: lista separada por vírgula (padrão: todas).
+// - async=true: roda como job e responde 202; o arquivo fica em GET /jobs/{id}/result.
+// - Sem async, a resposta é transmitida (chunked) enquanto as linhas são lidas do banco.
+func (h *ExportsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	in := domain.ExportRequest{Format: q.Get("format"), Filter: parseCatFilter(r)}
+	if in.Format == "" {
+		in.Format = exporter.FormatCSV
+	}
+	if v := q.Get("columns"); v != "" {
+		for _, c := range strings.Split(v, ",") {
+			in.Columns = append(in.Columns, strings.TrimSpace(c))
+		}
+	}
+	async, _ := strconv.ParseBool(q.Get("async"))
+
+	if err := h.svc.Validate(in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if async {
+		job, err := h.svc.Start(r.Context(), in)
+		if err != nil {
+			httpError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Location", fmt.Sprintf("/jobs/%d", job.ID))
+		writeJSON(w, http.StatusAccepted, withResultURL(job))
+		return
+	}
+
+	flush := streamResponse(w)
+	filename := fmt.Sprintf("cats-%s.%s", time.Now().UTC().Format("20060102-150405"), in.Format)
+	w.Header().Set("Content-Type", exporter.ContentType(in.Format))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	abortStream(r, "export", h.svc.Stream(r.Context(), in, w, flush))
+}
+
+// streamResponse prepara w para uma resposta transmitida enquanto é produzida (exportações, SSE),
+// que pode durar mais que o WriteTimeout do servidor: o prazo é removido só para esta resposta.
+// Devolve a função que envia ao cliente o que já foi escrito.
+func streamResponse(w http.ResponseWriter) (flush func()) {
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+	return func() { _ = rc.Flush() }
+}
+
+// abortStream trata o erro de uma resposta transmitida. O status 200 já foi enviado, então a única
+// forma de sinalizar a falha é abortar a conexão, para que o cliente perceba a transferência
+// incompleta. O cancelamento pelo próprio cliente não é erro.
+func abortStream(r *http.Request, op string, err error) {
+	if err == nil || errors.Is(err, r.Context().Err()) {
+		return
+	}
+	log.Printf("[%s] %s: %v", middleware.GetReqID(r.Context()), op, err)
+	panic(http.ErrAbortHandler)
+}
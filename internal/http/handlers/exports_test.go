@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAbortStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := httptest.NewRequest("GET", "/cats/export", nil).WithContext(ctx)
+	live := httptest.NewRequest("GET", "/cats/export", nil)
+
+	tests := []struct {
+		name  string
+		r     *http.Request
+		err   error
+		abort bool
+	}{
+		{"sem erro", live, nil, false},
+		{"cliente desconectou", canceled, context.Canceled, false},
+		{"falha no meio", live, errors.New("db down"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				p := recover()
+				if tt.abort != (p == http.ErrAbortHandler) {
+					t.Errorf("panic = %v, abort %v", p, tt.abort)
+				}
+			}()
+			abortStream(tt.r, "export", tt.err)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+Sparse fieldsets e relações embutidas:
+
+	GET /cats?fields=id,name                 -> [{"id":1,"name":"Mingau"}, ...]
+	GET /cats/1?include=thumbnails           -> {"id":1, ..., "thumbnails":[{"id":7,"path":"..."}]}
+	GET /cats?include=tags                   -> [{"id":1, ..., "tags":["indoor-only","shy"]}, ...]
+
+A projeção é levada até o SQL (storage.catSelect); aqui apenas montamos a
+resposta com os campos pedidos, na ordem em que foram pedidos.
+*/
+
+// parseProjection lê fields= e include= da query string.
+// Nomes de campo desconhecidos são rejeitados pelo serviço (ErrInvalidFields -> 400).
+func parseProjection(r *http.Request) (domain.CatProjection, error) {
+	var p domain.CatProjection
+	q := r.URL.Query()
+	for _, f := range splitList(q.Get("fields")) {
+		if !slices.Contains(p.Fields, f) {
+			p.Fields = append(p.Fields, f)
+		}
+	}
+	for _, inc := range splitList(q.Get("include")) {
+		switch inc {
+		case domain.CatIncludeThumbnails:
+			p.Thumbnails = true
+		case domain.CatIncludeTags:
+			p.Tags = true
+		default:
+			return p, errorf("include", inc, domain.CatIncludeThumbnails, domain.CatIncludeTags)
+		}
+	}
+	return p, nil
+}
+
+// splitList separa "a, b,,c" em [a b c].
+func splitList(s string) []string {
+	var out []string
+	for part := range strings.SplitSeq(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// projectionKey identifica a projeção no ETag: páginas com campos diferentes são representações diferentes.
+func projectionKey(p domain.CatProjection) string {
+	key := strings.Join(p.Fields, ",")
+	if p.Thumbnails {
+		key += "+" + domain.CatIncludeThumbnails
+	}
+	if p.Tags {
+		key += "+" + domain.CatIncludeTags
+	}
+	return key
+}
+
+// catView serializa o gato apenas com os campos da projeção.
+// Com a projeção vazia o gato sai completo, como antes.
+type catView struct {
+	cat domain.Cat
+	p   domain.CatProjection
+}
+
+func (v catView) MarshalJSON() ([]byte, error) {
+	full, err := json.Marshal(v.cat)
+	if err != nil || (len(v.p.Fields) == 0 && !v.p.Thumbnails && !v.p.Tags) {
+		return full, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(full, &all); err != nil {
+		return nil, err
+	}
+
+	fields := v.p.Fields
+	if len(fields) == 0 {
+		fields = domain.CatFields
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(key string, raw []byte) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q:", key)
+		buf.Write(raw)
+	}
+	for _, f := range fields {
+		if raw, ok := all[f]; ok { // campos nulos (omitempty) continuam ausentes
+			write(f, raw)
+		}
+	}
+	if v.p.Thumbnails {
+		thumbs := v.cat.Thumbnails
+		if thumbs == nil {
+			thumbs = []domain.CatThumbnail{} // include pedido: sempre presente, mesmo vazio
+		}
+		raw, err := json.Marshal(thumbs)
+		if err != nil {
+			return nil, err
+		}
+		write(domain.CatIncludeThumbnails, raw)
+	}
+	if v.p.Tags {
+		tags := v.cat.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		raw, err := json.Marshal(tags)
+		if err != nil {
+			return nil, err
+		}
+		write(domain.CatIncludeTags, raw)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// catViews aplica a projeção a todos os itens de uma página.
+func catViews(cats []domain.Cat, p domain.CatProjection) []catView {
+	views := make([]catView, len(cats))
+	for i, c := range cats {
+		views[i] = catView{cat: c, p: p}
+	}
+	return views
+}
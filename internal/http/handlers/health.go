@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// HealthHandler atende o prontuário dos gatos (/cats/{id}/vaccinations, /vet-visits,
+// /medications, /allergies, /neutering, /health) e o relatório /reports/vaccinations-due.
+type HealthHandler struct {
+	svc       service.HealthService
+	validator *validator.Validate
+}
+
+func NewHealthHandler(svc service.HealthService) *HealthHandler {
+	return &HealthHandler{svc: svc, validator: newValidator()}
+}
+
+// healthError traduz os erros do serviço de prontuário em status HTTP.
+func healthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrHealthRecordNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrHealthRecordExists):
+		httpError(w, r, http.StatusConflict, err)
+	case errors.Is(err, service.ErrHealthDates):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+/*
+Os quatro tipos de registro (vacinas, consultas, medicamentos e alergias) têm as
+mesmas três rotas: GET lista, POST cria e DELETE /{recordId} remove. As funções
+abaixo fazem a parte HTTP comum; cada handler só escolhe o método do serviço.
+*/
+
+// listRecords responde {"items": [...]} com os registros do gato.
+func listRecords[T any](w http.ResponseWriter, r *http.Request, list func(ctx context.Context, catID int64) ([]T, error)) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := list(r.Context(), catID)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// createRecord decodifica e valida o corpo, cria o registro e responde 201.
+func createRecord[In, Out any](v *validator.Validate, w http.ResponseWriter, r *http.Request, add func(ctx context.Context, catID int64, in In) (Out, error)) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in In
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := v.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	out, err := add(r.Context(), catID, in)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, out)
+}
+
+// deleteRecord remove o registro {recordId} do gato e responde 204.
+func deleteRecord(w http.ResponseWriter, r *http.Request, del func(ctx context.Context, catID, id int64) error) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "recordId")
+	if !ok {
+		return
+	}
+	if err := del(r.Context(), catID, id); err != nil {
+		healthError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+/*** vacinas ***/
+
+func (h *HealthHandler) Vaccinations(w http.ResponseWriter, r *http.Request) {
+	listRecords(w, r, h.svc.Vaccinations)
+}
+
+// AddVaccination: POST /cats/{id}/vaccinations -> registra uma dose (next_due_on = data do reforço).
+func (h *HealthHandler) AddVaccination(w http.ResponseWriter, r *http.Request) {
+	createRecord(h.validator, w, r, h.svc.AddVaccination)
+}
+
+func (h *HealthHandler) DeleteVaccination(w http.ResponseWriter, r *http.Request) {
+	deleteRecord(w, r, h.svc.DeleteVaccination)
+}
+
+/*** consultas ***/
+
+func (h *HealthHandler) VetVisits(w http.ResponseWriter, r *http.Request) {
+	listRecords(w, r, h.svc.VetVisits)
+}
+
+func (h *HealthHandler) AddVetVisit(w http.ResponseWriter, r *http.Request) {
+	createRecord(h.validator, w, r, h.svc.AddVetVisit)
+}
+
+func (h *HealthHandler) DeleteVetVisit(w http.ResponseWriter, r *http.Request) {
+	deleteRecord(w, r, h.svc.DeleteVetVisit)
+}
+
+/*** medicamentos ***/
+
+// Medications: GET /cats/{id}/medications?active=true -> tratamentos (só os em curso com active=true).
+func (h *HealthHandler) Medications(w http.ResponseWriter, r *http.Request) {
+	active, _ := strconv.ParseBool(r.URL.Query().Get("active"))
+	listRecords(w, r, func(ctx context.Context, catID int64) ([]domain.Medication, error) {
+		return h.svc.Medications(ctx, catID, active)
+	})
+}
+
+func (h *HealthHandler) AddMedication(w http.ResponseWriter, r *http.Request) {
+	createRecord(h.validator, w, r, h.svc.AddMedication)
+}
+
+func (h *HealthHandler) DeleteMedication(w http.ResponseWriter, r *http.Request) {
+	deleteRecord(w, r, h.svc.DeleteMedication)
+}
+
+/*** alergias ***/
+
+func (h *HealthHandler) Allergies(w http.ResponseWriter, r *http.Request) {
+	listRecords(w, r, h.svc.Allergies)
+}
+
+// AddAllergy: POST /cats/{id}/allergies -> registra uma alergia (alérgeno repetido -> 409).
+func (h *HealthHandler) AddAllergy(w http.ResponseWriter, r *http.Request) {
+	createRecord(h.validator, w, r, h.svc.AddAllergy)
+}
+
+func (h *HealthHandler) DeleteAllergy(w http.ResponseWriter, r *http.Request) {
+	deleteRecord(w, r, h.svc.DeleteAllergy)
+}
+
+/*** castração e resumo ***/
+
+func (h *HealthHandler) Neutering(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	n, err := h.svc.Neutering(r.Context(), catID)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+// SetNeutering: PUT /cats/{id}/neutering -> informa a castração ({"neutered": true, "neutered_on": "2024-01-10"}).
+func (h *HealthHandler) SetNeutering(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.NeuteringUpdate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	n, err := h.svc.SetNeutering(r.Context(), catID, in)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+// Summary: GET /cats/{id}/health -> resumo do prontuário.
+func (h *HealthHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	s, err := h.svc.Summary(r.Context(), catID)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+/*** relatório ***/
+
+// VaccinationsDue: GET /reports/vaccinations-due?status=overdue|upcoming -> último relatório calculado.
+func (h *HealthHandler) VaccinationsDue(w http.ResponseWriter, r *http.Request) {
+	var status *string
+	if v := r.URL.Query().Get("status"); v != "" {
+		status = &v
+	}
+	report, err := h.svc.VaccinationsDue(r.Context(), status)
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// RefreshVaccinationsDue: POST /reports/vaccinations-due/refresh -> recalcula agora (202 + job),
+// sem esperar o próximo ciclo do agendador.
+func (h *HealthHandler) RefreshVaccinationsDue(w http.ResponseWriter, r *http.Request) {
+	job, err := h.svc.ScheduleVaccinationsDue(r.Context())
+	if err != nil {
+		healthError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/jobs/%d", job.ID))
+	writeJSON(w, http.StatusAccepted, withResultURL(job))
+}
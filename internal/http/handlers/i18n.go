@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	pt_BR_translations "github.com/go-playground/validator/v10/translations/pt_BR"
+)
+
+/*
+Internacionalização das mensagens de erro.
+
+As mensagens do validator vêm dos pacotes de tradução do próprio go-playground
+(pt_BR e en). O idioma é escolhido pelo header Accept-Language; sem header
+(ou com idioma não suportado) usamos pt-BR.
+*/
+
+const (
+	langPT = "pt_BR"
+	langEN = "en"
+)
+
+// universal guarda os tradutores dos idiomas suportados (o primeiro é o fallback).
+var universal = ut.New(pt_BR.New(), pt_BR.New(), en.New())
+
+// newValidator cria o validador com as traduções registradas e usando o nome
+// do campo no JSON (ex: "age_years") nas mensagens, em vez do nome da struct Go.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	pt, _ := universal.GetTranslator(langPT)
+	en, _ := universal.GetTranslator(langEN)
+	_ = pt_BR_translations.RegisterDefaultTranslations(v, pt)
+	_ = en_translations.RegisterDefaultTranslations(v, en)
+	// regras sem tradução nos pacotes do go-playground
+	registerMessage(v, pt, "unique", "{0} não pode ter valores repetidos")
+	registerMessage(v, en, "unique", "{0} must not contain duplicate values")
+	registerMessage(v, pt, "excludesall", "{0} contém caracteres não permitidos")
+	registerMessage(v, en, "excludesall", "{0} contains characters that are not allowed")
+	registerMessage(v, pt, "required_with", "{0} é obrigatório quando {1} é informado")
+	registerMessage(v, en, "required_with", "{0} is required when {1} is present")
+	registerMessage(v, pt, "http_url", "{0} deve ser uma URL http ou https")
+	registerMessage(v, en, "http_url", "{0} must be an http or https URL")
+	return v
+}
+
+// registerMessage registra a mensagem de uma regra do validator ({0} = nome do campo,
+// {1} = parâmetro da regra, em minúsculas como os nomes no JSON).
+func registerMessage(v *validator.Validate, t ut.Translator, tag, text string) {
+	_ = v.RegisterTranslation(tag, t,
+		func(t ut.Translator) error { return t.Add(tag, text, true) },
+		func(t ut.Translator, fe validator.FieldError) string {
+			msg, _ := t.T(tag, fe.Field(), strings.ToLower(fe.Param()))
+			return msg
+		},
+	)
+}
+
+// language escolhe o idioma da resposta a partir do Accept-Language (respeitando q=).
+func language(r *http.Request) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		tag = strings.ToLower(tag)
+		switch {
+		case tag == "pt" || strings.HasPrefix(tag, "pt-"):
+			candidates = append(candidates, candidate{langPT, q})
+		case tag == "en" || strings.HasPrefix(tag, "en-"):
+			candidates = append(candidates, candidate{langEN, q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) > 0 && candidates[0].q > 0 {
+		return candidates[0].lang
+	}
+	return langPT
+}
+
+// translator devolve o tradutor do validator para o idioma da requisição.
+func translator(r *http.Request) ut.Translator {
+	t, _ := universal.GetTranslator(language(r))
+	return t
+}
+
+// messages traduz os textos próprios da API (títulos de erro e regras do contrato OpenAPI).
+var messages = map[string]map[string]string{
+	langPT: {
+		"internal":             "erro interno no servidor",
+		"validation":           "um ou mais campos são inválidos",
+		"contract":             "a requisição não corresponde ao contrato da API",
+		"type":                 "%s deve ser do tipo %s",
+		"required":             "%s é obrigatório",
+		"additionalProperties": "%s não é um campo conhecido",
+		"enum":                 "%s deve ser um de: %s",
+		"minLength":            "%s deve ter pelo menos %s caracteres",
+		"maxLength":            "%s deve ter no máximo %s caracteres",
+		"minimum":              "%s deve ser maior ou igual a %s",
+		"maximum":              "%s deve ser menor ou igual a %s",
+		"exclusiveMinimum":     "%s deve ser maior que %s",
+		"exclusiveMaximum":     "%s deve ser menor que %s",
+		"minItems":             "%s deve ter pelo menos %s itens",
+		"maxItems":             "%s deve ter no máximo %s itens",
+		"pattern":              "%s não está no formato esperado",
+		"format":               "%s deve estar no formato %s",
+		"json":                 "o corpo não é um JSON válido",
+		"maxBytes":             "o corpo excede o tamanho máximo permitido",
+		"invalidParam":         "%s inválido",
+		"asOf":                 "as_of inválido: use RFC 3339 (ex.: 2026-01-01T00:00:00Z)",
+		"actorHeader":          "%s inválido: até %d caracteres, sem caracteres de controle",
+		"tenantHeader":         "%s inválido: use minúsculas, dígitos, - e _ (até 64)",
+		"ifMatchRequired":      "header If-Match obrigatório",
+		"ifMatchInvalid":       "header If-Match inválido",
+		"ifMatchWeak":          "header If-Match com ETag fraco: use o ETag do GET sem fields= nem include=",
+		"include":              "include desconhecido: %s (disponíveis: %s, %s)",
+		"importMap":            "map inválido: %q (use coluna=campo)",
+		"sireDamRequired":      "sire_id e dam_id são obrigatórios",
+		"notAcceptable":        "formatos disponíveis: %s",
+		"mediaType":            "content-type não suportado: %s (aceitos: %s)",
+	},
+	langEN: {
+		"internal":             "internal server error",
+		"validation":           "one or more fields are invalid",
+		"contract":             "the request does not match the API contract",
+		"type":                 "%s must be of type %s",
+		"required":             "%s is required",
+		"additionalProperties": "%s is not a known field",
+		"enum":                 "%s must be one of: %s",
+		"minLength":            "%s must be at least %s characters long",
+		"maxLength":            "%s must be at most %s characters long",
+		"minimum":              "%s must be greater than or equal to %s",
+		"maximum":              "%s must be less than or equal to %s",
+		"exclusiveMinimum":     "%s must be greater than %s",
+		"exclusiveMaximum":     "%s must be less than %s",
+		"minItems":             "%s must have at least %s items",
+		"maxItems":             "%s must have at most %s items",
+		"pattern":              "%s does not match the expected format",
+		"format":               "%s must be in %s format",
+		"json":                 "the body is not valid JSON",
+		"maxBytes":             "the body exceeds the maximum allowed size",
+		"invalidParam":         "invalid %s",
+		"asOf":                 "invalid as_of: use RFC 3339 (e.g. 2026-01-01T00:00:00Z)",
+		"actorHeader":          "invalid %s: up to %d characters, no control characters",
+		"tenantHeader":         "invalid %s: use lowercase letters, digits, - and _ (up to 64)",
+		"ifMatchRequired":      "If-Match header is required",
+		"ifMatchInvalid":       "invalid If-Match header",
+		"ifMatchWeak":          "weak ETag in If-Match header: use the ETag from a GET without fields= or include=",
+		"include":              "unknown include: %s (available: %s, %s)",
+		"importMap":            "invalid map: %q (use column=field)",
+		"sireDamRequired":      "sire_id and dam_id are required",
+		"notAcceptable":        "available formats: %s",
+		"mediaType":            "unsupported content-type: %s (accepted: %s)",
+	},
+}
+
+// message devolve o texto traduzido para key; "" se não houver tradução.
+func message(r *http.Request, key string) string {
+	return messages[language(r)][key]
+}
+
+// requestError é um erro de requisição com texto próprio da API: httpError usa a
+// mensagem key de messages no idioma da requisição; Error() devolve o texto em pt-BR.
+type requestError struct {
+	key  string
+	args []any
+}
+
+// errorf cria um requestError com a mensagem key, formatada com args.
+func errorf(key string, args ...any) error {
+	return &requestError{key: key, args: args}
+}
+
+func (e *requestError) Error() string {
+	return e.text(langPT)
+}
+
+// text formata a mensagem no idioma lang.
+func (e *requestError) text(lang string) string {
+	return fmt.Sprintf(messages[lang][e.key], e.args...)
+}
+
+// Is faz errors.Is comparar pela chave, ignorando os argumentos.
+func (e *requestError) Is(target error) bool {
+	t, ok := target.(*requestError)
+	return ok && t.key == e.key
+}
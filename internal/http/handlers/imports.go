@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/importer"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+type ImportsHandler struct {
+	svc      service.ImportService
+	maxBytes int64 // tamanho máximo do arquivo enviado
+}
+
+func NewImportsHandler(svc service.ImportService, maxBytes int64) *ImportsHandler {
+	return &ImportsHandler{svc: svc, maxBytes: maxBytes}
+}
+
+// Import: recebe um arquivo CSV ou NDJSON no corpo e agenda a importação.
+// - format: "csv" ou "ndjson" (se ausente, deduzido do Content-Type).
+// - dry_run=true: apenas valida e gera o relatório, sem gravar.
+// - map=coluna=campo (repetível): mapeia colunas extras; use "-" como campo para ignorar a coluna.
+// - Responde 202 com o job; o progresso fica em GET /jobs/{id} e o relatório em GET /jobs/{id}/result.
+func (h *ImportsHandler) Import(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	in := domain.ImportRequest{Format: q.Get("format")}
+	if in.Format == "" {
+		in.Format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if v := q.Get("dry_run"); v != "" {
+		dry, err := strconv.ParseBool(v)
+		if err != nil {
+			httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "dry_run"))
+			return
+		}
+		in.DryRun = dry
+	}
+	for _, m := range q["map"] {
+		column, field, ok := strings.Cut(m, "=")
+		if !ok || column == "" || field == "" {
+			httpError(w, r, http.StatusBadRequest, errorf("importMap", m))
+			return
+		}
+		if in.Mapping == nil {
+			in.Mapping = map[string]string{}
+		}
+		in.Mapping[column] = field
+	}
+
+	body := http.MaxBytesReader(w, r.Body, h.maxBytes)
+	job, err := h.svc.Start(r.Context(), in, body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.Is(err, service.ErrUnsupportedFormat):
+			httpError(w, r, http.StatusUnsupportedMediaType, err)
+		case errors.As(err, &tooLarge):
+			httpError(w, r, http.StatusRequestEntityTooLarge, err)
+		default:
+			httpError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/jobs/%d", job.ID))
+	writeJSON(w, http.StatusAccepted, withResultURL(job))
+}
+
+// formatFromContentType deduz o formato do arquivo a partir do Content-Type.
+func formatFromContentType(contentType string) string {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch mt {
+	case "text/csv", "application/csv":
+		return importer.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return importer.FormatNDJSON
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dya-andrade/cat-api/internal/blob"
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+type JobsHandler struct {
+	svc service.JobService
+}
+
+func NewJobsHandler(svc service.JobService) *JobsHandler {
+	return &JobsHandler{svc: svc}
+}
+
+// GetByID: retorna o estado e o progresso de um job em background.
+// Quando o job tem arquivo de resultado, result_url aponta para o download.
+func (h *JobsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "id"))
+		return
+	}
+	job, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrJobNotFound) {
+			httpError(w, r, http.StatusNotFound, err)
+			return
+		}
+		httpError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, withResultURL(job))
+}
+
+// Result: faz o download do arquivo gerado pelo job (relatório, exportação...).
+func (h *JobsHandler) Result(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "id"))
+		return
+	}
+	job, rc, err := h.svc.OpenResult(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrJobNotFound) || errors.Is(err, blob.ErrNotFound) {
+			httpError(w, r, http.StatusNotFound, err)
+			return
+		}
+		httpError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	defer rc.Close()
+
+	if job.ResultType != nil {
+		w.Header().Set("Content-Type", *job.ResultType)
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="job-%d-%s"`, job.ID, path.Base(*job.ResultKey)))
+	_, _ = io.Copy(w, rc)
+}
+
+// withResultURL preenche o link de download quando o job tem resultado.
+func withResultURL(job domain.Job) domain.Job {
+	if job.ResultKey != nil {
+		job.ResultURL = fmt.Sprintf("/jobs/%d/result", job.ID)
+	}
+	return job
+}
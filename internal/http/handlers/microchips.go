@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// MicrochipsHandler atende a busca por microchip (/cats/lookup) e o registro das buscas (/microchip-lookups).
+type MicrochipsHandler struct {
+	svc service.MicrochipService
+}
+
+func NewMicrochipsHandler(svc service.MicrochipService) *MicrochipsHandler {
+	return &MicrochipsHandler{svc: svc}
+}
+
+// microchipError traduz os erros da busca por microchip em status HTTP.
+func microchipError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrMicrochipNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidMicrochip):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// Lookup: GET /cats/lookup?microchip=985141000123456 -> gato com o microchip (achados e perdidos).
+// Espaços, hífens e pontos são ignorados. Toda busca fica registrada em /microchip-lookups.
+func (h *MicrochipsHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	cat, err := h.svc.Lookup(r.Context(), r.URL.Query().Get("microchip"), requester(r))
+	if err != nil {
+		microchipError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cat)
+}
+
+// Lookups: GET /microchip-lookups?microchip=&cat_id= -> registro das buscas por microchip (limit/cursor como em GET /cats).
+func (h *MicrochipsHandler) Lookups(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var f domain.MicrochipLookupFilter
+	if v := q.Get("microchip"); v != "" {
+		f.Microchip = &v
+	}
+	if v := q.Get("cat_id"); v != "" {
+		if catID, err := strconv.ParseInt(v, 10, 64); err == nil {
+			f.CatID = &catID
+		}
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *time.Time
+	if t, err := time.Parse(time.RFC3339, q.Get("cursor")); err == nil {
+		cursor = &t
+	}
+	items, next, err := h.svc.Lookups(r.Context(), f, limit, cursor)
+	if err != nil {
+		microchipError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.MicrochipLookup{}
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = next.Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
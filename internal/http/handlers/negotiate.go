@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/codec"
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+)
+
+/*
+Negociação de conteúdo:
+- a resposta é escolhida pelo header Accept entre os encoders abaixo (JSON é o padrão);
+- o corpo de POST /cats é lido conforme o Content-Type pelos decoders abaixo.
+Sem formato aceitável a API responde 406; com Content-Type desconhecido, 415.
+Erros continuam sempre em application/problem+json.
+*/
+
+// encoder serializa uma resposta em um formato.
+type encoder struct {
+	mediaType   string   // nome usado na negociação (ex: application/xml)
+	aliases     []string // nomes alternativos aceitos no Accept (ex: text/xml)
+	contentType string   // Content-Type da resposta
+	listOnly    bool     // formato tabular, só faz sentido em listagens (CSV)
+	encode      func(w io.Writer, v any) error
+}
+
+// encoders é o registro de formatos de resposta, em ordem de preferência (o primeiro é o padrão).
+var encoders = []encoder{
+	{mediaType: "application/json", contentType: "application/json; charset=utf-8", encode: encodeJSON},
+	{mediaType: codec.MsgpackContentType, aliases: []string{"application/x-msgpack", "application/vnd.msgpack"}, contentType: codec.MsgpackContentType, encode: codec.EncodeMsgpack},
+	{mediaType: codec.XMLContentType, aliases: []string{"text/xml"}, contentType: "application/xml; charset=utf-8", encode: encodeXML},
+	{mediaType: "text/csv", contentType: "text/csv; charset=utf-8", listOnly: true, encode: encodeCSV},
+}
+
+// decoders lê o corpo da requisição conforme o Content-Type (sem Content-Type assumimos JSON).
+var decoders = map[string]func(r io.Reader, v any) error{
+	"application/json":       decodeJSONBody,
+	codec.MsgpackContentType: codec.DecodeMsgpack,
+	"application/x-msgpack":  codec.DecodeMsgpack,
+	codec.XMLContentType:     codec.DecodeXML,
+	"text/xml":               codec.DecodeXML,
+}
+
+// errUnsupportedMediaType identifica (errors.Is) os erros de unsupportedMediaType.
+var errUnsupportedMediaType = errorf("mediaType")
+
+// unsupportedMediaType é o erro de um Content-Type sem decoder, listando os aceitos.
+func unsupportedMediaType(ct string) error {
+	return errorf("mediaType", ct, strings.Join(RequestTypes(), ", "))
+}
+
+// ResponseTypes lista os media types que um endpoint pode produzir (usado no contrato OpenAPI).
+func ResponseTypes(list bool) []string {
+	var types []string
+	for _, e := range encoders {
+		if list || !e.listOnly {
+			types = append(types, e.mediaType)
+		}
+	}
+	return types
+}
+
+// RequestTypes lista os Content-Types aceitos no corpo das requisições.
+func RequestTypes() []string {
+	types := make([]string, 0, len(decoders))
+	for t := range decoders {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// mediaRange é um item do header Accept (ex: "application/xml;q=0.8").
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// parseAccept lê o header Accept ordenando por qualidade (q) e especificidade.
+// Itens com q=0 (explicitamente recusados) são descartados.
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(header, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, _ := strings.Cut(mt, "/")
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(qs, 64); err == nil {
+				q = v
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return specificity(ranges[i]) > specificity(ranges[j])
+	})
+	return ranges
+}
+
+func specificity(m mediaRange) int {
+	switch {
+	case m.typ == "*":
+		return 0
+	case m.subtype == "*":
+		return 1
+	}
+	return 2
+}
+
+// matches diz se o encoder atende ao media range (considerando curingas e aliases).
+func (e encoder) matches(m mediaRange) bool {
+	for _, name := range append([]string{e.mediaType}, e.aliases...) {
+		typ, subtype, _ := strings.Cut(name, "/")
+		if (m.typ == "*" || m.typ == typ) && (m.subtype == "*" || m.subtype == subtype) {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiate escolhe o encoder para o header Accept. list indica se o endpoint é uma listagem.
+func negotiate(accept string, list bool) (encoder, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return encoders[0], true
+	}
+	for _, m := range parseAccept(accept) {
+		for _, e := range encoders {
+			if e.listOnly && !list {
+				continue
+			}
+			if e.matches(m) {
+				return e, true
+			}
+		}
+	}
+	return encoder{}, false
+}
+
+// accept escolhe o formato da resposta; se nenhum for aceitável responde 406
+// informando os formatos disponíveis. Deve ser chamado antes de qualquer efeito colateral.
+func accept(w http.ResponseWriter, r *http.Request, list bool) (encoder, bool) {
+	enc, ok := negotiate(r.Header.Get("Accept"), list)
+	if !ok {
+		httpError(w, r, http.StatusNotAcceptable, errorf("notAcceptable", strings.Join(ResponseTypes(list), ", ")))
+		return encoder{}, false
+	}
+	return enc, true
+}
+
+// write escreve a resposta no formato escolhido.
+// Vary: Accept avisa caches de que a mesma URL tem representações diferentes.
+func (e encoder) write(w http.ResponseWriter, status int, v any) {
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", e.contentType)
+	w.WriteHeader(status)
+	_ = e.encode(w, v)
+}
+
+// decodeBody lê o corpo conforme o Content-Type.
+// Retorna errUnsupportedMediaType (-> 415) para formatos sem decoder.
+func decodeBody(r *http.Request, v any) error {
+	ct := r.Header.Get("Content-Type")
+	mt := "application/json"
+	if ct != "" {
+		var err error
+		if mt, _, err = mime.ParseMediaType(ct); err != nil {
+			return unsupportedMediaType(ct)
+		}
+	}
+	dec, ok := decoders[mt]
+	if !ok {
+		return unsupportedMediaType(mt)
+	}
+	return dec(r.Body, v)
+}
+
+/*** encoders/decoders ***/
+
+func encodeJSON(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func decodeJSONBody(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields() // sem isso, typos como "nome" seriam ignorados silenciosamente
+	return dec.Decode(v)
+}
+
+// encodeXML usa um elemento raiz com o nome do recurso (<cat>, <cats>).
+func encodeXML(w io.Writer, v any) error {
+	root := "response"
+	switch v.(type) {
+	case domain.Cat, catView:
+		root = "cat"
+	case catPage:
+		root = "cats"
+	}
+	return codec.EncodeXML(w, root, v)
+}
+
+// encodeCSV escreve uma página de gatos com as mesmas colunas da exportação (ou as de fields=).
+// O cursor da próxima página vai no header X-Next-Cursor (CSV não tem onde guardá-lo).
+func encodeCSV(w io.Writer, v any) error {
+	page, ok := v.(catPage)
+	if !ok {
+		return fmt.Errorf("csv: tipo %T não é uma listagem", v)
+	}
+	cw, err := exporter.NewWriter(exporter.FormatCSV, w, page.fields)
+	if err != nil {
+		return err
+	}
+	for _, c := range page.cats {
+		if err := cw.Write(c); err != nil {
+			return err
+		}
+	}
+	return cw.Close()
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// NotesHandler atende as notas dos gatos (/cats/{id}/notes) e a linha do tempo (/cats/{id}/timeline).
+type NotesHandler struct {
+	svc       service.NoteService
+	validator *validator.Validate
+}
+
+func NewNotesHandler(svc service.NoteService) *NotesHandler {
+	return &NotesHandler{svc: svc, validator: newValidator()}
+}
+
+// noteError traduz os erros de notas e da linha do tempo em status HTTP.
+func noteError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrNoteNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidTimelineCursor):
+		httpError(w, r, http.StatusBadRequest, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// List: GET /cats/{id}/notes -> notas do gato, fixadas primeiro.
+func (h *NotesHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.List(r.Context(), id)
+	if err != nil {
+		noteError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// Create: POST /cats/{id}/notes -> cria uma nota.
+func (h *NotesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.NoteCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	n, err := h.svc.Create(r.Context(), id, in)
+	if err != nil {
+		noteError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, n)
+}
+
+// Update: PUT /cats/{id}/notes/{noteId} -> edita o texto (nova versão no histórico) e/ou fixa a nota.
+func (h *NotesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "noteId")
+	if !ok {
+		return
+	}
+	var in domain.NoteUpdate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	n, err := h.svc.Update(r.Context(), catID, id, in)
+	if err != nil {
+		noteError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+// Delete: DELETE /cats/{id}/notes/{noteId} -> apaga a nota e o histórico dela.
+func (h *NotesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "noteId")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), catID, id); err != nil {
+		noteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// History: GET /cats/{id}/notes/{noteId}/history -> versões do texto, da original à atual.
+func (h *NotesHandler) History(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "noteId")
+	if !ok {
+		return
+	}
+	items, err := h.svc.History(r.Context(), catID, id)
+	if err != nil {
+		noteError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// Timeline: GET /cats/{id}/timeline?kinds=note,weight&limit=20&cursor= -> atividades do gato, mais recentes primeiro.
+// O cursor é o next_cursor da página anterior, repassado como veio.
+func (h *NotesHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *domain.TimelineCursor
+	if c := q.Get("cursor"); c != "" {
+		tc, err := domain.ParseTimelineCursor(c)
+		if err != nil {
+			noteError(w, r, err)
+			return
+		}
+		cursor = &tc
+	}
+	items, next, err := h.svc.Timeline(r.Context(), id, splitList(q.Get("kinds")), limit, cursor)
+	if err != nil {
+		noteError(w, r, err)
+		return
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = next.String()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// OwnersHandler atende o cadastro de tutores (/owners) e a tutela dos gatos
+// (/cats/{id}/owners, /cats/{id}/transfer, /cats/{id}/transfers).
+type OwnersHandler struct {
+	svc       service.OwnerService
+	validator *validator.Validate
+}
+
+func NewOwnersHandler(svc service.OwnerService) *OwnersHandler {
+	return &OwnersHandler{svc: svc, validator: newValidator()}
+}
+
+// ownerError traduz os erros do serviço de tutores em status HTTP.
+func ownerError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrOwnerNotFound), errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrOwnershipNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrOwnerEmailTaken), errors.Is(err, service.ErrOwnerInUse), errors.Is(err, service.ErrOwnershipConflict):
+		httpError(w, r, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidTransfer), errors.Is(err, service.ErrOwnershipDates):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// pathID lê um parâmetro numérico positivo do path; responde 400 se for inválido.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	if err != nil || id <= 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", name))
+		return 0, false
+	}
+	return id, true
+}
+
+// history lê ?history=true (inclui vínculos encerrados).
+func history(r *http.Request) bool {
+	v, _ := strconv.ParseBool(r.URL.Query().Get("history"))
+	return v
+}
+
+/*** cadastro de tutores ***/
+
+// List: lista tutores com paginação por cursor (mesmo formato de GET /cats).
+func (h *OwnersHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *time.Time
+	if t, err := time.Parse(time.RFC3339, q.Get("cursor")); err == nil {
+		cursor = &t
+	}
+	var f domain.OwnerFilter
+	if v := q.Get("name"); v != "" {
+		f.Name = &v
+	}
+	if v := q.Get("email"); v != "" {
+		f.Email = &v
+	}
+
+	owners, next, err := h.svc.List(r.Context(), f, limit, cursor)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	if owners == nil {
+		owners = []domain.Owner{}
+	}
+	resp := map[string]any{"items": owners}
+	if next != nil {
+		resp["next_cursor"] = next.Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Create: cadastra um tutor (e-mail único, sem diferenciar maiúsculas/minúsculas -> 409).
+func (h *OwnersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var in domain.OwnerCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	owner, err := h.svc.Create(r.Context(), in)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, owner)
+}
+
+func (h *OwnersHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	owner, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, owner)
+}
+
+// Update: atualização parcial (campos ausentes mantêm o valor atual).
+func (h *OwnersHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.OwnerUpdate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	owner, err := h.svc.Update(r.Context(), id, in)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, owner)
+}
+
+// Delete: remove um tutor. Tutores com histórico de tutela não podem ser removidos (409).
+func (h *OwnersHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Cats: GET /owners/{id}/cats -> gatos do tutor com o vínculo de cada um.
+func (h *OwnersHandler) Cats(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.CatsOf(r.Context(), id, history(r))
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+/*** tutela dos gatos ***/
+
+// CatOwners: GET /cats/{id}/owners -> tutores do gato (ativos; ?history=true inclui os anteriores).
+func (h *OwnersHandler) CatOwners(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.OwnersOf(r.Context(), id, history(r))
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// AddCatOwner: POST /cats/{id}/owners -> vincula um tutor (primary, co-owner ou foster).
+// Um segundo tutor principal ativo, ou o mesmo tutor vinculado duas vezes, resulta em 409.
+func (h *OwnersHandler) AddCatOwner(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.OwnershipCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	ownership, err := h.svc.AddOwner(r.Context(), id, in)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, ownership)
+}
+
+// EndCatOwner: DELETE /cats/{id}/owners/{ownerId}?end_date=AAAA-MM-DD -> encerra o vínculo ativo.
+// O vínculo continua no histórico (?history=true); a resposta traz o período encerrado.
+func (h *OwnersHandler) EndCatOwner(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	ownerID, ok := pathID(w, r, "ownerId")
+	if !ok {
+		return
+	}
+	var end *domain.Date
+	if v := r.URL.Query().Get("end_date"); v != "" {
+		d, err := domain.ParseDate(v)
+		if err != nil {
+			httpError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		end = &d
+	}
+	ownership, err := h.svc.EndOwnership(r.Context(), id, ownerID, end)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ownership)
+}
+
+// Transfer: POST /cats/{id}/transfer -> passa a tutela principal para outro tutor.
+func (h *OwnersHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.TransferRequest
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	t, err := h.svc.Transfer(r.Context(), id, in)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// Transfers: GET /cats/{id}/transfers -> histórico de transferências, da mais antiga para a mais recente.
+func (h *OwnersHandler) Transfers(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.Transfers(r.Context(), id)
+	if err != nil {
+		ownerError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// PedigreeHandler atende a genealogia (/cats/{id}/pedigree, /cats/{id}/descendants) e as ninhadas (/litters).
+type PedigreeHandler struct {
+	svc       service.PedigreeService
+	validator *validator.Validate
+}
+
+func NewPedigreeHandler(svc service.PedigreeService) *PedigreeHandler {
+	return &PedigreeHandler{svc: svc, validator: newValidator()}
+}
+
+// pedigreeError traduz os erros de genealogia em status HTTP.
+func pedigreeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrLitterNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrParentNotFound), errors.Is(err, service.ErrInvalidLineage):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// generationsParam lê ?generations= (o serviço aplica o padrão e o limite).
+func generationsParam(r *http.Request) int {
+	n, _ := strconv.Atoi(r.URL.Query().Get("generations"))
+	return n
+}
+
+// Pedigree: GET /cats/{id}/pedigree?generations=4 -> árvore de ancestrais e COI do gato.
+func (h *PedigreeHandler) Pedigree(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	p, err := h.svc.Pedigree(r.Context(), id, generationsParam(r))
+	if err != nil {
+		pedigreeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+// Descendants: GET /cats/{id}/descendants?generations=4 -> filhos, netos... (mais próximos primeiro).
+func (h *PedigreeHandler) Descendants(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	items, err := h.svc.Descendants(r.Context(), id, generationsParam(r))
+	if err != nil {
+		pedigreeError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.Descendant{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// MatingCOI: GET /litters/coi?sire_id=&dam_id=&generations= -> COI previsto dos filhotes de um cruzamento.
+func (h *PedigreeHandler) MatingCOI(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	sireID, err1 := strconv.ParseInt(q.Get("sire_id"), 10, 64)
+	damID, err2 := strconv.ParseInt(q.Get("dam_id"), 10, 64)
+	if err1 != nil || err2 != nil || sireID <= 0 || damID <= 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("sireDamRequired"))
+		return
+	}
+	coi, err := h.svc.MatingCOI(r.Context(), sireID, damID, generationsParam(r))
+	if err != nil {
+		pedigreeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, coi)
+}
+
+// CreateLitter: POST /litters -> registra uma ninhada (os filhotes entram nela pelo litter_id do gato).
+func (h *PedigreeHandler) CreateLitter(w http.ResponseWriter, r *http.Request) {
+	var in domain.LitterCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	l, err := h.svc.CreateLitter(r.Context(), in)
+	if err != nil {
+		pedigreeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, l)
+}
+
+// GetLitter: GET /litters/{id}?generations=4 -> ninhada com os filhotes e o COI deles.
+func (h *PedigreeHandler) GetLitter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	l, err := h.svc.GetLitter(r.Context(), id, generationsParam(r))
+	if err != nil {
+		pedigreeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, l)
+}
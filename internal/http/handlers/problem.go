@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/http/openapi"
+)
+
+/*
+Respostas de erro no formato RFC 7807 (application/problem+json):
+
+	{
+	  "type": "/problems/validation-error",
+	  "title": "Entidade não processável",
+	  "status": 422,
+	  "detail": "um ou mais campos são inválidos",
+	  "instance": "host/abc123-000001",          // ID da requisição (middleware.RequestID)
+	  "errors": [{"field": "name", "rule": "min", "param": "2", "message": "..."}]
+	}
+
+Erros 5xx nunca expõem a mensagem original (pgx, rede, etc): ela vai apenas para o log,
+junto com o ID da requisição, e o cliente recebe uma mensagem genérica.
+*/
+
+// Problem é o corpo de erro retornado pela API.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+
+	AllowedStatuses  []string `json:"allowed_statuses,omitempty"`   // transição de status inválida: próximos status permitidos
+	ConflictingCatID *int64   `json:"conflicting_cat_id,omitempty"` // microchip já registrado: gato que o usa
+}
+
+// FieldProblem descreve um campo inválido: a regra violada (min, lte, required, ...),
+// o parâmetro da regra e uma mensagem no idioma do cliente.
+type FieldProblem struct {
+	Field   string `json:"field"`
+	In      string `json:"in,omitempty"` // body, path ou query
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// problemTypes associa o status a um identificador estável do tipo de problema.
+var problemTypes = map[int]string{
+	http.StatusBadRequest:           "bad-request",
+	http.StatusNotFound:             "not-found",
+	http.StatusConflict:             "conflict",
+	http.StatusPreconditionFailed:   "precondition-failed",
+	http.StatusPreconditionRequired: "precondition-required",
+	http.StatusUnprocessableEntity:  "validation-error",
+	http.StatusInternalServerError:  "internal-error",
+}
+
+// titles traduz o título (resumo do status) para pt-BR; em inglês usamos http.StatusText.
+var titlesPT = map[int]string{
+	http.StatusBadRequest:            "Requisição inválida",
+	http.StatusNotFound:              "Não encontrado",
+	http.StatusMethodNotAllowed:      "Método não permitido",
+	http.StatusNotAcceptable:         "Formato não aceitável",
+	http.StatusConflict:              "Conflito",
+	http.StatusPreconditionFailed:    "Pré-condição falhou",
+	http.StatusRequestEntityTooLarge: "Corpo muito grande",
+	http.StatusUnsupportedMediaType:  "Tipo de mídia não suportado",
+	http.StatusUnprocessableEntity:   "Entidade não processável",
+	http.StatusPreconditionRequired:  "Pré-condição obrigatória",
+	http.StatusInternalServerError:   "Erro interno",
+	http.StatusServiceUnavailable:    "Serviço indisponível",
+}
+
+// newProblem monta o Problem com type, title (traduzido) e instance preenchidos.
+func newProblem(r *http.Request, status int, detail string) Problem {
+	slug, ok := problemTypes[status]
+	if !ok {
+		slug = strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "-")
+	}
+	title := http.StatusText(status)
+	if language(r) == langPT {
+		if t, ok := titlesPT[status]; ok {
+			title = t
+		}
+	}
+	return Problem{
+		Type:     "/problems/" + slug,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: middleware.GetReqID(r.Context()),
+	}
+}
+
+// writeProblem escreve o Problem com Content-Type application/problem+json.
+func writeProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// httpError escreve um erro simples (sem lista de campos). Erros criados com errorf
+// têm o detail traduzido para o idioma da requisição. Para status >= 500 o erro original é logado e o cliente recebe uma mensagem genérica.
+func httpError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	detail := err.Error()
+	var rerr *requestError
+	if errors.As(err, &rerr) {
+		detail = rerr.text(language(r))
+	}
+	if status >= http.StatusInternalServerError {
+		log.Printf("[%s] %s %s: %v", middleware.GetReqID(r.Context()), r.Method, r.URL.Path, err)
+		detail = message(r, "internal")
+	}
+	writeProblem(w, newProblem(r, status, detail))
+}
+
+// validationError converte os erros do validator em um 422 com a lista de campos,
+// com mensagens traduzidas para o idioma da requisição.
+func validationError(w http.ResponseWriter, r *http.Request, err error) {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+	trans := translator(r)
+	p := newProblem(r, http.StatusUnprocessableEntity, message(r, "validation"))
+	for _, fe := range verrs {
+		p.Errors = append(p.Errors, FieldProblem{
+			Field:   fieldPath(fe),
+			In:      "body",
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fe.Translate(trans),
+		})
+	}
+	writeProblem(w, p)
+}
+
+// fieldPath remove o nome da struct raiz do namespace (ex: "CatCreate.name" -> "name").
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// ContractError é usado pelo middleware de validação OpenAPI (openapi.ValidatorOptions.OnError).
+func ContractError(w http.ResponseWriter, r *http.Request, status int, errs []openapi.FieldError) {
+	detail := message(r, "contract")
+	if status == http.StatusUnprocessableEntity {
+		detail = message(r, "validation")
+	}
+	p := newProblem(r, status, detail)
+	for _, e := range errs {
+		p.Errors = append(p.Errors, FieldProblem{
+			Field:   e.Field,
+			In:      e.In,
+			Rule:    e.Rule,
+			Param:   e.Param,
+			Message: contractMessage(r, e),
+		})
+	}
+	writeProblem(w, p)
+}
+
+// contractMessage traduz a palavra-chave violada do contrato OpenAPI; se não houver tradução, usa a mensagem original.
+func contractMessage(r *http.Request, e openapi.FieldError) string {
+	format := message(r, e.Keyword)
+	if format == "" {
+		return e.Message
+	}
+	switch strings.Count(format, "%s") {
+	case 0:
+		return format
+	case 1:
+		return fmt.Sprintf(format, e.Field)
+	default:
+		return fmt.Sprintf(format, e.Field, strings.ReplaceAll(e.Param, "|", " | "))
+	}
+}
+
+// NotFound e MethodNotAllowed substituem as respostas texto padrão do chi.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, newProblem(r, http.StatusNotFound, r.URL.Path))
+}
+
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, newProblem(r, http.StatusMethodNotAllowed, r.Method+" "+r.URL.Path))
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPErrorDetail(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   string
+		status int
+		err    error
+		want   string
+	}{
+		{"padrão pt-BR", "", http.StatusBadRequest, errorf("invalidParam", "id"), "id inválido"},
+		{"inglês", "en-US,en;q=0.9", http.StatusBadRequest, errorf("invalidParam", "id"), "invalid id"},
+		{"pt preferido pelo q", "en;q=0.5, pt-BR", http.StatusBadRequest, errorf("invalidParam", "id"), "id inválido"},
+		{"If-Match em inglês", "en", http.StatusPreconditionRequired, errIfMatchRequired, "If-Match header is required"},
+		{"com argumentos", "en", http.StatusBadRequest, errorf("actorHeader", ActorHeader, maxActorLen), "invalid X-Actor: up to 100 characters, no control characters"},
+		{"erro do domínio sem tradução", "en", http.StatusNotFound, errors.New("cat not found"), "cat not found"},
+		{"5xx genérico", "en", http.StatusInternalServerError, errorf("invalidParam", "id"), "internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/cats/x", nil)
+			if tt.lang != "" {
+				r.Header.Set("Accept-Language", tt.lang)
+			}
+			w := httptest.NewRecorder()
+			httpError(w, r, tt.status, tt.err)
+			var p Problem
+			if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Detail != tt.want {
+				t.Errorf("detail = %q, want %q", p.Detail, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestErrorIs(t *testing.T) {
+	if !errors.Is(unsupportedMediaType("text/plain"), errUnsupportedMediaType) {
+		t.Error("unsupportedMediaType não é errUnsupportedMediaType")
+	}
+	if errors.Is(errIfMatchWeak, errIfMatchInvalid) {
+		t.Error("chaves diferentes não podem ser o mesmo erro")
+	}
+}
+
+func TestMessagesCatalog(t *testing.T) {
+	for lang, other := range map[string]string{langPT: langEN, langEN: langPT} {
+		for key := range messages[lang] {
+			if messages[other][key] == "" {
+				t.Errorf("%s: %q sem tradução em %s", lang, key, other)
+			}
+		}
+	}
+}
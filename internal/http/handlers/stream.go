@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// StreamHandler atende o stream de eventos dos gatos (GET /cats/stream, Server-Sent Events).
+type StreamHandler struct {
+	svc       service.StreamService // nil: stream desligado (a outbox não publica no NOTIFY)
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(svc service.StreamService, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &StreamHandler{svc: svc, heartbeat: heartbeat}
+}
+
+const (
+	streamRetry      = 3 * time.Second // espera sugerida ao navegador antes de reconectar
+	streamEventReset = "reset"         // o Last-Event-ID é antigo demais: recarregue os gatos
+)
+
+/*
+Stream: GET /cats/stream -> um evento SSE por evento da outbox, de qualquer réplica, no
+formato entregue aos outros destinos da outbox:
+
+	id: 42
+	event: cat.updated
+	data: {"id":42,"type":"cat.updated","aggregate":"cat","aggregate_id":7,"data":{...},"created_at":"..."}
+
+Com Last-Event-ID (enviado pelo EventSource ao reconectar) ou ?last_event_id=, os eventos
+publicados depois dele são enviados primeiro. Se ele já saiu da outbox, vem um evento
+"reset" e o cliente deve recarregar os gatos por GET /cats. A cada heartbeat sem eventos
+vai um comentário (": heartbeat"), para proxies não fecharem a conexão ociosa.
+*/
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	if h.svc == nil {
+		httpError(w, r, http.StatusServiceUnavailable, errors.New("stream desligado: configure OUTBOX_CHANNEL"))
+		return
+	}
+	last, ok := lastEventID(w, r)
+	if !ok {
+		return
+	}
+
+	// O stream não tem fim
+	flush := streamResponse(w)
+
+	// Inscreve antes de ler a outbox: um evento publicado no meio da leitura não se perde
+	events, cancel := h.svc.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx: não segura a resposta em buffer
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	ctx := r.Context()
+	sent := map[int64]struct{}{} // enviados pelo Replay, que ainda podem chegar pelo canal
+	if last != nil {
+		err := h.svc.Replay(ctx, *last, func(e domain.OutboxEvent) error {
+			sent[e.ID] = struct{}{}
+			return writeEvent(w, e)
+		})
+		switch {
+		case errors.Is(err, service.ErrEventsMissed):
+			if err := writeSSE(w, "", streamEventReset, map[string]string{"reason": err.Error()}); err != nil {
+				return
+			}
+		case err != nil:
+			if ctx.Err() == nil {
+				log.Printf("[%s] stream: replay: %v", middleware.GetReqID(ctx), err)
+			}
+			return // o cliente reconecta com o último id recebido
+		}
+	}
+	flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return // ficou para trás ou o servidor está desligando: o cliente reconecta
+			}
+			if _, dup := sent[e.ID]; dup {
+				delete(sent, e.ID)
+				continue
+			}
+			if writeEvent(w, e) != nil {
+				return
+			}
+			flush()
+			heartbeat.Reset(h.heartbeat)
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flush()
+		}
+	}
+}
+
+// lastEventID lê o header Last-Event-ID ou, sem ele, o parâmetro last_event_id (nil se ausentes).
+// Responde 400 e devolve ok=false se o valor não for um id.
+func lastEventID(w http.ResponseWriter, r *http.Request) (*int64, bool) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		httpError(w, r, http.StatusBadRequest, errorf("invalidParam", "Last-Event-ID"))
+		return nil, false
+	}
+	return &id, true
+}
+
+// writeEvent escreve um evento do stream; o id permite retomar com Last-Event-ID.
+func writeEvent(w io.Writer, e domain.OutboxEvent) error {
+	return writeSSE(w, strconv.FormatInt(e.ID, 10), e.Type, e)
+}
+
+// writeSSE escreve um evento SSE com data em JSON (uma linha só: json.Marshal não quebra linhas).
+func writeSSE(w io.Writer, id, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	return err
+}
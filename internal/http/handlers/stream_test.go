@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+func TestWriteSSE(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		event string
+		data  any
+		want  string
+	}{
+		{"com id", "42", "cat.updated", map[string]int{"id": 42}, "id: 42\nevent: cat.updated\ndata: {\"id\":42}\n\n"},
+		{"sem id", "", "reset", map[string]string{"reason": "x"}, "event: reset\ndata: {\"reason\":\"x\"}\n\n"},
+		{"quebra de linha escapada", "1", "cat.created", "a\nb", "id: 1\nevent: cat.created\ndata: \"a\\nb\"\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeSSE(&buf, tt.id, tt.event, tt.data); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// fakeStream entrega os eventos do Replay e, pelo canal, os eventos ao vivo; depois fecha o canal.
+type fakeStream struct {
+	replay    []domain.OutboxEvent
+	replayErr error
+	live      []domain.OutboxEvent
+	after     *int64
+}
+
+func (s *fakeStream) Subscribe() (<-chan domain.OutboxEvent, func()) {
+	ch := make(chan domain.OutboxEvent, len(s.live))
+	for _, e := range s.live {
+		ch <- e
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func (s *fakeStream) Replay(ctx context.Context, afterID int64, fn func(domain.OutboxEvent) error) error {
+	s.after = &afterID
+	for _, e := range s.replay {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return s.replayErr
+}
+
+func streamEvent(id int64) domain.OutboxEvent {
+	return domain.OutboxEvent{ID: id, Type: domain.EventCatUpdated, Aggregate: "cat", AggregateID: 7}
+}
+
+// sseFrames devolve os ids dos eventos do stream e os nomes dos eventos sem id, na ordem.
+func sseFrames(body string) []string {
+	var frames []string
+	for _, block := range strings.Split(body, "\n\n") {
+		for _, line := range strings.Split(block, "\n") {
+			if id, ok := strings.CutPrefix(line, "id: "); ok {
+				frames = append(frames, id)
+				break
+			}
+			if ev, ok := strings.CutPrefix(line, "event: "); ok {
+				frames = append(frames, ev)
+				break
+			}
+		}
+	}
+	return frames
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		query  string
+		svc    *fakeStream
+		status int
+		after  int64 // -1: sem Replay
+		want   []string
+	}{
+		{"sem Last-Event-ID", "", "", &fakeStream{live: []domain.OutboxEvent{streamEvent(1), streamEvent(2)}},
+			http.StatusOK, -1, []string{"1", "2"}},
+		{"replay antes dos novos, sem repetir", "3", "", &fakeStream{
+			replay: []domain.OutboxEvent{streamEvent(5), streamEvent(4)},
+			live:   []domain.OutboxEvent{streamEvent(4), streamEvent(6)},
+		}, http.StatusOK, 3, []string{"5", "4", "6"}},
+		{"parâmetro last_event_id", "", "?last_event_id=9", &fakeStream{}, http.StatusOK, 9, nil},
+		{"Last-Event-ID apagado da outbox", "1", "", &fakeStream{
+			replayErr: service.ErrEventsMissed,
+			live:      []domain.OutboxEvent{streamEvent(8)},
+		}, http.StatusOK, 1, []string{streamEventReset, "8"}},
+		{"Last-Event-ID inválido", "abc", "", &fakeStream{}, http.StatusBadRequest, -1, nil},
+		{"Last-Event-ID negativo", "-1", "", &fakeStream{}, http.StatusBadRequest, -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/cats/stream"+tt.query, nil)
+			if tt.header != "" {
+				r.Header.Set("Last-Event-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			NewStreamHandler(tt.svc, time.Hour).Stream(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			body := w.Body.String()
+			if !strings.HasPrefix(body, "retry: 3000\n\n") {
+				t.Errorf("body starts with %q", body)
+			}
+			if got := sseFrames(body); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("frames = %v, want %v", got, tt.want)
+			}
+			switch {
+			case tt.after < 0 && tt.svc.after != nil:
+				t.Errorf("Replay called after %d", *tt.svc.after)
+			case tt.after >= 0 && (tt.svc.after == nil || *tt.svc.after != tt.after):
+				t.Errorf("Replay after = %v, want %d", tt.svc.after, tt.after)
+			}
+		})
+	}
+}
+
+func TestStreamDisabled(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewStreamHandler(nil, 0).Stream(w, httptest.NewRequest("GET", "/cats/stream", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// TagsHandler atende as etiquetas dos gatos (/cats/{id}/tags) e a manutenção delas (/tags).
+type TagsHandler struct {
+	svc       service.TagService
+	validator *validator.Validate
+}
+
+func NewTagsHandler(svc service.TagService) *TagsHandler {
+	return &TagsHandler{svc: svc, validator: newValidator()}
+}
+
+// tagError traduz os erros de etiquetas em status HTTP.
+func tagError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrTagNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrTagNameTaken):
+		httpError(w, r, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidTagMerge), errors.Is(err, service.ErrInvalidTagName):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// writeCatTags responde com as etiquetas do gato (lista vazia em vez de null).
+func writeCatTags(w http.ResponseWriter, tags []string) {
+	if tags == nil {
+		tags = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"tags": tags})
+}
+
+// CatTags: GET /cats/{id}/tags -> etiquetas do gato, em ordem alfabética.
+func (h *TagsHandler) CatTags(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	tags, err := h.svc.CatTags(r.Context(), id)
+	if err != nil {
+		tagError(w, r, err)
+		return
+	}
+	writeCatTags(w, tags)
+}
+
+// SetCatTags: PUT /cats/{id}/tags -> substitui as etiquetas do gato ({"tags": []} remove todas).
+func (h *TagsHandler) SetCatTags(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.CatTags
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	tags, err := h.svc.SetCatTags(r.Context(), id, in)
+	if err != nil {
+		tagError(w, r, err)
+		return
+	}
+	writeCatTags(w, tags)
+}
+
+// List: GET /tags?prefix=in&limit=20 -> etiquetas por prefixo, com a quantidade de gatos.
+func (h *TagsHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	items, err := h.svc.List(r.Context(), q.Get("prefix"), limit)
+	if err != nil {
+		tagError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.Tag{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// Rename: POST /tags/{id}/rename -> troca o nome (409 se outra etiqueta já usar o nome; use merge).
+func (h *TagsHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.TagRename
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	t, err := h.svc.Rename(r.Context(), id, in)
+	if err != nil {
+		tagError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// Merge: POST /tags/{id}/merge -> move os gatos para a etiqueta "into" e apaga esta; devolve "into".
+func (h *TagsHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.TagMerge
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	t, err := h.svc.Merge(r.Context(), id, in)
+	if err != nil {
+		tagError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// Delete: DELETE /tags/{id} -> apaga a etiqueta e a tira de todos os gatos.
+func (h *TagsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		tagError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
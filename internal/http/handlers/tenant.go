@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// TenantHeader identifica o tenant da requisição (sem ele vale domain.DefaultTenant).
+// Por enquanto o tenant só escolhe o esquema de atributos personalizados dos gatos.
+const TenantHeader = "X-Tenant-ID"
+
+var tenantID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Tenant é o middleware que leva o X-Tenant-ID para o contexto (service.WithTenant).
+// Um valor fora do formato responde 400.
+func Tenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t := r.Header.Get(TenantHeader)
+		if t == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !tenantID.MatchString(t) {
+			httpError(w, r, http.StatusBadRequest, errorf("tenantHeader", TenantHeader))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(service.WithTenant(r.Context(), t)))
+	})
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// WebhooksHandler atende as assinaturas de webhooks e as entregas (/webhooks).
+type WebhooksHandler struct {
+	svc       service.WebhookService
+	validator *validator.Validate
+}
+
+func NewWebhooksHandler(svc service.WebhookService) *WebhooksHandler {
+	return &WebhooksHandler{svc: svc, validator: newValidator()}
+}
+
+// webhookError traduz os erros de webhooks em status HTTP.
+func webhookError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrDeliveryNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrWebhookDisabled):
+		httpError(w, r, http.StatusConflict, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// Create: POST /webhooks -> cadastra uma assinatura. O segredo só aparece nesta resposta.
+func (h *WebhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var in domain.WebhookCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	wh, err := h.svc.Create(r.Context(), in)
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, wh)
+}
+
+// List: GET /webhooks -> assinaturas, das mais novas às mais antigas.
+func (h *WebhooksHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.List(r.Context())
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// GetByID: GET /webhooks/{id} -> assinatura (sem o segredo).
+func (h *WebhooksHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	wh, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wh)
+}
+
+// Update: PUT /webhooks/{id} -> altera URL, eventos ou segredo; active=true reativa, active=false desativa.
+func (h *WebhooksHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.WebhookUpdate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	wh, err := h.svc.Update(r.Context(), id, in)
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wh)
+}
+
+// Delete: DELETE /webhooks/{id} -> remove a assinatura e o registro das entregas.
+func (h *WebhooksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries: GET /webhooks/{id}/deliveries?status=failed&limit=20&cursor= -> entregas, mais novas primeiro.
+// O cursor é o id da última entrega da página anterior (next_cursor).
+func (h *WebhooksHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *int64
+	if c, err := strconv.ParseInt(q.Get("cursor"), 10, 64); err == nil {
+		cursor = &c
+	}
+	var status *string
+	if s := q.Get("status"); s != "" {
+		status = &s
+	}
+	items, next, err := h.svc.Deliveries(r.Context(), id, status, limit, cursor)
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = *next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Redeliver: POST /webhooks/{id}/deliveries/{deliveryId}/redeliver -> agenda um novo envio do evento (202).
+// 409 se a assinatura estiver desativada.
+func (h *WebhooksHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	deliveryID, ok := pathID(w, r, "deliveryId")
+	if !ok {
+		return
+	}
+	d, err := h.svc.Redeliver(r.Context(), id, deliveryID)
+	if err != nil {
+		webhookError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, d)
+}
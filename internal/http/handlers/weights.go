@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/service"
+)
+
+// WeightsHandler atende o histórico de peso (/cats/{id}/weights) e os alertas (/weight-alerts).
+type WeightsHandler struct {
+	svc       service.WeightService
+	validator *validator.Validate
+}
+
+func NewWeightsHandler(svc service.WeightService) *WeightsHandler {
+	return &WeightsHandler{svc: svc, validator: newValidator()}
+}
+
+// weightError traduz os erros do serviço de peso em status HTTP.
+func weightError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound), errors.Is(err, service.ErrWeightNotFound), errors.Is(err, service.ErrWeightAlertNotFound):
+		httpError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrWeightRange):
+		httpError(w, r, http.StatusUnprocessableEntity, err)
+	default:
+		httpError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+// Series: GET /cats/{id}/weights?from=AAAA-MM-DD&to=AAAA-MM-DD&bucket=day|week|month
+// -> medições do período; com bucket, uma média por dia, semana ou mês.
+func (h *WeightsHandler) Series(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	q := domain.WeightQuery{Bucket: r.URL.Query().Get("bucket")}
+	for name, dst := range map[string]**domain.Date{"from": &q.From, "to": &q.To} {
+		if v := r.URL.Query().Get(name); v != "" {
+			d, err := domain.ParseDate(v)
+			if err != nil {
+				httpError(w, r, http.StatusBadRequest, err)
+				return
+			}
+			*dst = &d
+		}
+	}
+	series, err := h.svc.Series(r.Context(), catID, q)
+	if err != nil {
+		weightError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// Add: POST /cats/{id}/weights -> registra uma medição ({"weight_kg": 4.2, "measured_at": "..."}).
+// Se for a medição mais recente, ela vira o weight_kg do gato.
+func (h *WeightsHandler) Add(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var in domain.WeightCreate
+	if err := decodeJSON(r, &in); err != nil {
+		httpError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.validator.Struct(in); err != nil {
+		validationError(w, r, err)
+		return
+	}
+	weight, err := h.svc.Add(r.Context(), catID, in)
+	if err != nil {
+		weightError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, weight)
+}
+
+// Delete: DELETE /cats/{id}/weights/{weightId} -> apaga uma medição; weight_kg volta a ser a mais recente restante.
+func (h *WeightsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	catID, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "weightId")
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(r.Context(), catID, id); err != nil {
+		weightError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Alerts: GET /weight-alerts?cat_id=&kind=loss|gain&open=true -> alertas de variação de peso (limit/cursor como em GET /cats).
+func (h *WeightsHandler) Alerts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var f domain.WeightAlertFilter
+	if v := q.Get("cat_id"); v != "" {
+		if catID, err := strconv.ParseInt(v, 10, 64); err == nil {
+			f.CatID = &catID
+		}
+	}
+	if v := q.Get("kind"); v != "" {
+		f.Kind = &v
+	}
+	f.Open, _ = strconv.ParseBool(q.Get("open"))
+
+	limit := 20
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+	var cursor *time.Time
+	if t, err := time.Parse(time.RFC3339, q.Get("cursor")); err == nil {
+		cursor = &t
+	}
+	items, next, err := h.svc.Alerts(r.Context(), f, limit, cursor)
+	if err != nil {
+		weightError(w, r, err)
+		return
+	}
+	if items == nil {
+		items = []domain.WeightAlert{}
+	}
+	resp := map[string]any{"items": items}
+	if next != nil {
+		resp["next_cursor"] = next.Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Acknowledge: POST /weight-alerts/{id}/acknowledge -> marca o alerta como visto (sai de ?open=true).
+func (h *WeightsHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	a, err := h.svc.Acknowledge(r.Context(), id)
+	if err != nil {
+		weightError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, a)
+}
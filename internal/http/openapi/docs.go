@@ -0,0 +1,21 @@
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed docs
+var docsFS embed.FS
+
+// DocsHandler serve a interface de documentação (HTML/JS embutidos no binário).
+// A página lê /openapi.json e renderiza as operações e schemas.
+// prefix é o caminho onde o handler é montado (ex: "/docs").
+func DocsHandler(prefix string) http.Handler {
+	sub, err := fs.Sub(docsFS, "docs")
+	if err != nil {
+		panic(err) // o diretório é embutido em tempo de compilação, não deve falhar
+	}
+	return http.StripPrefix(prefix, http.FileServer(http.FS(sub)))
+}
@@ -0,0 +1,56 @@
+// Renderiza o documento OpenAPI servido em /openapi.json sem dependências externas.
+(async function () {
+  const spec = await (await fetch('/openapi.json')).json();
+  document.getElementById('title').textContent = spec.info.title + ' ' + spec.info.version;
+  document.title = spec.info.title;
+
+  const esc = (s) => String(s ?? '').replace(/[&<>"]/g, (c) => ({ '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;' }[c]));
+  const refName = (s) => (s && s.$ref ? s.$ref.split('/').pop() : null);
+  const typeOf = (s) => {
+    if (!s) return '';
+    if (s.$ref) return refName(s);
+    if (s.type === 'array' || (Array.isArray(s.type) && s.type.includes('array'))) return typeOf(s.items) + '[]';
+    return [].concat(s.type || 'any').join(' | ') + (s.format ? ' (' + s.format + ')' : '');
+  };
+  const constraints = (s) => {
+    const out = [];
+    for (const k of ['minimum', 'maximum', 'exclusiveMinimum', 'exclusiveMaximum', 'minLength', 'maxLength', 'minItems', 'maxItems', 'pattern']) {
+      if (s[k] !== undefined) out.push(k + '=' + s[k]);
+    }
+    if (s.enum) out.push('enum=' + s.enum.join('|'));
+    return out.join(', ');
+  };
+  const content = (c) => Object.entries(c || {}).map(([mt, m]) => esc(mt) + ': <code>' + esc(typeOf(m.schema)) + '</code>').join('<br>');
+
+  const ops = document.getElementById('operations');
+  for (const path of Object.keys(spec.paths).sort()) {
+    for (const [method, op] of Object.entries(spec.paths[path])) {
+      const params = (op.parameters || []).map((p) =>
+        `<tr><td>${esc(p.name)}</td><td>${esc(p.in)}</td><td>${p.required ? 'sim' : ''}</td><td>${esc(typeOf(p.schema))}</td><td>${esc(p.description)}</td></tr>`).join('');
+      const responses = Object.entries(op.responses).map(([code, r]) =>
+        `<tr><td>${esc(code)}</td><td>${esc(r.description)}</td><td>${content(r.content)}</td></tr>`).join('');
+      ops.insertAdjacentHTML('beforeend', `
+        <details>
+          <summary><span class="method ${method}">${method}</span>${esc(path)} <small>${esc(op.summary)}</small></summary>
+          <div class="body">
+            ${op.description ? '<p>' + esc(op.description) + '</p>' : ''}
+            ${params ? '<h4>Parâmetros</h4><table><tr><th>nome</th><th>em</th><th>obrigatório</th><th>tipo</th><th>descrição</th></tr>' + params + '</table>' : ''}
+            ${op.requestBody ? '<h4>Corpo</h4><p>' + content(op.requestBody.content) + '</p>' : ''}
+            <h4>Respostas</h4><table><tr><th>status</th><th>descrição</th><th>conteúdo</th></tr>${responses}</table>
+          </div>
+        </details>`);
+    }
+  }
+
+  const schemas = document.getElementById('schemas');
+  for (const [name, s] of Object.entries(spec.components.schemas || {}).sort()) {
+    const required = new Set(s.required || []);
+    const rows = Object.entries(s.properties || {}).map(([prop, ps]) =>
+      `<tr><td>${esc(prop)}${required.has(prop) ? ' *' : ''}</td><td>${esc(typeOf(ps))}</td><td>${esc(constraints(ps))}</td></tr>`).join('');
+    schemas.insertAdjacentHTML('beforeend', `
+      <details>
+        <summary>${esc(name)}</summary>
+        <div class="body"><table><tr><th>campo</th><th>tipo</th><th>restrições</th></tr>${rows}</table></div>
+      </details>`);
+  }
+})();
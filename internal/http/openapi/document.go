@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+/*
+Pacote openapi: monta o documento OpenAPI 3.1 da API em Go puro.
+
+O documento não é escrito à mão em YAML: as rotas são declaradas em
+internal/http/spec.go e os schemas são gerados por reflexão a partir das
+structs de domain (incluindo as tags `validate`). Verify compara o documento
+com as rotas registradas no chi, para que os dois não saiam de sincronia.
+*/
+
+// Document é a raiz do documento OpenAPI.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// PathItem agrupa as operações de um path por método HTTP (em minúsculas: "get", "post", ...).
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query ou header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// New cria um documento vazio com o gerador de schemas associado.
+func New(title, version string) *Document {
+	return &Document{
+		OpenAPI:    "3.1.0",
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+}
+
+// Add registra uma operação. O path usa a mesma sintaxe do chi ("/cats/{id}").
+func (d *Document) Add(method, path string, op Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	if op.Responses == nil {
+		op.Responses = map[string]*Response{}
+	}
+	(*item)[strings.ToLower(method)] = &op
+}
+
+// Operations devolve "MÉTODO path" de todas as operações, ordenado.
+func (d *Document) Operations() []string {
+	var out []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			out = append(out, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Handler serve o documento em JSON (ex: GET /openapi.json).
+// O JSON é gerado uma única vez, já que o documento não muda em tempo de execução.
+func (d *Document) Handler() http.HandlerFunc {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // mantém "<" e ">" legíveis nas descrições
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		panic("openapi: documento inválido: " + err.Error())
+	}
+	body := buf.Bytes()
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(body)
+	}
+}
+
+/*** helpers para declarar operações ***/
+
+// JSONBody descreve um corpo de requisição JSON obrigatório.
+func JSONBody(s *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: s}}}
+}
+
+// JSONResponse descreve uma resposta com corpo JSON.
+func JSONResponse(description string, s *Schema) *Response {
+	return &Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: s}}}
+}
+
+// ProblemResponse descreve uma resposta de erro application/problem+json (RFC 7807).
+func ProblemResponse(description string, s *Schema) *Response {
+	return &Response{Description: description, Content: map[string]MediaType{"application/problem+json": {Schema: s}}}
+}
+
+// EmptyResponse descreve uma resposta sem corpo (ex: 204, 304).
+func EmptyResponse(description string) *Response {
+	return &Response{Description: description}
+}
+
+// PathParam descreve um parâmetro de path (sempre obrigatório).
+func PathParam(name, description string, s *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: s}
+}
+
+// QueryParam descreve um parâmetro opcional de query string.
+func QueryParam(name, description string, s *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: s}
+}
+
+// HeaderParam descreve um header de requisição.
+func HeaderParam(name, description string, required bool) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Required: required, Schema: String()}
+}
@@ -0,0 +1,243 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ValidatorOptions configura o middleware de validação.
+type ValidatorOptions struct {
+	// ValidateResponses também valida as respostas JSON contra o contrato.
+	// Violações são apenas logadas (o cliente recebe a resposta original),
+	// para servir de alarme em desenvolvimento/homologação sem quebrar produção.
+	ValidateResponses bool
+
+	// MaxBodyBytes limita o corpo JSON lido para validação (0 = sem limite).
+	// Um corpo maior responde 413 antes de chegar ao handler.
+	MaxBodyBytes int64
+
+	// OnError escreve a resposta de erro quando a requisição viola o contrato.
+	// status é 400 (JSON malformado, path/query inválidos), 413 (corpo grande demais) ou 422 (corpo inválido).
+	OnError func(w http.ResponseWriter, r *http.Request, status int, errs []FieldError)
+}
+
+// Validator devolve um middleware que valida cada requisição contra o documento:
+// parâmetros de path e query, e o corpo JSON (campos desconhecidos são rejeitados
+// quando o schema tem additionalProperties: false).
+// Requisições sem operação correspondente seguem adiante (o chi responde 404/405).
+func (d *Document) Validator(opts ValidatorOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, pathParams, ok := d.Find(r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if errs := d.validateParams(op, pathParams, r); len(errs) > 0 {
+				opts.OnError(w, r, http.StatusBadRequest, errs)
+				return
+			}
+
+			if op.RequestBody != nil {
+				status, errs := d.validateBody(op.RequestBody, w, r, opts.MaxBodyBytes)
+				if len(errs) > 0 {
+					opts.OnError(w, r, status, errs)
+					return
+				}
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			d.checkResponse(op, r, rec)
+		})
+	}
+}
+
+// validateParams valida os parâmetros de path e query declarados na operação.
+func (d *Document) validateParams(op *Operation, pathParams map[string]string, r *http.Request) []FieldError {
+	var errs []FieldError
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		var raw string
+		var present bool
+		switch p.In {
+		case "path":
+			raw, present = pathParams[p.Name]
+		case "query":
+			present = query.Has(p.Name)
+			raw = query.Get(p.Name)
+		default:
+			continue // headers são tratados pelos próprios handlers (ex: If-Match -> 428)
+		}
+		if !present {
+			if p.Required {
+				errs = append(errs, FieldError{Field: p.Name, In: p.In, Rule: "required", Keyword: "required", Message: "parâmetro obrigatório"})
+			}
+			continue
+		}
+		errs = append(errs, d.Validate(p.Schema, d.coerceParam(p.Schema, raw), p.Name, p.In)...)
+	}
+	return errs
+}
+
+// validateBody lê o corpo (até maxBytes, se maior que 0), valida contra o schema JSON e o devolve
+// intacto para o handler. Corpos em outros formatos (content types não declarados como JSON)
+// não são validados aqui: quem os aceita (ex: importação) aplica o próprio limite.
+func (d *Document) validateBody(body *RequestBody, w http.ResponseWriter, r *http.Request, maxBytes int64) (int, []FieldError) {
+	media, ok := body.Content["application/json"]
+	if !ok || !isJSON(r.Header.Get("Content-Type")) {
+		return 0, nil
+	}
+	if maxBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+	raw, err := io.ReadAll(r.Body)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		limit := strconv.FormatInt(tooLarge.Limit, 10)
+		return http.StatusRequestEntityTooLarge, []FieldError{{In: "body", Rule: "maxBytes", Keyword: "maxBytes", Param: limit, Message: "corpo maior que " + limit + " bytes"}}
+	case err != nil:
+		return http.StatusBadRequest, []FieldError{{In: "body", Rule: "readable", Keyword: "readable", Message: err.Error()}}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		if body.Required {
+			return http.StatusBadRequest, []FieldError{{In: "body", Rule: "required", Keyword: "required", Message: "corpo da requisição obrigatório"}}
+		}
+		return 0, nil
+	}
+
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return http.StatusBadRequest, []FieldError{{In: "body", Rule: "json", Keyword: "json", Message: "JSON inválido: " + err.Error()}}
+	}
+	if errs := d.Validate(media.Schema, v, "", "body"); len(errs) > 0 {
+		return http.StatusUnprocessableEntity, errs
+	}
+	return 0, nil
+}
+
+// checkResponse valida a resposta gravada e loga violações do contrato.
+func (d *Document) checkResponse(op *Operation, r *http.Request, rec *recorder) {
+	resp, ok := op.Responses[strconv.Itoa(rec.status)]
+	if !ok {
+		log.Printf("openapi: %s %s respondeu %d, status não documentado", r.Method, r.URL.Path, rec.status)
+		return
+	}
+	contentType := rec.Header().Get("Content-Type")
+	if rec.body.Len() == 0 || !isJSON(contentType) {
+		return
+	}
+	mt, _, _ := mime.ParseMediaType(contentType)
+	media, ok := resp.Content[mt]
+	if !ok {
+		log.Printf("openapi: %s %s respondeu %d com %s, formato não documentado", r.Method, r.URL.Path, rec.status, mt)
+		return
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(rec.body.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		log.Printf("openapi: %s %s respondeu JSON inválido: %v", r.Method, r.URL.Path, err)
+		return
+	}
+	for _, e := range d.Validate(media.Schema, v, "", "response") {
+		log.Printf("openapi: %s %s resposta %d viola o contrato em %q: %s (%s)", r.Method, r.URL.Path, rec.status, e.Field, e.Message, e.Rule)
+	}
+}
+
+// Find encontra a operação para o método e path concretos (ex: GET /cats/42),
+// devolvendo também os valores dos parâmetros de path.
+// Segmentos literais têm prioridade sobre parâmetros (ex: /cats/lookup antes de /cats/{id}).
+func (d *Document) Find(method, path string) (*Operation, map[string]string, bool) {
+	segments := splitPath(path)
+	var (
+		best       *Operation
+		bestParams map[string]string
+		bestScore  = -1
+	)
+	for template, item := range d.Paths {
+		op, ok := (*item)[strings.ToLower(method)]
+		if !ok {
+			continue
+		}
+		tsegs := splitPath(template)
+		if len(tsegs) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		score := 0
+		for i, ts := range tsegs {
+			if strings.HasPrefix(ts, "{") && strings.HasSuffix(ts, "}") {
+				params[ts[1:len(ts)-1]] = segments[i]
+				continue
+			}
+			if ts != segments[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestParams, bestScore = op, params, score
+		}
+	}
+	return best, bestParams, best != nil
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true // sem Content-Type assumimos JSON (comportamento histórico da API)
+	}
+	mt, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+// recorder repassa a resposta ao cliente e guarda uma cópia para validação.
+// Só respostas JSON são copiadas: as outras não são validadas e podem não ter fim (ex: SSE).
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if isJSON(rec.Header().Get("Content-Type")) {
+		rec.body.Write(b)
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap permite que http.ResponseController alcance o ResponseWriter original.
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
@@ -0,0 +1,64 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidatorMaxBodyBytes(t *testing.T) {
+	d := New("test", "1")
+	d.Add("POST", "/cats", Operation{
+		RequestBody: JSONBody(Object(map[string]*Schema{"name": String()})),
+		Responses:   map[string]*Response{"201": {Description: "ok"}},
+	})
+	var gotStatus int
+	var gotErrs []FieldError
+	mw := d.Validator(ValidatorOptions{
+		MaxBodyBytes: 32,
+		OnError: func(w http.ResponseWriter, r *http.Request, status int, errs []FieldError) {
+			gotStatus, gotErrs = status, errs
+			w.WriteHeader(status)
+		},
+	})
+	var handlerBody string
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		handlerBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"dentro do limite", `{"name":"Mingau"}`, http.StatusCreated},
+		{"acima do limite", `{"name":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStatus, gotErrs, handlerBody = 0, nil, ""
+			req := httptest.NewRequest("POST", "/cats", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (errs %+v)", rec.Code, tt.status, gotErrs)
+			}
+			if tt.status == http.StatusCreated && handlerBody != tt.body {
+				t.Errorf("handler read %q, want the original body", handlerBody)
+			}
+			if tt.status == http.StatusRequestEntityTooLarge {
+				if gotStatus != tt.status || len(gotErrs) != 1 || gotErrs[0].Rule != "maxBytes" || gotErrs[0].Param != "32" {
+					t.Errorf("OnError(%d, %+v)", gotStatus, gotErrs)
+				}
+				if handlerBody != "" {
+					t.Error("handler ran for an oversized body")
+				}
+			}
+		})
+	}
+}
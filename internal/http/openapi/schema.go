@@ -0,0 +1,342 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaType é o "type" do JSON Schema. No OpenAPI 3.1 campos anuláveis
+// são expressos como lista (ex: ["string", "null"]), então guardamos um slice.
+type SchemaType []string
+
+// MarshalJSON emite uma string quando há um único tipo e uma lista caso contrário.
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Has indica se o tipo aceita o valor informado (ex: "null").
+func (t SchemaType) Has(name string) bool {
+	for _, v := range t {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema é o subconjunto de JSON Schema usado pela API.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"` // *Schema ou bool
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+
+	rules map[string]string // palavra-chave -> regra do validator que a gerou (ver applyValidateTag)
+}
+
+/*** construtores de schemas simples ***/
+
+func String() *Schema  { return &Schema{Type: SchemaType{"string"}} }
+func Integer() *Schema { return &Schema{Type: SchemaType{"integer"}, Format: "int64"} }
+func Number() *Schema  { return &Schema{Type: SchemaType{"number"}} }
+func Boolean() *Schema { return &Schema{Type: SchemaType{"boolean"}} }
+
+// DateTime é uma string RFC 3339.
+func DateTime() *Schema { return &Schema{Type: SchemaType{"string"}, Format: "date-time"} }
+
+// ArrayOf descreve uma lista de itens.
+func ArrayOf(items *Schema) *Schema { return &Schema{Type: SchemaType{"array"}, Items: items} }
+
+// Object monta um objeto a partir de propriedades; required lista os campos obrigatórios.
+func Object(props map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: SchemaType{"object"}, Properties: props, Required: required}
+}
+
+// Between aplica minimum/maximum a um schema numérico.
+func (s *Schema) Between(min, max float64) *Schema {
+	s.Minimum, s.Maximum = &min, &max
+	return s
+}
+
+// Describe define a descrição do schema.
+func (s *Schema) Describe(description string) *Schema {
+	s.Description = description
+	return s
+}
+
+/*** geração por reflexão ***/
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// formatter é implementado por tipos que viram string no JSON com um "format" do JSON Schema.
+type formatter interface{ JSONSchemaFormat() string }
+
+var formatType = reflect.TypeFor[formatter]()
+
+// SchemaOf gera o schema de v. Structs são registradas em components/schemas
+// (pelo nome do tipo) e referenciadas via $ref.
+func (d *Document) SchemaOf(v any) *Schema {
+	return d.schemaFor(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s *Schema
+	switch {
+	case t == timeType:
+		s = DateTime()
+	case t.Implements(formatType):
+		// tipos serializados como string com formato próprio (ex: domain.Date -> "date")
+		s = &Schema{Type: SchemaType{"string"}, Format: reflect.Zero(t).Interface().(formatter).JSONSchemaFormat()}
+	case t.Kind() == reflect.Struct:
+		s = d.component(t)
+	case t.Kind() == reflect.String:
+		s = String()
+	case t.Kind() == reflect.Bool:
+		s = Boolean()
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = Integer()
+		if t.Kind() == reflect.Int32 || t.Kind() == reflect.Uint32 {
+			s.Format = "int32"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = Number()
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		s = &Schema{Type: SchemaType{"string"}, Format: "byte"} // []byte vira base64 no JSON
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		s = ArrayOf(d.schemaFor(t.Elem()))
+	case t.Kind() == reflect.Map:
+		s = &Schema{Type: SchemaType{"object"}, AdditionalProperties: d.schemaFor(t.Elem())}
+	default:
+		s = &Schema{} // interface{}: qualquer valor
+	}
+
+	if nullable && s.Ref == "" && len(s.Type) > 0 {
+		s.Type = append(s.Type, "null")
+	}
+	return s
+}
+
+// Partial registra em components/schemas uma cópia do componente referenciado por ref,
+// com o nome name e sem campos obrigatórios (ex: respostas com sparse fieldsets),
+// e devolve a referência para a cópia.
+func (d *Document) Partial(ref *Schema, name string) *Schema {
+	orig, ok := d.Components.Schemas[strings.TrimPrefix(ref.Ref, "#/components/schemas/")]
+	if !ok {
+		panic("openapi: Partial de schema que não é componente: " + ref.Ref)
+	}
+	cp := *orig
+	cp.Required = nil
+	d.Components.Schemas[name] = &cp
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// component registra a struct em components/schemas e devolve a referência.
+func (d *Document) component(t reflect.Type) *Schema {
+	name := t.Name()
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := d.Components.Schemas[name]; ok {
+		return ref
+	}
+	obj := Object(map[string]*Schema{})
+	obj.AdditionalProperties = false // campos desconhecidos (ex: "nome" no lugar de "name") são rejeitados
+	d.Components.Schemas[name] = obj // registra antes de descer (evita loop em tipos recursivos)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		fs := d.schemaFor(f.Type)
+		if applyValidateTag(fs, f.Type, f.Tag.Get("validate")) {
+			obj.Required = append(obj.Required, name)
+		}
+		obj.Properties[name] = fs
+	}
+	return ref
+}
+
+// jsonName devolve o nome do campo no JSON (tag `json`, ou o nome Go se não houver).
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// applyValidateTag traduz as regras do go-playground/validator em restrições do schema
+// e guarda qual regra gerou cada palavra-chave, para que os erros do contrato usem o
+// mesmo nome de regra dos erros do validator (min, lte, oneof...).
+// Retorna true se o campo for obrigatório ("required").
+func applyValidateTag(s *Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return required // regras após dive valem para os itens, não para o campo
+		case "required":
+			required = true
+		case "min", "gte":
+			s.setRule(setLowerBound(s, t, param, false), name)
+		case "max", "lte":
+			s.setRule(setUpperBound(s, t, param, false), name)
+		case "gt":
+			s.setRule(setLowerBound(s, t, param, true), name)
+		case "lt":
+			s.setRule(setUpperBound(s, t, param, true), name)
+		case "len":
+			s.setRule(setLowerBound(s, t, param, false), name)
+			s.setRule(setUpperBound(s, t, param, false), name)
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, v)
+			}
+			s.setRule("enum", name)
+		case "email":
+			s.Format = "email"
+			s.setRule("format", name)
+		case "url", "http_url":
+			s.Format = "uri"
+			s.setRule("format", name)
+		case "numeric":
+			s.Pattern = "^[0-9]+$"
+			s.setRule("pattern", name)
+		}
+	}
+	return required
+}
+
+// setLowerBound aplica o limite inferior e devolve a palavra-chave usada ("" se param for inválido).
+func setLowerBound(s *Schema, t reflect.Type, param string, exclusive bool) string {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v := int(n)
+		if exclusive {
+			v++
+		}
+		s.MinLength = &v
+		return "minLength"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		v := int(n)
+		if exclusive {
+			v++
+		}
+		s.MinItems = &v
+		return "minItems"
+	default:
+		if exclusive {
+			s.ExclusiveMinimum = &n
+			return "exclusiveMinimum"
+		}
+		s.Minimum = &n
+		return "minimum"
+	}
+}
+
+// setUpperBound aplica o limite superior e devolve a palavra-chave usada ("" se param for inválido).
+func setUpperBound(s *Schema, t reflect.Type, param string, exclusive bool) string {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v := int(n)
+		if exclusive {
+			v--
+		}
+		s.MaxLength = &v
+		return "maxLength"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		v := int(n)
+		if exclusive {
+			v--
+		}
+		s.MaxItems = &v
+		return "maxItems"
+	default:
+		if exclusive {
+			s.ExclusiveMaximum = &n
+			return "exclusiveMaximum"
+		}
+		s.Maximum = &n
+		return "maximum"
+	}
+}
+
+func (s *Schema) setRule(keyword, rule string) {
+	if keyword == "" {
+		return
+	}
+	if s.rules == nil {
+		s.rules = map[string]string{}
+	}
+	s.rules[keyword] = rule
+}
+
+// defaultRules é a regra equivalente do validator para restrições montadas à mão (ex: Between),
+// sem uma tag validate de origem.
+var defaultRules = map[string]string{
+	"minLength":        "min",
+	"maxLength":        "max",
+	"minItems":         "min",
+	"maxItems":         "max",
+	"minimum":          "gte",
+	"maximum":          "lte",
+	"exclusiveMinimum": "gt",
+	"exclusiveMaximum": "lt",
+	"enum":             "oneof",
+}
+
+// rule devolve o nome da regra do validator para a palavra-chave; palavras-chave sem
+// equivalente no validator (type, additionalProperties, pattern...) são devolvidas como estão.
+func (s *Schema) rule(keyword string) string {
+	if r, ok := s.rules[keyword]; ok {
+		return r
+	}
+	if r, ok := defaultRules[keyword]; ok {
+		return r
+	}
+	return keyword
+}
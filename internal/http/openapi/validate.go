@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError descreve uma violação do contrato em um campo específico.
+// Rule é o nome da regra do validator que gerou a restrição (min, lte, oneof, ...), o mesmo
+// que os handlers devolvem quando o validator recusa o campo; restrições sem tag de origem
+// usam o equivalente (minimum -> gte) ou, sem equivalente, a palavra-chave (type, additionalProperties).
+// Keyword é a palavra-chave do JSON Schema violada e Param guarda o limite/valor esperado,
+// para que a camada HTTP possa traduzir a mensagem.
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"` // body, path ou query
+	Rule    string `json:"rule"`
+	Keyword string `json:"keyword"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// resolve segue o $ref (apenas referências locais a components/schemas).
+func (d *Document) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// Validate valida um valor decodificado com json.Decoder.UseNumber contra o schema.
+// field é o caminho do valor (ex: "name", "items[2].weight_kg") e in a origem (body, path, query).
+func (d *Document) Validate(s *Schema, v any, field, in string) []FieldError {
+	var errs []FieldError
+	d.validate(s, v, field, in, &errs)
+	return errs
+}
+
+func (d *Document) validate(s *Schema, v any, field, in string, errs *[]FieldError) {
+	s = d.resolve(s)
+	if s == nil {
+		return
+	}
+	add := func(keyword, param, msg string) {
+		*errs = append(*errs, FieldError{Field: field, In: in, Rule: s.rule(keyword), Keyword: keyword, Param: param, Message: msg})
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, v) {
+		add("type", strings.Join(s.Type, "|"), "deve ser do tipo "+strings.Join(s.Type, " ou "))
+		return
+	}
+	if v == nil {
+		return // null permitido pelo tipo: não há mais o que checar
+	}
+
+	if len(s.Enum) > 0 {
+		ok := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			options := make([]string, len(s.Enum))
+			for i, e := range s.Enum {
+				options[i] = fmt.Sprint(e)
+			}
+			add("enum", strings.Join(options, " "), "deve ser um de: "+strings.Join(options, ", "))
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		n := len([]rune(val))
+		if s.MinLength != nil && n < *s.MinLength {
+			add("minLength", strconv.Itoa(*s.MinLength), fmt.Sprintf("deve ter pelo menos %d caracteres", *s.MinLength))
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			add("maxLength", strconv.Itoa(*s.MaxLength), fmt.Sprintf("deve ter no máximo %d caracteres", *s.MaxLength))
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(val) {
+				add("pattern", s.Pattern, "formato inválido")
+			}
+		}
+		switch s.Format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, val); err != nil {
+				add("format", s.Format, "deve ser uma data/hora RFC 3339")
+			}
+		case "date":
+			if _, err := time.Parse(time.DateOnly, val); err != nil {
+				add("format", s.Format, "deve ser uma data AAAA-MM-DD")
+			}
+		case "email":
+			if _, err := mail.ParseAddress(val); err != nil {
+				add("format", s.Format, "deve ser um e-mail válido")
+			}
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		bound := func(b *float64) string { return strconv.FormatFloat(*b, 'f', -1, 64) }
+		if s.Minimum != nil && f < *s.Minimum {
+			add("minimum", bound(s.Minimum), "deve ser maior ou igual a "+bound(s.Minimum))
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			add("maximum", bound(s.Maximum), "deve ser menor ou igual a "+bound(s.Maximum))
+		}
+		if s.ExclusiveMinimum != nil && f <= *s.ExclusiveMinimum {
+			add("exclusiveMinimum", bound(s.ExclusiveMinimum), "deve ser maior que "+bound(s.ExclusiveMinimum))
+		}
+		if s.ExclusiveMaximum != nil && f >= *s.ExclusiveMaximum {
+			add("exclusiveMaximum", bound(s.ExclusiveMaximum), "deve ser menor que "+bound(s.ExclusiveMaximum))
+		}
+	case []any:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			add("minItems", strconv.Itoa(*s.MinItems), fmt.Sprintf("deve ter pelo menos %d itens", *s.MinItems))
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			add("maxItems", strconv.Itoa(*s.MaxItems), fmt.Sprintf("deve ter no máximo %d itens", *s.MaxItems))
+		}
+		for i, item := range val {
+			d.validate(s.Items, item, fmt.Sprintf("%s[%d]", field, i), in, errs)
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				*errs = append(*errs, FieldError{Field: join(field, name), In: in, Rule: "required", Keyword: "required", Message: "campo obrigatório"})
+			}
+		}
+		// percorre em ordem alfabética para que a lista de erros seja estável
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ps, ok := s.Properties[k]; ok {
+				d.validate(ps, val[k], join(field, k), in, errs)
+				continue
+			}
+			switch extra := s.AdditionalProperties.(type) {
+			case bool:
+				if !extra {
+					*errs = append(*errs, FieldError{Field: join(field, k), In: in, Rule: "additionalProperties", Keyword: "additionalProperties", Message: "campo desconhecido"})
+				}
+			case *Schema:
+				d.validate(extra, val[k], join(field, k), in, errs)
+			}
+		}
+	}
+}
+
+// matchesType verifica se o valor decodificado corresponde a algum dos tipos aceitos.
+func matchesType(types SchemaType, v any) bool {
+	for _, t := range types {
+		switch val := v.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case json.Number:
+			if t == "number" {
+				return true
+			}
+			if t == "integer" {
+				if _, err := val.Int64(); err == nil {
+					return true
+				}
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coerceParam converte um parâmetro textual (path/query) para o tipo do schema,
+// para que ele possa ser validado como se viesse de um JSON.
+func (d *Document) coerceParam(s *Schema, raw string) any {
+	s = d.resolve(s)
+	if s == nil {
+		return raw
+	}
+	switch {
+	case s.Type.Has("integer"), s.Type.Has("number"):
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case s.Type.Has("boolean"):
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case s.Type.Has("array"):
+		items := []any{}
+		for _, part := range strings.Split(raw, ",") {
+			items = append(items, d.coerceParam(s.Items, part))
+		}
+		return items
+	}
+	return raw
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type ruleSample struct {
+	Name   string   `json:"name" validate:"required,min=2,max=5"`
+	Age    int      `json:"age" validate:"gte=0,lte=40"`
+	Weight *float64 `json:"weight" validate:"omitempty,gt=0,lt=50"`
+	Kind   string   `json:"kind" validate:"oneof=cat dog"`
+	Tags   []string `json:"tags" validate:"max=2"`
+	Email  string   `json:"email" validate:"omitempty,email"`
+	Chip   string   `json:"chip" validate:"len=3"`
+}
+
+// Os erros do contrato usam os nomes de regra do validator, como os erros dos handlers.
+func TestValidateRules(t *testing.T) {
+	d := New("test", "1")
+	s := d.SchemaOf(ruleSample{})
+	page := Integer().Between(1, 100)
+
+	tests := []struct {
+		name    string
+		schema  *Schema
+		body    string
+		field   string
+		rule    string
+		keyword string
+	}{
+		{"min em string", s, `{"name":"a","kind":"cat","chip":"abc"}`, "name", "min", "minLength"},
+		{"max em string", s, `{"name":"abcdef","kind":"cat","chip":"abc"}`, "name", "max", "maxLength"},
+		{"gte", s, `{"name":"ab","age":-1,"kind":"cat","chip":"abc"}`, "age", "gte", "minimum"},
+		{"lte", s, `{"name":"ab","age":41,"kind":"cat","chip":"abc"}`, "age", "lte", "maximum"},
+		{"gt", s, `{"name":"ab","weight":0,"kind":"cat","chip":"abc"}`, "weight", "gt", "exclusiveMinimum"},
+		{"lt", s, `{"name":"ab","weight":50,"kind":"cat","chip":"abc"}`, "weight", "lt", "exclusiveMaximum"},
+		{"oneof", s, `{"name":"ab","kind":"cow","chip":"abc"}`, "kind", "oneof", "enum"},
+		{"max em lista", s, `{"name":"ab","kind":"cat","tags":["a","b","c"],"chip":"abc"}`, "tags", "max", "maxItems"},
+		{"email", s, `{"name":"ab","kind":"cat","email":"x","chip":"abc"}`, "email", "email", "format"},
+		{"len", s, `{"name":"ab","kind":"cat","chip":"abcd"}`, "chip", "len", "maxLength"},
+		{"required", s, `{"kind":"cat","chip":"abc"}`, "name", "required", "required"},
+		{"campo desconhecido", s, `{"name":"ab","kind":"cat","chip":"abc","nome":"x"}`, "nome", "additionalProperties", "additionalProperties"},
+		{"tipo", s, `{"name":2,"kind":"cat","chip":"abc"}`, "name", "type", "type"},
+		{"Between sem tag", page, `0`, "", "gte", "minimum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.body))
+			dec.UseNumber()
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				t.Fatal(err)
+			}
+			errs := d.Validate(tt.schema, v, "", "body")
+			if len(errs) != 1 {
+				t.Fatalf("errs = %+v, want one error", errs)
+			}
+			if e := errs[0]; e.Field != tt.field || e.Rule != tt.rule || e.Keyword != tt.keyword {
+				t.Errorf("got %s/%s/%s, want %s/%s/%s", e.Field, e.Rule, e.Keyword, tt.field, tt.rule, tt.keyword)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// chiParamRegex remove a expressão regular de parâmetros do chi: {id:[0-9]+} -> {id}.
+var chiParamRegex = regexp.MustCompile(`\{([^}:]+):[^}]*\}`)
+
+// Verify compara as rotas registradas no chi com as operações do documento.
+// Retorna erro listando rotas sem documentação e operações documentadas sem rota.
+// Prefixos em ignore (ex: "/docs") são desconsiderados dos dois lados.
+func Verify(routes chi.Routes, d *Document, ignore ...string) error {
+	registered := map[string]bool{}
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = normalizePath(route)
+		for _, prefix := range ignore {
+			if strings.HasPrefix(route, prefix) {
+				return nil
+			}
+		}
+		registered[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	documented := map[string]bool{}
+	for _, op := range d.Operations() {
+		documented[op] = true
+	}
+
+	var problems []string
+	for op := range registered {
+		if !documented[op] {
+			problems = append(problems, "rota sem documentação: "+op)
+		}
+	}
+	for op := range documented {
+		if !registered[op] {
+			problems = append(problems, "operação documentada sem rota: "+op)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("openapi: documento e router fora de sincronia:\n  %s", strings.Join(problems, "\n  "))
+}
+
+// normalizePath converte o padrão do chi para o formato de path do OpenAPI.
+// Sub-rotas montadas com r.Route aparecem com "/" no final (ex: "/cats/"), que é removida.
+func normalizePath(route string) string {
+	route = chiParamRegex.ReplaceAllString(route, "{$1}")
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	return route
+}
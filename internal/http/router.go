@@ -1,16 +1,40 @@
 package http
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/dya-andrade/cat-api/internal/config"
 	"github.com/dya-andrade/cat-api/internal/http/handlers"
+	"github.com/dya-andrade/cat-api/internal/http/openapi"
 	"github.com/dya-andrade/cat-api/internal/service"
 )
 
-func NewRouter(catSvc service.CatService) http.Handler {
+// Services agrupa os serviços usados pelos handlers HTTP.
+type Services struct {
+	Cats     service.CatService
+	Jobs     service.JobService
+	Imports  service.ImportService
+	Exports  service.ExportService
+	Owners   service.OwnerService
+	Adoption service.AdoptionService
+	Health   service.HealthService
+	Weights  service.WeightService
+	Breeds   service.BreedService
+	Chips    service.MicrochipService
+	Pedigree service.PedigreeService
+	Tags     service.TagService
+	Attrs    service.AttributeService
+	Notes    service.NoteService
+	Audit    service.AuditService
+	Webhooks service.WebhookService
+	Stream   service.StreamService
+}
+
+func NewRouter(cfg config.Config, svc Services) http.Handler {
 	r := chi.NewRouter() // Cria um novo roteador usando o chi
 
 	// middlewares essenciais
@@ -19,6 +43,20 @@ func NewRouter(catSvc service.CatService) http.Handler {
 	r.Use(middleware.Logger)             // Loga informações de cada requisição (método, rota, tempo, etc)
 	r.Use(middleware.Recoverer)          // Recupera de panics e retorna erro 500 ao invés de travar o servidor
 	r.Use(middleware.Heartbeat("/live")) // Endpoint simples para checagem de vida (/live)
+	r.Use(handlers.Tenant)               // Leva o X-Tenant-ID (esquema de atributos) para o contexto
+	r.Use(handlers.Requester)            // Leva quem fez a requisição (X-Actor, IP, User-Agent) para a auditoria
+
+	// contrato OpenAPI (declarado em spec.go): valida path, query e corpo JSON de cada requisição
+	spec := apiSpec()
+	r.Use(spec.Validator(openapi.ValidatorOptions{
+		ValidateResponses: cfg.ValidateResponses,
+		MaxBodyBytes:      cfg.BodyMaxBytes,
+		OnError:           handlers.ContractError,
+	}))
+
+	// 404/405 também no formato application/problem+json
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
 
 	// health/ready
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -26,17 +64,169 @@ func NewRouter(catSvc service.CatService) http.Handler {
 		_, _ = w.Write([]byte(`{"status":"ok"}`)) // Retorna JSON simples indicando que está saudável
 	})
 
+	// documentação OpenAPI (contrato declarado em spec.go) e interface de docs embutida
+	r.Get("/openapi.json", spec.Handler())
+	r.Handle("/docs", http.RedirectHandler("/docs/", http.StatusMovedPermanently))
+	r.Handle("/docs/*", openapi.DocsHandler("/docs"))
+
 	// handlers
-	cats := handlers.NewCatsHandler(catSvc) // Cria o handler dos gatos, passando o serviço
+	cats := handlers.NewCatsHandler(svc.Cats)                              // Cria o handler dos gatos, passando o serviço
+	imports := handlers.NewImportsHandler(svc.Imports, cfg.ImportMaxBytes) // Importação de arquivos CSV/NDJSON
+	exports := handlers.NewExportsHandler(svc.Exports)                     // Exportação em CSV/NDJSON/XLSX
+	jobs := handlers.NewJobsHandler(svc.Jobs)                              // Acompanhamento de jobs em background
+	owners := handlers.NewOwnersHandler(svc.Owners)                        // Tutores e tutela dos gatos
+	adoption := handlers.NewAdoptionHandler(svc.Adoption)                  // Status de adoção e candidaturas
+	health := handlers.NewHealthHandler(svc.Health)                        // Prontuário e relatório de vacinas
+	weights := handlers.NewWeightsHandler(svc.Weights)                     // Histórico de peso e alertas
+	breeds := handlers.NewBreedsHandler(svc.Breeds)                        // Catálogo de raças
+	chips := handlers.NewMicrochipsHandler(svc.Chips)                      // Busca por microchip e auditoria
+	pedigree := handlers.NewPedigreeHandler(svc.Pedigree)                  // Genealogia e ninhadas
+	tags := handlers.NewTagsHandler(svc.Tags)                              // Etiquetas dos gatos
+	attrs := handlers.NewAttributesHandler(svc.Attrs)                      // Esquema de atributos por tenant
+	notes := handlers.NewNotesHandler(svc.Notes)                           // Notas e linha do tempo dos gatos
+	audit := handlers.NewAuditHandler(svc.Audit)                           // Registro de auditoria
+	webhooks := handlers.NewWebhooksHandler(svc.Webhooks)                  // Assinaturas de webhooks e entregas
+	stream := handlers.NewStreamHandler(svc.Stream, cfg.StreamHeartbeat)   // Stream SSE dos eventos dos gatos
 
 	r.Route("/cats", func(r chi.Router) {
-		r.Get("/", cats.List)        // GET /cats?limit=...&cursor=RFC3339 -> lista gatos
-		r.Post("/", cats.Create)     // POST /cats -> cria novo gato
-		r.Get("/{id}", cats.GetByID) // GET /cats/{id} -> busca gato por ID
-		//r.Put("/{id}", cats.Update)    // PUT /cats/{id} -> atualiza gato (comentado)
-		//r.Delete("/{id}", cats.Delete) // DELETE /cats/{id} -> remove gato (comentado)
+		r.Get("/", cats.List)             // GET /cats?limit=...&cursor=RFC3339&name=...&breed=...&status=... -> lista gatos
+		r.Post("/", cats.Create)          // POST /cats -> cria novo gato
+		r.Post("/import", imports.Import) // POST /cats/import?format=csv|ndjson -> agenda importação (202 + job)
+		r.Get("/export", exports.Export)  // GET /cats/export?format=csv|ndjson|xlsx -> exporta (stream ou job)
+		r.Get("/lookup", chips.Lookup)    // GET /cats/lookup?microchip=... -> gato pelo microchip (busca registrada)
+		r.Get("/stream", stream.Stream)   // GET /cats/stream -> eventos dos gatos em SSE (retoma com Last-Event-ID)
+		r.Get("/{id}", cats.GetByID)      // GET /cats/{id} -> busca gato por ID (envia ETag)
+		r.Put("/{id}", cats.Update)       // PUT /cats/{id} -> atualiza gato (exige If-Match)
+		r.Delete("/{id}", cats.Delete)    // DELETE /cats/{id} -> remove gato (exige If-Match)
+
+		r.Get("/{id}/versions", cats.Versions) // GET /cats/{id}/versions -> versões guardadas (GET /cats/{id}?as_of= lê uma delas)
+		r.Post("/{id}/revert", cats.Revert)    // POST /cats/{id}/revert?version=N -> restaura a versão N como nova (exige If-Match)
+
+		r.Get("/{id}/owners", owners.CatOwners)                // GET /cats/{id}/owners?history=true -> tutores do gato
+		r.Post("/{id}/owners", owners.AddCatOwner)             // POST /cats/{id}/owners -> vincula um tutor
+		r.Delete("/{id}/owners/{ownerId}", owners.EndCatOwner) // DELETE /cats/{id}/owners/{ownerId} -> encerra o vínculo
+		r.Post("/{id}/transfer", owners.Transfer)              // POST /cats/{id}/transfer -> transfere a tutela principal
+		r.Get("/{id}/transfers", owners.Transfers)             // GET /cats/{id}/transfers -> histórico de transferências
+
+		r.Post("/{id}/status", adoption.ChangeStatus)         // POST /cats/{id}/status -> muda o status de adoção (409 se inválido)
+		r.Get("/{id}/status/history", adoption.StatusHistory) // GET /cats/{id}/status/history -> transições de status
+		r.Post("/{id}/applications", adoption.Apply)          // POST /cats/{id}/applications -> candidatura de adoção
+		r.Get("/{id}/applications", adoption.CatApplications) // GET /cats/{id}/applications?status=... -> candidaturas do gato
+
+		r.Get("/{id}/health", health.Summary)                               // GET /cats/{id}/health -> resumo do prontuário
+		r.Get("/{id}/vaccinations", health.Vaccinations)                    // GET /cats/{id}/vaccinations -> doses aplicadas
+		r.Post("/{id}/vaccinations", health.AddVaccination)                 // POST /cats/{id}/vaccinations -> registra uma dose
+		r.Delete("/{id}/vaccinations/{recordId}", health.DeleteVaccination) // DELETE /cats/{id}/vaccinations/{recordId}
+		r.Get("/{id}/vet-visits", health.VetVisits)                         // GET /cats/{id}/vet-visits -> consultas
+		r.Post("/{id}/vet-visits", health.AddVetVisit)                      // POST /cats/{id}/vet-visits -> registra uma consulta
+		r.Delete("/{id}/vet-visits/{recordId}", health.DeleteVetVisit)      // DELETE /cats/{id}/vet-visits/{recordId}
+		r.Get("/{id}/medications", health.Medications)                      // GET /cats/{id}/medications?active=true -> tratamentos
+		r.Post("/{id}/medications", health.AddMedication)                   // POST /cats/{id}/medications -> registra um tratamento
+		r.Delete("/{id}/medications/{recordId}", health.DeleteMedication)   // DELETE /cats/{id}/medications/{recordId}
+		r.Get("/{id}/allergies", health.Allergies)                          // GET /cats/{id}/allergies -> alergias
+		r.Post("/{id}/allergies", health.AddAllergy)                        // POST /cats/{id}/allergies -> registra uma alergia
+		r.Delete("/{id}/allergies/{recordId}", health.DeleteAllergy)        // DELETE /cats/{id}/allergies/{recordId}
+		r.Get("/{id}/neutering", health.Neutering)                          // GET /cats/{id}/neutering -> situação de castração
+		r.Put("/{id}/neutering", health.SetNeutering)                       // PUT /cats/{id}/neutering -> informa a castração
+
+		r.Get("/{id}/weights", weights.Series)               // GET /cats/{id}/weights?from=&to=&bucket=week -> série de peso
+		r.Post("/{id}/weights", weights.Add)                 // POST /cats/{id}/weights -> registra uma medição
+		r.Delete("/{id}/weights/{weightId}", weights.Delete) // DELETE /cats/{id}/weights/{weightId} -> apaga uma medição
+
+		r.Get("/{id}/pedigree", pedigree.Pedigree)       // GET /cats/{id}/pedigree?generations=4 -> ancestrais e COI
+		r.Get("/{id}/descendants", pedigree.Descendants) // GET /cats/{id}/descendants?generations=4 -> descendentes
+
+		r.Get("/{id}/tags", tags.CatTags)    // GET /cats/{id}/tags -> etiquetas do gato
+		r.Put("/{id}/tags", tags.SetCatTags) // PUT /cats/{id}/tags -> substitui as etiquetas do gato
+
+		r.Get("/{id}/notes", notes.List)                     // GET /cats/{id}/notes -> notas do gato (fixadas primeiro)
+		r.Post("/{id}/notes", notes.Create)                  // POST /cats/{id}/notes -> cria uma nota
+		r.Put("/{id}/notes/{noteId}", notes.Update)          // PUT /cats/{id}/notes/{noteId} -> edita (nova versão) ou fixa
+		r.Delete("/{id}/notes/{noteId}", notes.Delete)       // DELETE /cats/{id}/notes/{noteId} -> apaga a nota
+		r.Get("/{id}/notes/{noteId}/history", notes.History) // GET /cats/{id}/notes/{noteId}/history -> versões do texto
+		r.Get("/{id}/timeline", notes.Timeline)              // GET /cats/{id}/timeline?kinds=&cursor= -> atividades do gato
+	})
+	r.Post("/cats:batch", cats.Batch) // POST /cats:batch -> cria/atualiza/remove em lote
+
+	r.Route("/owners", func(r chi.Router) {
+		r.Get("/", owners.List)          // GET /owners?name=...&email=... -> lista tutores
+		r.Post("/", owners.Create)       // POST /owners -> cadastra tutor
+		r.Get("/{id}", owners.GetByID)   // GET /owners/{id} -> busca tutor
+		r.Put("/{id}", owners.Update)    // PUT /owners/{id} -> atualiza tutor
+		r.Delete("/{id}", owners.Delete) // DELETE /owners/{id} -> remove tutor sem histórico
+		r.Get("/{id}/cats", owners.Cats) // GET /owners/{id}/cats?history=true -> gatos do tutor
+	})
+
+	r.Route("/applications", func(r chi.Router) {
+		r.Get("/", adoption.Applications)         // GET /applications?status=...&owner_id=... -> lista candidaturas
+		r.Get("/{id}", adoption.GetApplication)   // GET /applications/{id} -> busca candidatura
+		r.Post("/{id}/approve", adoption.Approve) // POST /applications/{id}/approve -> aprova (gato adotado)
+		r.Post("/{id}/reject", adoption.Reject)   // POST /applications/{id}/reject -> rejeita
+	})
+
+	r.Route("/breeds", func(r chi.Router) {
+		r.Get("/", breeds.Search)      // GET /breeds?prefix=sia -> autocomplete por nome, tradução ou apelido
+		r.Get("/stats", breeds.Stats)  // GET /breeds/stats -> gatos por raça e raças não reconhecidas
+		r.Get("/{id}", breeds.GetByID) // GET /breeds/{id} -> raça com traduções e apelidos
 	})
 
+	r.Route("/litters", func(r chi.Router) {
+		r.Post("/", pedigree.CreateLitter) // POST /litters -> registra uma ninhada
+		r.Get("/coi", pedigree.MatingCOI)  // GET /litters/coi?sire_id=&dam_id= -> COI previsto de um cruzamento
+		r.Get("/{id}", pedigree.GetLitter) // GET /litters/{id} -> ninhada com filhotes e COI
+	})
+
+	r.Route("/tags", func(r chi.Router) {
+		r.Get("/", tags.List)               // GET /tags?prefix=in -> autocomplete com a quantidade de gatos
+		r.Post("/{id}/rename", tags.Rename) // POST /tags/{id}/rename -> renomeia (409 se o nome já existir)
+		r.Post("/{id}/merge", tags.Merge)   // POST /tags/{id}/merge -> junta na etiqueta "into"
+		r.Delete("/{id}", tags.Delete)      // DELETE /tags/{id} -> apaga e tira de todos os gatos
+	})
+
+	r.Route("/attributes", func(r chi.Router) {
+		r.Get("/", attrs.List)           // GET /attributes -> esquema de atributos do tenant
+		r.Put("/{key}", attrs.Put)       // PUT /attributes/{key} -> cria ou altera uma chave
+		r.Delete("/{key}", attrs.Delete) // DELETE /attributes/{key} -> remove uma chave
+	})
+
+	r.Route("/audit", func(r chi.Router) {
+		r.Get("/", audit.List)         // GET /audit?entity=cat&id=42&actor=... -> mudanças registradas
+		r.Get("/export", audit.Export) // GET /audit/export?format=csv|ndjson -> exporta o registro filtrado
+	})
+
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", webhooks.List)                                             // GET /webhooks -> assinaturas
+		r.Post("/", webhooks.Create)                                          // POST /webhooks -> cadastra (devolve o segredo)
+		r.Get("/{id}", webhooks.GetByID)                                      // GET /webhooks/{id} -> assinatura
+		r.Put("/{id}", webhooks.Update)                                       // PUT /webhooks/{id} -> altera, reativa ou desativa
+		r.Delete("/{id}", webhooks.Delete)                                    // DELETE /webhooks/{id} -> remove
+		r.Get("/{id}/deliveries", webhooks.Deliveries)                        // GET /webhooks/{id}/deliveries?status= -> entregas
+		r.Post("/{id}/deliveries/{deliveryId}/redeliver", webhooks.Redeliver) // POST .../redeliver -> reenvia (202)
+	})
+
+	r.Get("/microchip-lookups", chips.Lookups) // GET /microchip-lookups?microchip=&cat_id= -> registro das buscas por microchip
+
+	r.Route("/weight-alerts", func(r chi.Router) {
+		r.Get("/", weights.Alerts)                       // GET /weight-alerts?cat_id=&kind=&open=true -> alertas de peso
+		r.Post("/{id}/acknowledge", weights.Acknowledge) // POST /weight-alerts/{id}/acknowledge -> reconhece o alerta
+	})
+
+	r.Route("/reports", func(r chi.Router) {
+		r.Get("/vaccinations-due", health.VaccinationsDue)                 // GET /reports/vaccinations-due?status=overdue|upcoming
+		r.Post("/vaccinations-due/refresh", health.RefreshVaccinationsDue) // POST /reports/vaccinations-due/refresh -> recalcula (202 + job)
+	})
+
+	r.Route("/jobs", func(r chi.Router) {
+		r.Get("/{id}", jobs.GetByID)       // GET /jobs/{id} -> estado e progresso do job
+		r.Get("/{id}/result", jobs.Result) // GET /jobs/{id}/result -> download do resultado (relatório, exportação)
+	})
+
+	// Confere se toda rota registrada está documentada em spec.go (e vice-versa).
+	// Quem barra a divergência é o spec_test.go; aqui só avisamos no log, sem derrubar o servidor.
+	if err := openapi.Verify(r, spec, "/docs"); err != nil {
+		log.Printf("openapi: spec out of sync with router: %v", err)
+	}
+
 	return r // Retorna o roteador configurado
 }
 
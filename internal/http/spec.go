@@ -0,0 +1,1530 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+	"github.com/dya-andrade/cat-api/internal/http/handlers"
+	"github.com/dya-andrade/cat-api/internal/http/openapi"
+)
+
+// apiSpec declara o contrato OpenAPI de cada rota registrada em NewRouter.
+// Ao adicionar/remover uma rota no router, atualize também este arquivo:
+// spec_test.go chama openapi.Verify e falha se os dois divergirem.
+func apiSpec() *openapi.Document {
+	doc := openapi.New("Cat API", "1.0.0")
+	doc.Info.Description = "API para cadastro de gatos."
+
+	// schemas reutilizados
+	cat := doc.SchemaOf(domain.Cat{})
+	errResp := openapi.ProblemResponse("Erro (RFC 7807); detail no idioma do Accept-Language", doc.SchemaOf(handlers.Problem{}))
+	// type é relativo ("/problems/validation-error"): pela RFC 7807, uma referência URI
+	// resolvida contra a URL da requisição, como o href de um link
+	problemType := doc.Components.Schemas["Problem"].Properties["type"]
+	problemType.Format = "uri-reference"
+	problemType.Describe("Tipo do problema: referência URI relativa (/problems/<tipo>), resolvida contra a URL da requisição (RFC 3986)")
+	catID := openapi.PathParam("id", "ID do gato", openapi.Integer().Between(1, 9223372036854775807))
+	etag := map[string]openapi.Header{"ETag": {Description: `Versão do recurso ("<id>-<versão>")`, Schema: openapi.String()}}
+	ifMatch := openapi.HeaderParam("If-Match", "ETag obtido no GET; use * para ignorar a versão", true)
+	ifNoneMatch := openapi.HeaderParam("If-None-Match", "ETag da última leitura; responde 304 se nada mudou", false)
+	tenant := openapi.HeaderParam(handlers.TenantHeader, "Tenant que define o esquema de atributos (padrão "+domain.DefaultTenant+")", false)
+
+	// negotiated descreve uma resposta disponível em todos os formatos negociáveis pelo Accept
+	// (JSON, MessagePack, XML e, em listagens, CSV). MessagePack e XML têm os mesmos campos do JSON.
+	negotiated := func(description string, s *openapi.Schema, list bool) *openapi.Response {
+		resp := &openapi.Response{Description: description, Content: map[string]openapi.MediaType{}}
+		for _, mt := range handlers.ResponseTypes(list) {
+			schema := s
+			if mt == "text/csv" {
+				schema = openapi.String().Describe("Colunas: " + strings.Join(exporter.Columns(), ", "))
+			}
+			resp.Content[mt] = openapi.MediaType{Schema: schema}
+		}
+		return resp
+	}
+
+	doc.Add("GET", "/health", openapi.Operation{
+		OperationID: "health",
+		Summary:     "Checagem de saúde",
+		Tags:        []string{"infra"},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("API saudável", openapi.Object(map[string]*openapi.Schema{"status": openapi.String()})),
+		},
+	})
+
+	doc.Add("GET", "/openapi.json", openapi.Operation{
+		OperationID: "openapi",
+		Summary:     "Este documento OpenAPI",
+		Tags:        []string{"infra"},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Documento OpenAPI 3.1", &openapi.Schema{Type: openapi.SchemaType{"object"}}),
+		},
+	})
+
+	catStatus := &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{}}
+	for _, st := range domain.CatStatuses {
+		catStatus.Enum = append(catStatus.Enum, st)
+	}
+
+	// pelagem: vocabulário controlado
+	coatColor := &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{}}
+	for _, c := range domain.CoatColors {
+		coatColor.Enum = append(coatColor.Enum, c)
+	}
+	coatPattern := &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{}}
+	for _, p := range domain.CoatPatterns {
+		coatPattern.Enum = append(coatPattern.Enum, p)
+	}
+
+	// filtros compartilhados pela listagem e pela exportação
+	catFilters := []openapi.Parameter{
+		openapi.QueryParam("name", "Busca parcial no nome", openapi.String()),
+		openapi.QueryParam("breed", "Raça exata (sem diferenciar maiúsculas/minúsculas); nomes, traduções e apelidos do catálogo levam à mesma raça", openapi.String()),
+		openapi.QueryParam("status", "Status de adoção", catStatus),
+		openapi.QueryParam("coat", "Cores da pelagem separadas por vírgula; o gato precisa ter todas (ex: black,white)", openapi.ArrayOf(coatColor)),
+		openapi.QueryParam("pattern", "Padrões da pelagem separados por vírgula; vale qualquer um (ex: tabby,bicolor)", openapi.ArrayOf(coatPattern)),
+		openapi.QueryParam("tags", "Etiquetas separadas por vírgula, sem diferenciar maiúsculas (ex: shy,indoor-only)", openapi.ArrayOf(openapi.String())),
+		openapi.QueryParam("tag_match", "any (padrão): qualquer uma das etiquetas; all: todas", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.TagMatchAny, domain.TagMatchAll}}),
+		openapi.QueryParam("attr", "Atributo personalizado, repetível: chave (o gato tem a chave) ou chave:valor (valor igual, comparado como texto)", openapi.String()),
+	}
+
+	// sparse fieldsets: com fields= qualquer campo pode faltar na resposta
+	catFields := doc.Partial(cat, "CatFields")
+	projection := []openapi.Parameter{
+		openapi.QueryParam("fields", "Campos da resposta separados por vírgula: "+strings.Join(domain.CatFields, ", "), openapi.String()),
+		openapi.QueryParam("include", "Relações embutidas na mesma consulta, separadas por vírgula: "+domain.CatIncludeThumbnails+", "+domain.CatIncludeTags,
+			openapi.ArrayOf(&openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.CatIncludeThumbnails, domain.CatIncludeTags}})),
+	}
+
+	catList := openapi.Object(map[string]*openapi.Schema{
+		"items":       openapi.ArrayOf(catFields),
+		"next_cursor": openapi.DateTime().Describe("Cursor da próxima página (ausente na última)"),
+	}, "items")
+	catListResp := negotiated("Página de gatos", catList, true)
+	catListResp.Headers = map[string]openapi.Header{
+		"ETag":          etag["ETag"],
+		"X-Next-Cursor": {Description: "Cursor da próxima página (útil em CSV, que não tem next_cursor)", Schema: openapi.DateTime()},
+	}
+	doc.Add("GET", "/cats", openapi.Operation{
+		OperationID: "listCats",
+		Summary:     "Lista gatos com paginação por cursor",
+		Tags:        []string{"cats"},
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("limit", "Itens por página (padrão 20)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "created_at do último item da página anterior (RFC 3339)", openapi.DateTime()),
+			ifNoneMatch,
+		}, append(catFilters, projection...)...),
+		Responses: map[string]*openapi.Response{
+			"200": catListResp,
+			"304": openapi.EmptyResponse("Página não mudou"),
+			"400": errResp,
+			"406": errResp,
+			"500": errResp,
+		},
+	})
+
+	created := negotiated("Gato criado", cat, false)
+	created.Headers = etag
+	createBody := &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{}}
+	for _, mt := range handlers.RequestTypes() {
+		createBody.Content[mt] = openapi.MediaType{Schema: doc.SchemaOf(domain.CatCreate{})}
+	}
+	doc.Add("POST", "/cats", openapi.Operation{
+		OperationID: "createCat",
+		Summary:     "Cria um gato",
+		Description: "O corpo pode ser JSON, MessagePack ou XML (<cat><name>...</name></cat>), conforme o Content-Type; a resposta segue o Accept. " +
+			"birth_date (com birth_date_precision, padrão exact) tem prioridade; sem ela, a data é estimada a partir de age_years. " +
+			"age_years e age_months da resposta são calculados na leitura; nascimento no futuro ou idade acima de 40 anos responde 422. " +
+			"breed é trocado pelo nome canônico do catálogo (GET /breeds); raças fora do catálogo são aceitas com breed_unrecognized=true " +
+			"ou, com BREED_POLICY=reject, respondem 422. " +
+			"coat_colors (até 3) e coat_pattern precisam combinar (solid: 1 cor; bicolor: 2; tortoiseshell: 2 ou mais; calico: 3, uma delas white), senão 422. " +
+			"microchip segue a ISO 11784/11785 (15 dígitos; espaços, hífens e pontos são ignorados); inválido responde 422 " +
+			"e já registrado em outro gato responde 409 com conflicting_cat_id. " +
+			"sire_id e dam_id apontam o pai e a mãe; com litter_id, os pais não informados vêm da ninhada. " +
+			"Pai, mãe ou ninhada inexistentes, ou pais diferentes dos da ninhada, respondem 422. " +
+			"attributes só aceita as chaves definidas para o tenant (GET /attributes), com valores do tipo definido; fora disso, 422.",
+		Tags:        []string{"cats"},
+		Parameters:  []openapi.Parameter{tenant},
+		RequestBody: createBody,
+		Responses: map[string]*openapi.Response{
+			"201": created,
+			"400": errResp,
+			"406": errResp,
+			"409": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	found := negotiated("Gato encontrado", catFields, false)
+	found.Headers = etag
+	doc.Add("GET", "/cats/{id}", openapi.Operation{
+		OperationID: "getCat",
+		Summary:     "Busca um gato pelo ID",
+		Description: "Com fields=, include=thumbnails ou include=tags o ETag é fraco (varia com a projeção) e não pode ser usado em If-Match. " +
+			"as_of devolve o gato como estava naquele instante (404 se ainda não existia ou já tinha sido removido); não aceita include.",
+		Tags: []string{"cats"},
+		Parameters: append([]openapi.Parameter{catID, ifNoneMatch,
+			openapi.QueryParam("as_of", "Instante a consultar no histórico de versões (RFC 3339)", openapi.DateTime()),
+		}, projection...),
+		Responses: map[string]*openapi.Response{
+			"200": found,
+			"304": openapi.EmptyResponse("Gato não mudou"),
+			"400": errResp,
+			"404": errResp,
+			"406": errResp,
+			"500": errResp,
+		},
+	})
+
+	updated := negotiated("Gato atualizado", cat, false)
+	updated.Headers = etag
+	doc.Add("PUT", "/cats/{id}", openapi.Operation{
+		OperationID: "updateCat",
+		Summary:     "Atualiza parcialmente um gato",
+		Description: "Campos ausentes mantêm o valor atual. Exige If-Match com o ETag do GET. breed, pelagem, microchip e genealogia seguem as regras da criação. " +
+			"breed, coat_color ou microchip vazios (\"\") removem o valor; sire_id, dam_id ou litter_id iguais a 0 removem a referência; " +
+			"um pai/mãe que seja descendente do gato (ciclo) responde 422. null equivale a ausente (mantém o valor). " +
+			"attributes é mesclado aos atuais; uma chave com null é removida.",
+		Tags:        []string{"cats"},
+		Parameters:  []openapi.Parameter{catID, ifMatch, tenant},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.CatUpdate{})),
+		Responses: map[string]*openapi.Response{
+			"200": updated,
+			"400": errResp,
+			"404": errResp,
+			"406": errResp,
+			"409": errResp,
+			"412": errResp,
+			"422": errResp,
+			"428": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/cats/{id}", openapi.Operation{
+		OperationID: "deleteCat",
+		Summary:     "Remove um gato",
+		Tags:        []string{"cats"},
+		Parameters:  []openapi.Parameter{catID, ifMatch},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Gato removido"),
+			"400": errResp,
+			"404": errResp,
+			"412": errResp,
+			"428": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/versions", openapi.Operation{
+		OperationID: "listCatVersions",
+		Summary:     "Versões guardadas de um gato",
+		Description: "Cada versão (o número do ETag) com o gato inteiro e o período em que foi a atual (valid_to ausente = atual). " +
+			"Da mais recente à mais antiga; continua disponível depois que o gato é removido.",
+		Tags: []string{"cats"},
+		Parameters: []openapi.Parameter{catID,
+			openapi.QueryParam("limit", "Itens por página (padrão 20, até 100)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "Versão da última linha da página anterior (next_cursor)", openapi.Integer().Between(1, 9223372036854775807)),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de versões", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(doc.SchemaOf(domain.CatVersion{})),
+				"next_cursor": openapi.Integer().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	reverted := negotiated("Gato revertido (nova versão)", cat, false)
+	reverted.Headers = etag
+	doc.Add("POST", "/cats/{id}/revert", openapi.Operation{
+		OperationID: "revertCat",
+		Summary:     "Reverte um gato para uma versão anterior",
+		Description: "Grava uma nova versão com o cadastro da versão informada; o histórico não é reescrito. O status de adoção não é revertido. " +
+			"Exige If-Match com o ETag atual. Um conteúdo que não vale mais (microchip agora de outro gato, pai removido) responde 409/422.",
+		Tags: []string{"cats"},
+		Parameters: []openapi.Parameter{catID, ifMatch,
+			{Name: "version", In: "query", Required: true, Description: "Versão a restaurar", Schema: openapi.Integer().Between(1, 9223372036854775807)},
+		},
+		Responses: map[string]*openapi.Response{
+			"200": reverted,
+			"400": errResp,
+			"404": errResp,
+			"406": errResp,
+			"409": errResp,
+			"412": errResp,
+			"422": errResp,
+			"428": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/cats:batch", openapi.Operation{
+		OperationID: "batchCats",
+		Summary:     "Cria, atualiza e remove gatos em lote",
+		Description: "mode=atomic (padrão) executa tudo em uma transação; mode=partial executa cada operação de forma independente e reporta o resultado de cada uma.",
+		Tags:        []string{"cats"},
+		Parameters:  []openapi.Parameter{tenant},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.CatBatchRequest{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Resultado de cada operação", doc.SchemaOf(handlers.CatBatchResponse{})),
+			"400": errResp,
+			"404": errResp,
+			"412": errResp,
+			"413": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	// tutores e tutela
+	owner := doc.SchemaOf(domain.Owner{})
+	ownerID := openapi.PathParam("id", "ID do tutor", openapi.Integer().Between(1, 9223372036854775807))
+	historyParam := openapi.QueryParam("history", "Inclui vínculos encerrados", openapi.Boolean())
+	items := func(s *openapi.Schema) *openapi.Schema {
+		return openapi.Object(map[string]*openapi.Schema{"items": openapi.ArrayOf(s)}, "items")
+	}
+
+	doc.Add("GET", "/owners", openapi.Operation{
+		OperationID: "listOwners",
+		Summary:     "Lista tutores com paginação por cursor",
+		Tags:        []string{"owners"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("limit", "Itens por página (padrão 20)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "created_at do último item da página anterior (RFC 3339)", openapi.DateTime()),
+			openapi.QueryParam("name", "Busca parcial no nome", openapi.String()),
+			openapi.QueryParam("email", "E-mail exato", openapi.String()),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de tutores", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(owner),
+				"next_cursor": openapi.DateTime().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/owners", openapi.Operation{
+		OperationID: "createOwner",
+		Summary:     "Cadastra um tutor",
+		Tags:        []string{"owners"},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.OwnerCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Tutor criado", owner),
+			"400": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/owners/{id}", openapi.Operation{
+		OperationID: "getOwner",
+		Summary:     "Busca um tutor pelo ID",
+		Tags:        []string{"owners"},
+		Parameters:  []openapi.Parameter{ownerID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Tutor", owner),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/owners/{id}", openapi.Operation{
+		OperationID: "updateOwner",
+		Summary:     "Atualiza parcialmente um tutor",
+		Tags:        []string{"owners"},
+		Parameters:  []openapi.Parameter{ownerID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.OwnerUpdate{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Tutor atualizado", owner),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/owners/{id}", openapi.Operation{
+		OperationID: "deleteOwner",
+		Summary:     "Remove um tutor",
+		Description: "Tutores com histórico de tutela não podem ser removidos (409).",
+		Tags:        []string{"owners"},
+		Parameters:  []openapi.Parameter{ownerID},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Tutor removido"),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/owners/{id}/cats", openapi.Operation{
+		OperationID: "listOwnerCats",
+		Summary:     "Gatos do tutor, com o vínculo de cada um",
+		Tags:        []string{"owners"},
+		Parameters:  []openapi.Parameter{ownerID, historyParam},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Gatos do tutor", items(doc.SchemaOf(domain.OwnedCat{}))),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/owners", openapi.Operation{
+		OperationID: "listCatOwners",
+		Summary:     "Tutores do gato, com o vínculo de cada um",
+		Description: "Por padrão só os vínculos ativos; history=true inclui os encerrados.",
+		Tags:        []string{"cats", "owners"},
+		Parameters:  []openapi.Parameter{catID, historyParam},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Tutores do gato", items(doc.SchemaOf(domain.CatOwner{}))),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	ownership := doc.SchemaOf(domain.Ownership{})
+	doc.Add("POST", "/cats/{id}/owners", openapi.Operation{
+		OperationID: "addCatOwner",
+		Summary:     "Vincula um tutor ao gato",
+		Description: "role: primary (no máximo um ativo por gato), co-owner ou foster. start_date ausente = hoje.",
+		Tags:        []string{"cats", "owners"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.OwnershipCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Vínculo criado", ownership),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/cats/{id}/owners/{ownerId}", openapi.Operation{
+		OperationID: "endCatOwner",
+		Summary:     "Encerra o vínculo ativo entre o gato e o tutor",
+		Description: "O vínculo continua no histórico com end_date preenchido.",
+		Tags:        []string{"cats", "owners"},
+		Parameters: []openapi.Parameter{
+			catID,
+			openapi.PathParam("ownerId", "ID do tutor", openapi.Integer().Between(1, 9223372036854775807)),
+			openapi.QueryParam("end_date", "Data de fim (padrão: hoje)", &openapi.Schema{Type: openapi.SchemaType{"string"}, Format: "date"}),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Vínculo encerrado", ownership),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	transfer := doc.SchemaOf(domain.Transfer{})
+	doc.Add("POST", "/cats/{id}/transfer", openapi.Operation{
+		OperationID: "transferCat",
+		Summary:     "Transfere a tutela principal do gato",
+		Description: "Encerra o vínculo principal atual, torna to_owner_id o novo principal e registra a transferência no histórico.",
+		Tags:        []string{"cats", "owners"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.TransferRequest{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Transferência registrada", transfer),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/transfers", openapi.Operation{
+		OperationID: "listCatTransfers",
+		Summary:     "Histórico de transferências de tutela do gato",
+		Tags:        []string{"cats", "owners"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Transferências, da mais antiga para a mais recente", items(transfer)),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	// adoção: status do gato e candidaturas
+	application := doc.SchemaOf(domain.AdoptionApplication{})
+	applicationID := openapi.PathParam("id", "ID da candidatura", openapi.Integer().Between(1, 9223372036854775807))
+	applicationStatus := openapi.QueryParam("status", "Status da candidatura",
+		&openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.ApplicationPending, domain.ApplicationApproved, domain.ApplicationRejected}})
+	page := []openapi.Parameter{
+		openapi.QueryParam("limit", "Itens por página (padrão 20)", openapi.Integer().Between(1, 100)),
+		openapi.QueryParam("cursor", "created_at do último item da página anterior (RFC 3339)", openapi.DateTime()),
+	}
+	applicationList := openapi.JSONResponse("Página de candidaturas", openapi.Object(map[string]*openapi.Schema{
+		"items":       openapi.ArrayOf(application),
+		"next_cursor": openapi.DateTime().Describe("Cursor da próxima página (ausente na última)"),
+	}, "items"))
+	decision := &openapi.RequestBody{Content: map[string]openapi.MediaType{"application/json": {Schema: doc.SchemaOf(domain.ApplicationDecision{})}}}
+
+	statusChanged := openapi.JSONResponse("Gato com o novo status", cat)
+	statusChanged.Headers = etag
+	doc.Add("POST", "/cats/{id}/status", openapi.Operation{
+		OperationID: "changeCatStatus",
+		Summary:     "Muda o status de adoção do gato",
+		Description: "Transições: " + statusTransitionsDoc() + ". Transição inválida responde 409 com allowed_statuses.",
+		Tags:        []string{"cats", "adoption"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.StatusChange{})),
+		Responses: map[string]*openapi.Response{
+			"200": statusChanged,
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/status/history", openapi.Operation{
+		OperationID: "catStatusHistory",
+		Summary:     "Histórico de status de adoção do gato",
+		Tags:        []string{"cats", "adoption"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Transições, da mais antiga para a mais recente", items(doc.SchemaOf(domain.StatusTransition{}))),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/cats/{id}/applications", openapi.Operation{
+		OperationID: "applyForCat",
+		Summary:     "Registra a candidatura de um tutor para adotar o gato",
+		Description: "O gato (available ou reserved) passa para adoption_pending. Gatos adotados, devolvidos ou não adotáveis respondem 409.",
+		Tags:        []string{"cats", "adoption"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.ApplicationCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Candidatura registrada", application),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/applications", openapi.Operation{
+		OperationID: "listCatApplications",
+		Summary:     "Candidaturas de adoção do gato",
+		Tags:        []string{"cats", "adoption"},
+		Parameters:  append([]openapi.Parameter{catID, applicationStatus}, page...),
+		Responses: map[string]*openapi.Response{
+			"200": applicationList,
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/applications", openapi.Operation{
+		OperationID: "listApplications",
+		Summary:     "Lista candidaturas de adoção",
+		Tags:        []string{"adoption"},
+		Parameters: append([]openapi.Parameter{
+			applicationStatus,
+			openapi.QueryParam("owner_id", "Candidaturas de um tutor", openapi.Integer().Between(1, 9223372036854775807)),
+		}, page...),
+		Responses: map[string]*openapi.Response{
+			"200": applicationList,
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/applications/{id}", openapi.Operation{
+		OperationID: "getApplication",
+		Summary:     "Busca uma candidatura",
+		Tags:        []string{"adoption"},
+		Parameters:  []openapi.Parameter{applicationID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Candidatura", application),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/applications/{id}/approve", openapi.Operation{
+		OperationID: "approveApplication",
+		Summary:     "Aprova a candidatura",
+		Description: "O gato vira adopted, o candidato passa a ser o tutor principal e as demais candidaturas pendentes são rejeitadas.",
+		Tags:        []string{"adoption"},
+		Parameters:  []openapi.Parameter{applicationID},
+		RequestBody: decision,
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Candidatura aprovada", application),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/applications/{id}/reject", openapi.Operation{
+		OperationID: "rejectApplication",
+		Summary:     "Rejeita a candidatura",
+		Description: "Sem outras candidaturas pendentes, o gato volta para available.",
+		Tags:        []string{"adoption"},
+		Parameters:  []openapi.Parameter{applicationID},
+		RequestBody: decision,
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Candidatura rejeitada", application),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	job := doc.SchemaOf(domain.Job{})
+	jobID := openapi.PathParam("id", "ID do job", openapi.Integer().Between(1, 9223372036854775807))
+	accepted := openapi.JSONResponse("Job agendado (acompanhe em Location)", job)
+	accepted.Headers = map[string]openapi.Header{"Location": {Description: "URL do job", Schema: openapi.String()}}
+	doc.Add("POST", "/cats/import", openapi.Operation{
+		OperationID: "importCats",
+		Summary:     "Importa gatos de um arquivo CSV ou NDJSON",
+		Description: "Cada linha é validada com as mesmas regras de POST /cats. A importação roda em background; o relatório (linhas aceitas e rejeitadas, com número da linha e motivo) fica em GET /jobs/{id}/result. Cabeçalhos em português (nome, idade, raca, cor, peso) são reconhecidos. Colunas e chaves desconhecidas são ignoradas e listadas como avisos no relatório (coluna warnings); só um mapeamento para campo inexistente faz o job falhar.",
+		Tags:        []string{"cats", "jobs"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("format", "Formato do arquivo (padrão: deduzido do Content-Type)", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{"csv", "ndjson"}}),
+			openapi.QueryParam("dry_run", "Apenas valida, sem gravar", openapi.Boolean()),
+			openapi.QueryParam("map", "Mapeamento coluna=campo (repetível); campo \"-\" ignora a coluna", openapi.String()),
+		},
+		RequestBody: &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{
+			"text/csv":             {Schema: openapi.String()},
+			"application/x-ndjson": {Schema: openapi.String()},
+		}},
+		Responses: map[string]*openapi.Response{
+			"202": accepted,
+			"400": errResp,
+			"413": errResp,
+			"415": errResp,
+			"500": errResp,
+		},
+	})
+
+	formats := make([]any, len(exporter.Formats))
+	for i, f := range exporter.Formats {
+		formats[i] = f
+	}
+	binary := &openapi.Schema{Type: openapi.SchemaType{"string"}, Format: "binary"}
+	doc.Add("GET", "/cats/export", openapi.Operation{
+		OperationID: "exportCats",
+		Summary:     "Exporta gatos em CSV, NDJSON ou XLSX",
+		Description: "Aceita os mesmos filtros da listagem. A resposta é transmitida enquanto os dados são lidos do banco; com async=true a exportação roda como job e o arquivo fica disponível em GET /jobs/{id}/result.",
+		Tags:        []string{"cats", "jobs"},
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("format", "Formato (padrão csv)", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: formats}),
+			openapi.QueryParam("columns", "Colunas separadas por vírgula: "+strings.Join(exporter.Columns(), ", "), openapi.String()),
+			openapi.QueryParam("async", "Roda em background e devolve um job", openapi.Boolean()),
+		}, catFilters...),
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Arquivo exportado", Content: map[string]openapi.MediaType{
+				exporter.ContentType(exporter.FormatCSV):    {Schema: openapi.String()},
+				exporter.ContentType(exporter.FormatNDJSON): {Schema: openapi.String()},
+				exporter.ContentType(exporter.FormatXLSX):   {Schema: binary},
+			}},
+			"202": accepted,
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/stream", openapi.Operation{
+		OperationID: "streamCatEvents",
+		Summary:     "Eventos dos gatos em tempo real (Server-Sent Events)",
+		Description: "Um evento SSE por evento da outbox, de qualquer réplica: " + strings.Join(domain.EventTypes, ", ") +
+			". O id de cada evento SSE permite retomar com Last-Event-ID (ou last_event_id); se esse evento já saiu da outbox (OUTBOX_RETENTION), " +
+			"vem um evento reset e os gatos devem ser recarregados. Comentários de heartbeat mantêm a conexão aberta. " +
+			"Um evento pode chegar repetido (a entrega da outbox é pelo menos uma vez): descarte ids já vistos. 503 se OUTBOX_CHANNEL estiver vazio.",
+		Tags: []string{"cats", "events"},
+		Parameters: []openapi.Parameter{
+			openapi.HeaderParam("Last-Event-ID", "id do último evento recebido (enviado pelo EventSource ao reconectar)", false),
+			openapi.QueryParam("last_event_id", "Mesmo que Last-Event-ID, para clientes que não enviam headers", openapi.Integer().Between(0, 9223372036854775807)),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Stream de eventos; data é o evento em JSON", Content: map[string]openapi.MediaType{
+				"text/event-stream": {Schema: openapi.String()},
+			}},
+			"400": errResp,
+			"503": errResp,
+		},
+	})
+
+	doc.Add("GET", "/jobs/{id}", openapi.Operation{
+		OperationID: "getJob",
+		Summary:     "Estado e progresso de um job em background",
+		Tags:        []string{"jobs"},
+		Parameters:  []openapi.Parameter{jobID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Job", job),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/jobs/{id}/result", openapi.Operation{
+		OperationID: "getJobResult",
+		Summary:     "Download do arquivo gerado pelo job",
+		Tags:        []string{"jobs"},
+		Parameters:  []openapi.Parameter{jobID},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Arquivo (o Content-Type depende do job)", Content: map[string]openapi.MediaType{
+				"application/octet-stream": {Schema: &openapi.Schema{Type: openapi.SchemaType{"string"}, Format: "binary"}},
+			}},
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	// prontuário: os quatro tipos de registro têm as mesmas rotas (listar, criar, remover)
+	recordID := openapi.PathParam("recordId", "ID do registro", openapi.Integer().Between(1, 9223372036854775807))
+	records := []struct {
+		path, op, label string
+		record, create  any
+		params          []openapi.Parameter
+		description     string
+	}{
+		{"vaccinations", "Vaccination", "vacina", domain.Vaccination{}, domain.VaccinationCreate{}, nil,
+			"next_due_on é a data do reforço e alimenta o relatório /reports/vaccinations-due; não pode ser anterior a administered_on (422)."},
+		{"vet-visits", "VetVisit", "consulta", domain.VetVisit{}, domain.VetVisitCreate{}, nil, ""},
+		{"medications", "Medication", "medicamento", domain.Medication{}, domain.MedicationCreate{},
+			[]openapi.Parameter{openapi.QueryParam("active", "Só os tratamentos em curso hoje", openapi.Boolean())},
+			"end_date ausente = tratamento contínuo; end_date antes de start_date responde 422."},
+		{"allergies", "Allergy", "alergia", domain.Allergy{}, domain.AllergyCreate{}, nil,
+			"Um registro por alérgeno (sem diferenciar maiúsculas); repetir o alérgeno responde 409."},
+	}
+	for _, rec := range records {
+		schema := doc.SchemaOf(rec.record)
+		doc.Add("GET", "/cats/{id}/"+rec.path, openapi.Operation{
+			OperationID: "list" + rec.op + "s",
+			Summary:     "Registros de " + rec.label + " do gato",
+			Tags:        []string{"cats", "health"},
+			Parameters:  append([]openapi.Parameter{catID}, rec.params...),
+			Responses: map[string]*openapi.Response{
+				"200": openapi.JSONResponse("Registros, do mais recente para o mais antigo", items(schema)),
+				"400": errResp,
+				"404": errResp,
+				"500": errResp,
+			},
+		})
+		doc.Add("POST", "/cats/{id}/"+rec.path, openapi.Operation{
+			OperationID: "add" + rec.op,
+			Summary:     "Registra " + rec.label + " no prontuário do gato",
+			Description: rec.description,
+			Tags:        []string{"cats", "health"},
+			Parameters:  []openapi.Parameter{catID},
+			RequestBody: openapi.JSONBody(doc.SchemaOf(rec.create)),
+			Responses: map[string]*openapi.Response{
+				"201": openapi.JSONResponse("Registro criado", schema),
+				"400": errResp,
+				"404": errResp,
+				"409": errResp,
+				"422": errResp,
+				"500": errResp,
+			},
+		})
+		doc.Add("DELETE", "/cats/{id}/"+rec.path+"/{recordId}", openapi.Operation{
+			OperationID: "delete" + rec.op,
+			Summary:     "Remove um registro de " + rec.label + " do gato",
+			Tags:        []string{"cats", "health"},
+			Parameters:  []openapi.Parameter{catID, recordID},
+			Responses: map[string]*openapi.Response{
+				"204": openapi.EmptyResponse("Registro removido"),
+				"400": errResp,
+				"404": errResp,
+				"500": errResp,
+			},
+		})
+	}
+
+	neutering := doc.SchemaOf(domain.Neutering{})
+	doc.Add("GET", "/cats/{id}/neutering", openapi.Operation{
+		OperationID: "getNeutering",
+		Summary:     "Situação de castração do gato",
+		Description: "Campos ausentes = ainda não informado.",
+		Tags:        []string{"cats", "health"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Castração", neutering),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/cats/{id}/neutering", openapi.Operation{
+		OperationID: "setNeutering",
+		Summary:     "Informa a castração do gato",
+		Description: "neutered_on só é aceito com neutered=true e não pode estar no futuro (422).",
+		Tags:        []string{"cats", "health"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.NeuteringUpdate{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Castração atualizada", neutering),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/health", openapi.Operation{
+		OperationID: "getHealthSummary",
+		Summary:     "Resumo do prontuário do gato",
+		Description: "Castração, alergias, tratamentos em curso, dose mais recente de cada vacina e última consulta.",
+		Tags:        []string{"cats", "health"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Resumo", doc.SchemaOf(domain.HealthSummary{})),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/reports/vaccinations-due", openapi.Operation{
+		OperationID: "vaccinationsDue",
+		Summary:     "Vacinas vencidas ou a vencer",
+		Description: "Lê o último relatório calculado pelo job reports.vaccinations_due (agendado periodicamente). generated_at ausente = ainda não calculado.",
+		Tags:        []string{"health", "reports"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("status", "Só as vencidas ou só as a vencer", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.VaccinationOverdue, domain.VaccinationUpcoming}}),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Relatório", doc.SchemaOf(domain.VaccinationsDueReport{})),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/reports/vaccinations-due/refresh", openapi.Operation{
+		OperationID: "refreshVaccinationsDue",
+		Summary:     "Recalcula o relatório de vacinas agora",
+		Tags:        []string{"health", "reports", "jobs"},
+		Responses: map[string]*openapi.Response{
+			"202": accepted,
+			"500": errResp,
+		},
+	})
+
+	// histórico de peso e alertas
+	date := &openapi.Schema{Type: openapi.SchemaType{"string"}, Format: "date"}
+	doc.Add("GET", "/cats/{id}/weights", openapi.Operation{
+		OperationID: "catWeights",
+		Summary:     "Série de peso do gato",
+		Description: "Medições em ordem cronológica no período [from, to]. Com bucket, um ponto por dia, semana ou mês: measured_at é o início do período, weight_kg a média e min_kg/max_kg/samples o resumo. from depois de to responde 422.",
+		Tags:        []string{"cats", "weights"},
+		Parameters: []openapi.Parameter{
+			catID,
+			openapi.QueryParam("from", "Data inicial (inclusive)", date),
+			openapi.QueryParam("to", "Data final (inclusive)", date),
+			openapi.QueryParam("bucket", "Agrupa as medições (downsampling)", &openapi.Schema{Type: openapi.SchemaType{"string"},
+				Enum: []any{domain.WeightBucketDay, domain.WeightBucketWeek, domain.WeightBucketMonth}}),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Série", doc.SchemaOf(domain.WeightSeries{})),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/cats/{id}/weights", openapi.Operation{
+		OperationID: "addCatWeight",
+		Summary:     "Registra uma medição de peso",
+		Description: "measured_at ausente = agora; medições retroativas são aceitas, no futuro respondem 422. A medição mais recente vira o weight_kg do gato. Variações acima das regras de alerta geram um alerta em /weight-alerts.",
+		Tags:        []string{"cats", "weights"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.WeightCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Medição registrada", doc.SchemaOf(domain.Weight{})),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/cats/{id}/weights/{weightId}", openapi.Operation{
+		OperationID: "deleteCatWeight",
+		Summary:     "Apaga uma medição de peso",
+		Description: "Os alertas disparados pela medição também são apagados; weight_kg volta a ser a medição mais recente restante.",
+		Tags:        []string{"cats", "weights"},
+		Parameters: []openapi.Parameter{
+			catID,
+			openapi.PathParam("weightId", "ID da medição", openapi.Integer().Between(1, 9223372036854775807)),
+		},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Medição apagada"),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	weightAlert := doc.SchemaOf(domain.WeightAlert{})
+	doc.Add("GET", "/weight-alerts", openapi.Operation{
+		OperationID: "listWeightAlerts",
+		Summary:     "Alertas de variação de peso",
+		Description: "Gerados quando uma medição varia mais que o limite da regra em relação às medições da janela (padrão: perda de 10% ou ganho de 20% em 30 dias).",
+		Tags:        []string{"weights"},
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("cat_id", "Só os alertas do gato", openapi.Integer().Between(1, 9223372036854775807)),
+			openapi.QueryParam("kind", "Tipo do alerta", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.WeightAlertLoss, domain.WeightAlertGain}}),
+			openapi.QueryParam("open", "Só os não reconhecidos", openapi.Boolean()),
+		}, page...),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de alertas", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(weightAlert),
+				"next_cursor": openapi.DateTime().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/weight-alerts/{id}/acknowledge", openapi.Operation{
+		OperationID: "acknowledgeWeightAlert",
+		Summary:     "Reconhece um alerta de peso",
+		Tags:        []string{"weights"},
+		Parameters:  []openapi.Parameter{openapi.PathParam("id", "ID do alerta", openapi.Integer().Between(1, 9223372036854775807))},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Alerta reconhecido", weightAlert),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	breed := doc.SchemaOf(domain.Breed{})
+	breedID := openapi.PathParam("id", "ID da raça", openapi.Integer().Between(1, 9223372036854775807))
+	doc.Add("GET", "/breeds", openapi.Operation{
+		OperationID: "searchBreeds",
+		Summary:     "Autocomplete de raças",
+		Description: "Raças cujo nome canônico, tradução ou apelido começa com prefix, sem diferenciar maiúsculas, acentos e pontuação. " +
+			"match traz o nome que casou; display_name segue o Accept-Language.",
+		Tags: []string{"breeds"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("prefix", "Início do nome (vazio lista o catálogo)", openapi.String()),
+			openapi.QueryParam("limit", "Máximo de itens (padrão 20)", openapi.Integer().Between(1, 100)),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Raças encontradas", openapi.Object(map[string]*openapi.Schema{
+				"items": openapi.ArrayOf(breed),
+			}, "items")),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/breeds/stats", openapi.Operation{
+		OperationID: "breedStats",
+		Summary:     "Estatísticas por raça",
+		Description: "Gatos por raça do catálogo (total, disponíveis e peso médio), os textos de raça não reconhecidos mais frequentes e os gatos sem raça.",
+		Tags:        []string{"breeds"},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Estatísticas", doc.SchemaOf(domain.BreedStats{})),
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/breeds/{id}", openapi.Operation{
+		OperationID: "getBreed",
+		Summary:     "Busca uma raça",
+		Tags:        []string{"breeds"},
+		Parameters:  []openapi.Parameter{breedID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Raça com traduções e apelidos", breed),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/lookup", openapi.Operation{
+		OperationID: "lookupCatByMicrochip",
+		Summary:     "Busca um gato pelo microchip",
+		Description: "Para achados e perdidos. Espaços, hífens e pontos são ignorados; número fora da ISO 11784/11785 responde 422. " +
+			"Toda busca (encontrada ou não, e as inválidas) é registrada com IP, User-Agent e ID da requisição (GET /microchip-lookups).",
+		Tags: []string{"microchips"},
+		Parameters: []openapi.Parameter{{
+			Name: "microchip", In: "query", Required: true,
+			Description: "Número do microchip (15 dígitos)",
+			Schema:      openapi.String(),
+		}},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Gato com o microchip", cat),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/microchip-lookups", openapi.Operation{
+		OperationID: "listMicrochipLookups",
+		Summary:     "Registro das buscas por microchip",
+		Description: "Para revisão de privacidade: quem buscou qual microchip, quando e com qual resultado.",
+		Tags:        []string{"microchips"},
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("microchip", "Só as buscas por este microchip", openapi.String()),
+			openapi.QueryParam("cat_id", "Só as buscas que encontraram este gato", openapi.Integer().Between(1, 9223372036854775807)),
+		}, page...),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de buscas", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(doc.SchemaOf(domain.MicrochipLookup{})),
+				"next_cursor": openapi.DateTime().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	generations := openapi.QueryParam("generations", "Gerações consultadas (padrão 4, até 10)", openapi.Integer().Between(1, 10))
+	doc.Add("GET", "/cats/{id}/pedigree", openapi.Operation{
+		OperationID: "catPedigree",
+		Summary:     "Árvore de ancestrais do gato",
+		Description: "Pai (sire) e mãe (dam) de cada gato até o número de gerações pedido. " +
+			"coi é o coeficiente de consanguinidade de Wright, calculado sobre os ancestrais da árvore (desconhecidos contam como não aparentados).",
+		Tags:       []string{"pedigree"},
+		Parameters: []openapi.Parameter{catID, generations},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Árvore de ancestrais", doc.SchemaOf(domain.Pedigree{})),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/descendants", openapi.Operation{
+		OperationID: "catDescendants",
+		Summary:     "Descendentes do gato",
+		Description: "Filhos (generation 1), netos (2)... até o número de gerações pedido; cada gato aparece uma vez, na geração mais próxima.",
+		Tags:        []string{"pedigree"},
+		Parameters:  []openapi.Parameter{catID, generations},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Descendentes", openapi.Object(map[string]*openapi.Schema{
+				"items": openapi.ArrayOf(doc.SchemaOf(domain.Descendant{})),
+			}, "items")),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	litter := doc.SchemaOf(domain.Litter{})
+	doc.Add("POST", "/litters", openapi.Operation{
+		OperationID: "createLitter",
+		Summary:     "Registra uma ninhada",
+		Description: "Os filhotes entram na ninhada pelo litter_id do gato (POST/PUT /cats) e herdam dela o pai e a mãe. " +
+			"Pai ou mãe inexistentes, ou o mesmo gato como pai e mãe, respondem 422.",
+		Tags:        []string{"pedigree"},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.LitterCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Ninhada registrada", litter),
+			"400": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/litters/coi", openapi.Operation{
+		OperationID: "matingCOI",
+		Summary:     "COI previsto de um cruzamento",
+		Description: "Coeficiente de consanguinidade dos filhotes de sire_id e dam_id (o parentesco entre os dois), " +
+			"considerando o número de gerações dos filhotes.",
+		Tags: []string{"pedigree"},
+		Parameters: []openapi.Parameter{
+			{Name: "sire_id", In: "query", Required: true, Description: "ID do pai", Schema: openapi.Integer().Between(1, 9223372036854775807)},
+			{Name: "dam_id", In: "query", Required: true, Description: "ID da mãe", Schema: openapi.Integer().Between(1, 9223372036854775807)},
+			generations,
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("COI previsto", doc.SchemaOf(domain.MatingCOI{})),
+			"400": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/litters/{id}", openapi.Operation{
+		OperationID: "getLitter",
+		Summary:     "Busca uma ninhada",
+		Description: "Ninhada com os filhotes e o COI deles (ausente se o pai ou a mãe forem desconhecidos).",
+		Tags:        []string{"pedigree"},
+		Parameters: []openapi.Parameter{
+			openapi.PathParam("id", "ID da ninhada", openapi.Integer().Between(1, 9223372036854775807)),
+			generations,
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Ninhada", litter),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	catTags := openapi.Object(map[string]*openapi.Schema{"tags": openapi.ArrayOf(openapi.String())}, "tags")
+	doc.Add("GET", "/cats/{id}/tags", openapi.Operation{
+		OperationID: "catTags",
+		Summary:     "Etiquetas do gato",
+		Tags:        []string{"cats", "tags"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Etiquetas em ordem alfabética", catTags),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/cats/{id}/tags", openapi.Operation{
+		OperationID: "setCatTags",
+		Summary:     "Substitui as etiquetas do gato",
+		Description: "Etiquetas que ainda não existem são criadas. Maiúsculas não diferenciam etiquetas (\"Shy\" e \"shy\" são a mesma); " +
+			"repetidas são ignoradas e uma lista vazia remove todas.",
+		Tags:        []string{"cats", "tags"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.CatTags{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Etiquetas do gato", catTags),
+			"400": errResp,
+			"404": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	tag := doc.SchemaOf(domain.Tag{})
+	tagID := openapi.PathParam("id", "ID da etiqueta", openapi.Integer().Between(1, 9223372036854775807))
+	doc.Add("GET", "/tags", openapi.Operation{
+		OperationID: "listTags",
+		Summary:     "Autocomplete de etiquetas",
+		Description: "Etiquetas que começam com prefix (sem diferenciar maiúsculas), com a quantidade de gatos de cada uma.",
+		Tags:        []string{"tags"},
+		Parameters: []openapi.Parameter{
+			openapi.QueryParam("prefix", "Início do nome (vazio lista todas)", openapi.String()),
+			openapi.QueryParam("limit", "Máximo de itens (padrão 20)", openapi.Integer().Between(1, 100)),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Etiquetas encontradas", items(tag)),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/tags/{id}/rename", openapi.Operation{
+		OperationID: "renameTag",
+		Summary:     "Renomeia uma etiqueta",
+		Description: "Os gatos continuam com a etiqueta, agora com o novo nome. Um nome já usado por outra etiqueta responde 409 (para juntá-las, use merge).",
+		Tags:        []string{"tags"},
+		Parameters:  []openapi.Parameter{tagID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.TagRename{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Etiqueta renomeada", tag),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/tags/{id}/merge", openapi.Operation{
+		OperationID: "mergeTag",
+		Summary:     "Junta uma etiqueta em outra",
+		Description: "Os gatos da etiqueta passam a ter a etiqueta into e a etiqueta é apagada, em uma transação. Devolve a etiqueta into.",
+		Tags:        []string{"tags"},
+		Parameters:  []openapi.Parameter{tagID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.TagMerge{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Etiqueta resultante", tag),
+			"400": errResp,
+			"404": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/tags/{id}", openapi.Operation{
+		OperationID: "deleteTag",
+		Summary:     "Apaga uma etiqueta",
+		Description: "A etiqueta sai de todos os gatos.",
+		Tags:        []string{"tags"},
+		Parameters:  []openapi.Parameter{tagID},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Etiqueta apagada"),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	attribute := doc.SchemaOf(domain.AttributeDef{})
+	attributeKey := openapi.PathParam("key", "Chave do atributo (minúsculas, dígitos e _, começando por letra)", openapi.String())
+	doc.Add("GET", "/attributes", openapi.Operation{
+		OperationID: "listAttributes",
+		Summary:     "Esquema de atributos do tenant",
+		Description: "Chaves aceitas em attributes dos gatos do tenant (X-Tenant-ID) e o tipo de cada uma.",
+		Tags:        []string{"attributes"},
+		Parameters:  []openapi.Parameter{tenant},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Definições", items(attribute)),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/attributes/{key}", openapi.Operation{
+		OperationID: "putAttribute",
+		Summary:     "Cria ou altera uma chave de atributo",
+		Description: "type é string, number, integer, boolean ou date (2006-01-02). Valores já gravados nos gatos não são convertidos quando o tipo muda.",
+		Tags:        []string{"attributes"},
+		Parameters:  []openapi.Parameter{attributeKey, tenant},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.AttributeDefInput{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Definição gravada", attribute),
+			"400": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/attributes/{key}", openapi.Operation{
+		OperationID: "deleteAttribute",
+		Summary:     "Remove uma chave de atributo",
+		Description: "Novos valores da chave passam a ser recusados; os já gravados nos gatos ficam e podem ser removidos com null.",
+		Tags:        []string{"attributes"},
+		Parameters:  []openapi.Parameter{attributeKey, tenant},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Definição removida"),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	// notas e linha do tempo
+	note := doc.SchemaOf(domain.Note{})
+	noteID := openapi.PathParam("noteId", "ID da nota", openapi.Integer().Between(1, 9223372036854775807))
+	doc.Add("GET", "/cats/{id}/notes", openapi.Operation{
+		OperationID: "listCatNotes",
+		Summary:     "Notas do gato",
+		Description: "Fixadas (pinned) primeiro, depois as mais recentes.",
+		Tags:        []string{"cats", "notes"},
+		Parameters:  []openapi.Parameter{catID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Notas", items(note)),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/cats/{id}/notes", openapi.Operation{
+		OperationID: "createCatNote",
+		Summary:     "Cria uma nota",
+		Description: "O texto (markdown) é guardado como enviado e vira a versão 1 do histórico da nota.",
+		Tags:        []string{"cats", "notes"},
+		Parameters:  []openapi.Parameter{catID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.NoteCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Nota criada", note),
+			"400": errResp,
+			"404": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/cats/{id}/notes/{noteId}", openapi.Operation{
+		OperationID: "updateCatNote",
+		Summary:     "Edita ou fixa uma nota",
+		Description: "Campos ausentes ficam como estão. Um texto diferente do atual gera uma nova versão no histórico e exige editor; " +
+			"fixar ou desafixar não.",
+		Tags:        []string{"cats", "notes"},
+		Parameters:  []openapi.Parameter{catID, noteID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.NoteUpdate{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Nota alterada", note),
+			"400": errResp,
+			"404": errResp,
+			"415": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/cats/{id}/notes/{noteId}", openapi.Operation{
+		OperationID: "deleteCatNote",
+		Summary:     "Apaga uma nota",
+		Description: "O histórico de edição da nota também é apagado.",
+		Tags:        []string{"cats", "notes"},
+		Parameters:  []openapi.Parameter{catID, noteID},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Nota apagada"),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/cats/{id}/notes/{noteId}/history", openapi.Operation{
+		OperationID: "catNoteHistory",
+		Summary:     "Histórico de edição de uma nota",
+		Description: "Todas as versões do texto, da original (versão 1, do autor) à atual, com quem editou.",
+		Tags:        []string{"cats", "notes"},
+		Parameters:  []openapi.Parameter{catID, noteID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Versões da nota", items(doc.SchemaOf(domain.NoteRevision{}))),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	timelineKinds := make([]any, len(domain.TimelineKinds))
+	for i, k := range domain.TimelineKinds {
+		timelineKinds[i] = k
+	}
+	doc.Add("GET", "/cats/{id}/timeline", openapi.Operation{
+		OperationID: "catTimeline",
+		Summary:     "Linha do tempo do gato",
+		Description: "Cadastro, última alteração, notas e edições, fotos, pesagens e mudanças de status, do mais recente ao mais antigo. " +
+			"data traz os campos de cada tipo. Para a próxima página, repasse next_cursor em cursor.",
+		Tags: []string{"cats", "notes"},
+		Parameters: []openapi.Parameter{
+			catID,
+			openapi.QueryParam("kinds", "Tipos de evento separados por vírgula (padrão: todos)",
+				openapi.ArrayOf(&openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: timelineKinds})),
+			openapi.QueryParam("limit", "Itens por página (padrão 20, até 100)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "next_cursor da página anterior", openapi.String()),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página da linha do tempo", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(doc.SchemaOf(domain.TimelineEvent{})),
+				"next_cursor": openapi.String().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	// auditoria
+	auditEntity := &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{}}
+	for _, e := range domain.AuditEntities {
+		auditEntity.Enum = append(auditEntity.Enum, e)
+	}
+	auditFilters := []openapi.Parameter{
+		openapi.QueryParam("entity", "Entidade", auditEntity),
+		openapi.QueryParam("id", "ID da entidade", openapi.Integer().Between(1, 9223372036854775807)),
+		openapi.QueryParam("action", "Ação", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.AuditCreate, domain.AuditUpdate, domain.AuditDelete}}),
+		openapi.QueryParam("actor", "Quem fez a mudança (header "+handlers.ActorHeader+" da requisição)", openapi.String()),
+		openapi.QueryParam("request_id", "ID da requisição que fez a mudança", openapi.String()),
+		openapi.QueryParam("from", "Mudanças a partir deste instante (RFC 3339)", openapi.DateTime()),
+		openapi.QueryParam("to", "Mudanças antes deste instante (RFC 3339)", openapi.DateTime()),
+	}
+	doc.Add("GET", "/audit", openapi.Operation{
+		OperationID: "listAuditEvents",
+		Summary:     "Registro de auditoria",
+		Description: "Toda criação, alteração e remoção de gatos, gravada na mesma transação da mudança, com quem fez " +
+			"(header " + handlers.ActorHeader + "), ID da requisição, IP e User-Agent. before/after trazem a linha inteira na " +
+			"criação e na remoção, e só as colunas que mudaram na alteração. Mais recentes primeiro.",
+		Tags: []string{"audit"},
+		Parameters: append(auditFilters,
+			openapi.QueryParam("limit", "Itens por página (padrão 20, até 100)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "id do último evento da página anterior (next_cursor)", openapi.Integer().Between(1, 9223372036854775807)),
+		),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de eventos", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(doc.SchemaOf(domain.AuditEvent{})),
+				"next_cursor": openapi.Integer().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	auditFormats := make([]any, len(exporter.AuditFormats))
+	for i, f := range exporter.AuditFormats {
+		auditFormats[i] = f
+	}
+	doc.Add("GET", "/audit/export", openapi.Operation{
+		OperationID: "exportAuditEvents",
+		Summary:     "Exporta o registro de auditoria em CSV ou NDJSON",
+		Description: "Aceita os mesmos filtros de GET /audit; os eventos saem em ordem cronológica e a resposta é transmitida " +
+			"enquanto é lida do banco. No CSV, before e after vão como JSON.",
+		Tags: []string{"audit"},
+		Parameters: append([]openapi.Parameter{
+			openapi.QueryParam("format", "Formato (padrão csv)", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: auditFormats}),
+		}, auditFilters...),
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Arquivo exportado", Content: map[string]openapi.MediaType{
+				exporter.ContentType(exporter.FormatCSV):    {Schema: openapi.String()},
+				exporter.ContentType(exporter.FormatNDJSON): {Schema: openapi.String()},
+			}},
+			"400": errResp,
+			"500": errResp,
+		},
+	})
+
+	// webhooks
+	webhook := doc.SchemaOf(domain.Webhook{})
+	webhookID := openapi.PathParam("id", "ID da assinatura", openapi.Integer().Between(1, 9223372036854775807))
+	delivery := doc.SchemaOf(domain.WebhookDelivery{})
+	doc.Add("GET", "/webhooks", openapi.Operation{
+		OperationID: "listWebhooks",
+		Summary:     "Lista as assinaturas de webhooks",
+		Tags:        []string{"webhooks"},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Assinaturas", items(webhook)),
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/webhooks", openapi.Operation{
+		OperationID: "createWebhook",
+		Summary:     "Cadastra uma assinatura de webhook",
+		Description: "Cada evento dos tipos assinados vira um POST para url, assinado com HMAC-SHA256 de \"<timestamp>.<corpo>\" " +
+			"(headers X-Webhook-Timestamp e X-Webhook-Signature). Sem secret, um segredo é gerado; " +
+			"ele só aparece nesta resposta.",
+		Tags:        []string{"webhooks"},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.WebhookCreate{})),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.JSONResponse("Assinatura criada (com o segredo)", webhook),
+			"400": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/webhooks/{id}", openapi.Operation{
+		OperationID: "getWebhook",
+		Summary:     "Busca uma assinatura de webhook",
+		Description: "consecutive_failures conta as tentativas seguidas que falharam; ao chegar ao limite a assinatura é desativada (active=false, disabled_reason).",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{webhookID},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Assinatura", webhook),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("PUT", "/webhooks/{id}", openapi.Operation{
+		OperationID: "updateWebhook",
+		Summary:     "Altera uma assinatura de webhook",
+		Description: "Campos ausentes mantêm o valor atual. active=true reativa (e zera as falhas seguidas); active=false desativa. " +
+			"Um secret novo passa a valer nas próximas tentativas.",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{webhookID},
+		RequestBody: openapi.JSONBody(doc.SchemaOf(domain.WebhookUpdate{})),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Assinatura atualizada", webhook),
+			"400": errResp,
+			"404": errResp,
+			"422": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("DELETE", "/webhooks/{id}", openapi.Operation{
+		OperationID: "deleteWebhook",
+		Summary:     "Remove uma assinatura de webhook e o registro das entregas",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{webhookID},
+		Responses: map[string]*openapi.Response{
+			"204": openapi.EmptyResponse("Assinatura removida"),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("GET", "/webhooks/{id}/deliveries", openapi.Operation{
+		OperationID: "listWebhookDeliveries",
+		Summary:     "Entregas de uma assinatura",
+		Description: "Cada entrega traz a situação, as tentativas e o resultado da última (código e início da resposta, erro). Mais novas primeiro.",
+		Tags:        []string{"webhooks"},
+		Parameters: []openapi.Parameter{webhookID,
+			openapi.QueryParam("status", "Situação da entrega", &openapi.Schema{Type: openapi.SchemaType{"string"}, Enum: []any{domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryFailed}}),
+			openapi.QueryParam("limit", "Itens por página (padrão 20, até 100)", openapi.Integer().Between(1, 100)),
+			openapi.QueryParam("cursor", "id da última entrega da página anterior (next_cursor)", openapi.Integer().Between(1, 9223372036854775807)),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.JSONResponse("Página de entregas", openapi.Object(map[string]*openapi.Schema{
+				"items":       openapi.ArrayOf(delivery),
+				"next_cursor": openapi.Integer().Describe("Cursor da próxima página (ausente na última)"),
+			}, "items")),
+			"400": errResp,
+			"404": errResp,
+			"500": errResp,
+		},
+	})
+
+	doc.Add("POST", "/webhooks/{id}/deliveries/{deliveryId}/redeliver", openapi.Operation{
+		OperationID: "redeliverWebhook",
+		Summary:     "Reenvia uma entrega",
+		Description: "Agenda um novo envio do mesmo evento, como uma entrega nova (redelivery_of aponta a original). 409 se a assinatura estiver desativada.",
+		Tags:        []string{"webhooks"},
+		Parameters: []openapi.Parameter{webhookID,
+			openapi.PathParam("deliveryId", "ID da entrega", openapi.Integer().Between(1, 9223372036854775807)),
+		},
+		Responses: map[string]*openapi.Response{
+			"202": openapi.JSONResponse("Reenvio agendado", delivery),
+			"400": errResp,
+			"404": errResp,
+			"409": errResp,
+			"500": errResp,
+		},
+	})
+
+	return doc
+}
+
+// statusTransitionsDoc descreve a máquina de estados da adoção ("available -> reserved|adoption_pending|...").
+func statusTransitionsDoc() string {
+	parts := make([]string, 0, len(domain.CatStatuses))
+	for _, st := range domain.CatStatuses {
+		parts = append(parts, st+" -> "+strings.Join(domain.NextStatuses(st), "|"))
+	}
+	return strings.Join(parts, "; ")
+}
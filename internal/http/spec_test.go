@@ -0,0 +1,22 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/dya-andrade/cat-api/internal/config"
+	"github.com/dya-andrade/cat-api/internal/http/openapi"
+)
+
+// O router e o contrato em spec.go precisam andar juntos: uma rota nova sem
+// documentação (ou uma operação documentada sem rota) quebra este teste.
+func TestSpecMatchesRouter(t *testing.T) {
+	routes, ok := NewRouter(config.Config{}, Services{}).(chi.Routes)
+	if !ok {
+		t.Fatal("NewRouter does not return chi.Routes")
+	}
+	if err := openapi.Verify(routes, apiSpec(), "/docs"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,310 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+Pacote importer: lê arquivos CSV ou NDJSON e converte cada linha em domain.CatCreate.
+
+A validação das regras de negócio (tags `validate`) fica no serviço; aqui só
+tratamos formato: colunas, tipos e conversão de valores. Planilhas com cabeçalho
+em português (nome, idade, raca...) são reconhecidas automaticamente e o cliente
+pode informar um mapeamento extra (coluna -> campo). Colunas e chaves desconhecidas
+são ignoradas e viram avisos no relatório; só um mapeamento para um campo que não
+existe é erro.
+*/
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	// Ignore é o destino de mapeamento usado para descartar uma coluna.
+	Ignore = "-"
+)
+
+// Fields são os campos de CatCreate aceitos na importação.
+var Fields = []string{"name", "age_years", "birth_date", "birth_date_precision", "breed", "coat_color", "coat_colors", "coat_pattern", "weight_kg", "microchip", "sire_id", "dam_id", "litter_id"}
+
+// aliases reconhece cabeçalhos comuns (já normalizados por normalize) para cada campo.
+var aliases = map[string]string{
+	"nome": "name", "gato": "name",
+	"idade": "age_years", "idade_anos": "age_years", "age": "age_years",
+	"nascimento": "birth_date", "data_nascimento": "birth_date", "data_de_nascimento": "birth_date", "birthday": "birth_date",
+	"precisao_nascimento": "birth_date_precision",
+	"raca":                "breed", "raça": "breed",
+	"cor": "coat_color", "pelagem": "coat_color", "cor_pelagem": "coat_color", "coat": "coat_color",
+	"cores": "coat_colors", "padrao": "coat_pattern", "padrão": "coat_pattern", "pattern": "coat_pattern",
+	"peso": "weight_kg", "peso_kg": "weight_kg", "weight": "weight_kg",
+	"chip": "microchip", "numero_microchip": "microchip",
+	"pai": "sire_id", "mae": "dam_id", "mãe": "dam_id", "ninhada": "litter_id",
+}
+
+// Row é uma linha lida do arquivo. Se Err != nil a linha não pôde ser convertida.
+type Row struct {
+	Line     int // número da linha no arquivo (1 = primeira linha, incluindo o cabeçalho no CSV)
+	Cat      domain.CatCreate
+	Err      error
+	Warnings []string // chaves desconhecidas ignoradas (NDJSON)
+}
+
+// Reader devolve uma linha por vez; retorna io.EOF ao final do arquivo.
+type Reader interface {
+	Next() (Row, error)
+	// Warnings lista os avisos do arquivo todo (colunas desconhecidas do cabeçalho CSV).
+	Warnings() []string
+}
+
+// NewReader cria o leitor do formato informado.
+// mapping complementa os aliases padrão: coluna de origem -> campo (ou "-" para ignorar).
+func NewReader(format string, r io.Reader, mapping map[string]string) (Reader, error) {
+	m := map[string]string{}
+	for k, v := range aliases {
+		m[k] = v
+	}
+	for _, f := range Fields {
+		m[f] = f
+	}
+	for k, v := range mapping {
+		if v != Ignore && !isField(v) {
+			return nil, fmt.Errorf("coluna %q mapeada para campo inexistente %q", k, v)
+		}
+		m[normalize(k)] = v
+	}
+
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r, m)
+	case FormatNDJSON:
+		return newNDJSONReader(r, m), nil
+	default:
+		return nil, fmt.Errorf("formato não suportado: %q", format)
+	}
+}
+
+// normalize deixa o cabeçalho comparável: minúsculas, sem espaços nas pontas e "_" no lugar de espaços.
+func normalize(header string) string {
+	header = strings.TrimPrefix(header, "\uFEFF") // BOM que o Excel coloca no início do arquivo
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+}
+
+// resolveField devolve o campo de destino de uma coluna; colunas sem mapeamento
+// são ignoradas (known = false). Os mapeamentos já foram conferidos em NewReader.
+func resolveField(mapping map[string]string, column string) (field string, known bool) {
+	field, ok := mapping[normalize(column)]
+	if !ok {
+		return Ignore, false
+	}
+	return field, true
+}
+
+func isField(name string) bool {
+	for _, f := range Fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+/*** CSV ***/
+
+type csvReader struct {
+	r        *csv.Reader
+	fields   []string // campo de destino de cada coluna
+	warnings []string
+}
+
+func newCSVReader(r io.Reader, mapping map[string]string) (*csvReader, error) {
+	br := bufio.NewReader(r)
+	// Planilhas em pt-BR costumam exportar com ";" (a vírgula é separador decimal)
+	cr := csv.NewReader(br)
+	head, _ := br.Peek(4096)
+	if line, _, _ := bytes.Cut(head, []byte("\n")); bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		cr.Comma = ';'
+	}
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("arquivo vazio: cabeçalho ausente")
+		}
+		return nil, err
+	}
+	c := &csvReader{r: cr, fields: make([]string, len(header))}
+	for i, column := range header {
+		field, known := resolveField(mapping, column)
+		if !known {
+			c.warnings = append(c.warnings, fmt.Sprintf("coluna desconhecida ignorada: %q", column))
+		}
+		c.fields[i] = field
+	}
+	return c, nil
+}
+
+func (c *csvReader) Warnings() []string { return c.warnings }
+
+func (c *csvReader) Next() (Row, error) {
+	record, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return Row{}, io.EOF
+	}
+	if err != nil {
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			return Row{Line: perr.StartLine, Err: perr.Err}, nil
+		}
+		return Row{}, err
+	}
+
+	// FieldPos só vale depois de uma leitura sem erro
+	line, _ := c.r.FieldPos(0)
+	row := Row{Line: line}
+	values := map[string]string{}
+	for i, v := range record {
+		if c.fields[i] != Ignore {
+			values[c.fields[i]] = strings.TrimSpace(v)
+		}
+	}
+	row.Cat, row.Err = fromStrings(values)
+	return row, nil
+}
+
+// fromStrings converte os valores textuais (CSV) para os tipos de CatCreate.
+// Células vazias viram nil nos campos opcionais.
+func fromStrings(values map[string]string) (domain.CatCreate, error) {
+	var c domain.CatCreate
+	c.Name = values["name"]
+	if v := values["age_years"]; v != "" {
+		age, err := strconv.Atoi(v)
+		if err != nil {
+			return c, fmt.Errorf("age_years: %q não é um número inteiro", v)
+		}
+		c.AgeYears = age
+	}
+	if v := values["birth_date"]; v != "" {
+		d, err := domain.ParseDate(v)
+		if err != nil {
+			return c, fmt.Errorf("birth_date: %v", err)
+		}
+		c.BirthDate = &d
+	}
+	if v := values["birth_date_precision"]; v != "" {
+		c.BirthDatePrecision = &v
+	}
+	if v := values["breed"]; v != "" {
+		c.Breed = &v
+	}
+	if v := values["coat_color"]; v != "" {
+		c.CoatColor = &v
+	}
+	if v := values["coat_colors"]; v != "" {
+		// "black,white", "black|white" ou "black white"
+		c.CoatColors = strings.FieldsFunc(strings.ToLower(v), func(r rune) bool {
+			return r == ',' || r == '|' || r == ';' || r == ' '
+		})
+	}
+	if v := values["coat_pattern"]; v != "" {
+		v = strings.ToLower(v)
+		c.CoatPattern = &v
+	}
+	if v := values["microchip"]; v != "" {
+		c.Microchip = &v
+	}
+	if v := values["weight_kg"]; v != "" {
+		w, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64) // aceita "4,5"
+		if err != nil {
+			return c, fmt.Errorf("weight_kg: %q não é um número", v)
+		}
+		c.WeightKG = &w
+	}
+	// genealogia: IDs de gatos (pai, mãe) e da ninhada já cadastrados
+	for _, ref := range []struct {
+		field string
+		dst   **int64
+	}{{"sire_id", &c.SireID}, {"dam_id", &c.DamID}, {"litter_id", &c.LitterID}} {
+		if v := values[ref.field]; v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || id <= 0 {
+				return c, fmt.Errorf("%s: %q não é um ID válido", ref.field, v)
+			}
+			*ref.dst = &id
+		}
+	}
+	return c, nil
+}
+
+/*** NDJSON ***/
+
+type ndjsonReader struct {
+	s       *bufio.Scanner
+	mapping map[string]string
+	line    int
+}
+
+func newNDJSONReader(r io.Reader, mapping map[string]string) *ndjsonReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024) // linhas de até 1MB
+	return &ndjsonReader{s: s, mapping: mapping}
+}
+
+func (n *ndjsonReader) Next() (Row, error) {
+	for n.s.Scan() {
+		n.line++
+		raw := bytes.TrimSpace(n.s.Bytes())
+		if len(raw) == 0 {
+			continue // linhas em branco são ignoradas
+		}
+		row := Row{Line: n.line}
+		row.Cat, row.Warnings, row.Err = n.decode(raw)
+		return row, nil
+	}
+	if err := n.s.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
+
+// Warnings: no NDJSON os avisos são por linha (Row.Warnings).
+func (n *ndjsonReader) Warnings() []string { return nil }
+
+// decode renomeia as chaves conforme o mapeamento e decodifica em CatCreate.
+// Chaves desconhecidas são descartadas e devolvidas como avisos, em ordem alfabética.
+func (n *ndjsonReader) decode(raw []byte) (domain.CatCreate, []string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return domain.CatCreate{}, nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+	renamed := map[string]json.RawMessage{}
+	var warnings []string
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		field, known := resolveField(n.mapping, k)
+		if !known {
+			warnings = append(warnings, fmt.Sprintf("chave desconhecida ignorada: %q", k))
+		}
+		if field != Ignore {
+			renamed[field] = obj[k]
+		}
+	}
+	buf, _ := json.Marshal(renamed)
+	var c domain.CatCreate
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return c, warnings, err
+	}
+	return c, warnings, nil
+}
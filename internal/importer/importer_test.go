@@ -0,0 +1,204 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// readAll lê todas as linhas do arquivo.
+func readAll(t *testing.T, format, data string, mapping map[string]string) []Row {
+	t.Helper()
+	r, err := NewReader(format, strings.NewReader(data), mapping)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	var rows []Row
+	for {
+		row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping map[string]string
+		lines   []int  // linha de cada Row
+		errs    []bool // se cada Row tem erro
+	}{
+		{
+			name:  "cabeçalho em português com ponto e vírgula",
+			data:  "nome;idade;raça;peso\nMingau;3;Persa;4,5\nFrajola;2;;\n",
+			lines: []int{2, 3},
+			errs:  []bool{false, false},
+		},
+		{
+			name:  "aspas soltas",
+			data:  "name,age_years\nMin\"gau,3\nFrajola,2\n",
+			lines: []int{2, 3},
+			errs:  []bool{true, false},
+		},
+		{
+			name:  "número de colunas diferente do cabeçalho",
+			data:  "name,age_years\nMingau,3,extra\nFrajola\nTom,1\n",
+			lines: []int{2, 3, 4},
+			errs:  []bool{true, true, false},
+		},
+		{
+			name:  "aspas sem fechar até o fim do arquivo",
+			data:  "name,age_years\n\"Mingau,3\n",
+			lines: []int{2},
+			errs:  []bool{true},
+		},
+		{
+			name:  "valores inválidos",
+			data:  "name,age_years,weight_kg,sire_id\nMingau,três,4,\nTom,1,pesado,\nLua,1,,0\n",
+			lines: []int{2, 3, 4},
+			errs:  []bool{true, true, true},
+		},
+		{
+			name:    "coluna ignorada pelo mapeamento",
+			data:    "name,observações\nMingau,dócil\n",
+			mapping: map[string]string{"Observações": Ignore},
+			lines:   []int{2},
+			errs:    []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := readAll(t, FormatCSV, tt.data, tt.mapping)
+			if len(rows) != len(tt.lines) {
+				t.Fatalf("got %d rows, want %d: %+v", len(rows), len(tt.lines), rows)
+			}
+			for i, row := range rows {
+				if row.Line != tt.lines[i] {
+					t.Errorf("row %d: line = %d, want %d", i, row.Line, tt.lines[i])
+				}
+				if (row.Err != nil) != tt.errs[i] {
+					t.Errorf("row %d: err = %v, want error %v", i, row.Err, tt.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCSVValues(t *testing.T) {
+	rows := readAll(t, FormatCSV, "nome,idade,peso,cores,pai\nMingau,3,\"4,5\",Black|White,7\n", nil)
+	if len(rows) != 1 || rows[0].Err != nil {
+		t.Fatalf("rows = %+v", rows)
+	}
+	c := rows[0].Cat
+	if c.Name != "Mingau" || c.AgeYears != 3 || c.WeightKG == nil || *c.WeightKG != 4.5 {
+		t.Errorf("cat = %+v", c)
+	}
+	if len(c.CoatColors) != 2 || c.CoatColors[0] != "black" || c.CoatColors[1] != "white" {
+		t.Errorf("coat_colors = %v", c.CoatColors)
+	}
+	if c.SireID == nil || *c.SireID != 7 {
+		t.Errorf("sire_id = %v", c.SireID)
+	}
+}
+
+func TestCSVHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping map[string]string
+	}{
+		{"arquivo vazio", "", nil},
+		{"mapeamento para campo inexistente", "name,olhos\n", map[string]string{"olhos": "eyes"}},
+		{"mapeamento inexistente para coluna ausente", "name\n", map[string]string{"olhos": "eyes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReader(FormatCSV, strings.NewReader(tt.data), tt.mapping); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCSVUnknownColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		mapping  map[string]string
+		warnings []string
+	}{
+		{"todas conhecidas", "nome,idade\nMingau,3\n", nil, nil},
+		{"desconhecida", "name,cor_dos_olhos\nMingau,verde\n", nil, []string{`coluna desconhecida ignorada: "cor_dos_olhos"`}},
+		{"ignorada pelo mapeamento não avisa", "name,obs\nMingau,dócil\n", map[string]string{"obs": Ignore}, nil},
+		{"várias, na ordem do cabeçalho", "olhos,name,Dono\nverde,Mingau,Ana\n", nil,
+			[]string{`coluna desconhecida ignorada: "olhos"`, `coluna desconhecida ignorada: "Dono"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(FormatCSV, strings.NewReader(tt.data), tt.mapping)
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			if got := r.Warnings(); !slices.Equal(got, tt.warnings) {
+				t.Errorf("warnings = %q, want %q", got, tt.warnings)
+			}
+			row, err := r.Next()
+			if err != nil || row.Err != nil || row.Cat.Name != "Mingau" {
+				t.Errorf("row = %+v, err %v", row, err)
+			}
+		})
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	data := strings.Join([]string{
+		`{"nome":"Mingau","idade":3}`,
+		``,
+		`{"name":"Tom"`,
+		`{"name":"Lua","eyes":"blue"}`,
+		`{"name":"Frajola","age_years":"dois"}`,
+		`[1,2]`,
+		`{"name":"Nina","chip":"985112345678903"}`,
+	}, "\n")
+	rows := readAll(t, FormatNDJSON, data, nil)
+	wantLines := []int{1, 3, 4, 5, 6, 7}
+	wantErr := []bool{false, true, false, true, true, false}
+	if len(rows) != len(wantLines) {
+		t.Fatalf("got %d rows, want %d: %+v", len(rows), len(wantLines), rows)
+	}
+	for i, row := range rows {
+		if row.Line != wantLines[i] || (row.Err != nil) != wantErr[i] {
+			t.Errorf("row %d: line %d err %v, want line %d error %v", i, row.Line, row.Err, wantLines[i], wantErr[i])
+		}
+	}
+	if rows[0].Cat.Name != "Mingau" || rows[0].Cat.AgeYears != 3 {
+		t.Errorf("cat = %+v", rows[0].Cat)
+	}
+	if want := []string{`chave desconhecida ignorada: "eyes"`}; rows[2].Cat.Name != "Lua" || !slices.Equal(rows[2].Warnings, want) {
+		t.Errorf("chave desconhecida: cat %+v, warnings %q, want %q", rows[2].Cat, rows[2].Warnings, want)
+	}
+	if rows[5].Cat.Microchip == nil || *rows[5].Cat.Microchip != "985112345678903" {
+		t.Errorf("microchip = %v", rows[5].Cat.Microchip)
+	}
+}
+
+func TestNDJSONUnknownMapping(t *testing.T) {
+	_, err := NewReader(FormatNDJSON, strings.NewReader(`{"name":"Mingau"}`), map[string]string{"olhos": "eyes"})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewReader("xml", strings.NewReader(""), nil); err == nil {
+		t.Error("expected error")
+	}
+}
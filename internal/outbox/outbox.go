@@ -0,0 +1,154 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+Pacote outbox: entrega os eventos de domínio gravados na tabela outbox (na mesma
+transação das mudanças) aos destinos configurados (Sink): webhook, arquivo NDJSON,
+Postgres NOTIFY.
+
+Garantias:
+  - Cada destino recebe cada evento pelo menos uma vez (não exatamente uma vez). A entrega
+    a cada destino é registrada (outbox_deliveries) assim que confirmada, então uma falha em
+    um destino reenvia o evento só para ele; mas se o relay cair entre a confirmação e o
+    registro, o destino recebe o evento de novo. O id do evento permite descartar repetições.
+  - Ordem por agregado: os eventos são entregues na ordem em que foram gravados; se um
+    evento de um gato falha, os seguintes do mesmo gato esperam a próxima rodada.
+    Eventos de outros gatos seguem normalmente.
+  - Um único relay ativo entre as réplicas (advisory lock no Postgres).
+*/
+
+// Sink é um destino de eventos.
+type Sink interface {
+	// Name identifica o destino no registro de entregas; não deve mudar entre execuções.
+	Name() string
+	// Publish entrega o evento; só deve retornar nil se a entrega foi confirmada.
+	Publish(ctx context.Context, e domain.OutboxEvent) error
+}
+
+// Store é o que o relay precisa do banco (storage.OutboxRepository).
+type Store interface {
+	Lock(ctx context.Context) (unlock func(), ok bool, err error)
+	Pending(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, eventID int64, sink string) error
+	MarkPublished(ctx context.Context, eventID int64) error
+	MarkFailed(ctx context.Context, eventID int64, msg string, next time.Time) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+const (
+	publishTimeout = 10 * time.Second // tempo máximo de uma entrega
+	maxBackoff     = 10 * time.Minute // espera máxima entre tentativas de um evento
+)
+
+// Relay lê os eventos pendentes da outbox e os entrega aos destinos.
+type Relay struct {
+	store     Store
+	sinks     []Sink
+	batch     int           // eventos lidos por consulta
+	retention time.Duration // eventos publicados há mais tempo são apagados (0 = mantém)
+}
+
+// NewRelay cria o relay. batch <= 0 usa 100.
+func NewRelay(store Store, sinks []Sink, batch int, retention time.Duration) *Relay {
+	if batch <= 0 {
+		batch = 100
+	}
+	return &Relay{store: store, sinks: sinks, batch: batch, retention: retention}
+}
+
+// Run entrega todos os eventos pendentes e apaga os publicados fora da retenção.
+// Feito para worker.Every: se outra réplica estiver entregando, não faz nada.
+func (r *Relay) Run(ctx context.Context) {
+	unlock, ok, err := r.store.Lock(ctx)
+	if err != nil {
+		log.Printf("outbox: lock: %v", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	if err := r.relay(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("outbox: relay: %v", err)
+	}
+	if r.retention > 0 {
+		if _, err := r.store.Purge(ctx, time.Now().Add(-r.retention)); err != nil && ctx.Err() == nil {
+			log.Printf("outbox: purge: %v", err)
+		}
+	}
+}
+
+// relay percorre os pendentes em ordem, página a página. Um agregado com evento que falhou
+// (ou que ainda espera o backoff) fica bloqueado até o fim da rodada.
+func (r *Relay) relay(ctx context.Context) error {
+	blocked := map[string]bool{}
+	var after int64
+	for {
+		events, err := r.store.Pending(ctx, after, r.batch)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			after = e.ID
+			key := fmt.Sprintf("%s:%d", e.Aggregate, e.AggregateID)
+			if blocked[key] {
+				continue
+			}
+			if e.NextAttemptAt.After(time.Now()) {
+				blocked[key] = true
+				continue
+			}
+			if err := r.publish(ctx, e); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				blocked[key] = true
+				log.Printf("outbox: event %d (%s): %v", e.ID, e.Type, err)
+				if err := r.store.MarkFailed(ctx, e.ID, err.Error(), time.Now().Add(backoff(e.Attempts+1))); err != nil {
+					return err
+				}
+			}
+		}
+		if len(events) < r.batch {
+			return nil
+		}
+	}
+}
+
+// publish entrega o evento aos destinos que ainda não o receberam, registrando cada entrega,
+// e o marca como publicado quando todos receberam.
+func (r *Relay) publish(ctx context.Context, e domain.OutboxEvent) error {
+	for _, s := range r.sinks {
+		if slices.Contains(e.Delivered, s.Name()) {
+			continue
+		}
+		pctx, cancel := context.WithTimeout(ctx, publishTimeout)
+		err := s.Publish(pctx, e)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Name(), err)
+		}
+		if err := r.store.MarkDelivered(ctx, e.ID, s.Name()); err != nil {
+			return err
+		}
+	}
+	return r.store.MarkPublished(ctx, e.ID)
+}
+
+// backoff dobra a espera a cada falha: 2s, 4s, 8s... até maxBackoff.
+func backoff(attempts int) time.Duration {
+	if attempts >= 20 {
+		return maxBackoff
+	}
+	return min(time.Second<<attempts, maxBackoff)
+}
@@ -0,0 +1,119 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{9, 512 * time.Second},
+		{10, maxBackoff}, // 1024s passaria do limite
+		{20, maxBackoff},
+		{64, maxBackoff}, // 1s<<64 estouraria
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// fakeStore guarda os eventos pendentes e registra o que o relay marcou.
+type fakeStore struct {
+	events    []domain.OutboxEvent
+	delivered []string // "id:sink"
+	published []int64
+	failed    []int64
+}
+
+func (s *fakeStore) Lock(ctx context.Context) (func(), bool, error) { return func() {}, true, nil }
+
+func (s *fakeStore) Pending(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	var out []domain.OutboxEvent
+	for _, e := range s.events {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) MarkDelivered(ctx context.Context, eventID int64, sink string) error {
+	s.delivered = append(s.delivered, fmt.Sprintf("%d:%s", eventID, sink))
+	return nil
+}
+
+func (s *fakeStore) MarkPublished(ctx context.Context, eventID int64) error {
+	s.published = append(s.published, eventID)
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(ctx context.Context, eventID int64, msg string, next time.Time) error {
+	s.failed = append(s.failed, eventID)
+	return nil
+}
+
+func (s *fakeStore) Purge(ctx context.Context, before time.Time) (int64, error) { return 0, nil }
+
+// fakeSink falha para os eventos em fail.
+type fakeSink struct {
+	name string
+	fail map[int64]bool
+	got  []int64
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Publish(ctx context.Context, e domain.OutboxEvent) error {
+	if s.fail[e.ID] {
+		return errors.New("down")
+	}
+	s.got = append(s.got, e.ID)
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	cat := func(id, catID int64) domain.OutboxEvent {
+		return domain.OutboxEvent{ID: id, Aggregate: "cat", AggregateID: catID}
+	}
+	waiting := cat(6, 3)
+	waiting.NextAttemptAt = time.Now().Add(time.Minute)
+	retried := cat(1, 1)
+	retried.Delivered = []string{"file"} // só o webhook falhou da outra vez
+
+	store := &fakeStore{events: []domain.OutboxEvent{retried, cat(2, 2), cat(3, 1), cat(4, 2), cat(5, 3), waiting, cat(7, 3)}}
+	file := &fakeSink{name: "file"}
+	webhook := &fakeSink{name: "webhook", fail: map[int64]bool{2: true}}
+	NewRelay(store, []Sink{file, webhook}, 2, 0).Run(context.Background())
+
+	// o gato 2 para no evento 2 (falhou) e o gato 3 no 6 (esperando o backoff)
+	if want := []int64{1, 3, 5}; !slices.Equal(store.published, want) {
+		t.Errorf("published %v, want %v", store.published, want)
+	}
+	if want := []int64{2}; !slices.Equal(store.failed, want) {
+		t.Errorf("failed %v, want %v", store.failed, want)
+	}
+	if want := []int64{2, 3, 5}; !slices.Equal(file.got, want) {
+		t.Errorf("file got %v, want %v", file.got, want)
+	}
+	if want := []int64{1, 3, 5}; !slices.Equal(webhook.got, want) {
+		t.Errorf("webhook got %v, want %v", webhook.got, want)
+	}
+	// o evento 2 chegou ao arquivo: na próxima rodada só o webhook o recebe
+	if !slices.Contains(store.delivered, "2:file") {
+		t.Errorf("delivered %v, want 2:file recorded", store.delivered)
+	}
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*** webhook ***/
+
+// Webhook entrega cada evento com um POST do JSON do evento para uma URL fixa.
+// Qualquer resposta fora de 2xx é uma falha (o evento é reenviado depois).
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: publishTimeout}}
+}
+
+func (w *Webhook) Name() string { return "webhook" }
+
+func (w *Webhook) Publish(ctx context.Context, e domain.OutboxEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", fmt.Sprint(e.ID))
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // permite reaproveitar a conexão
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s respondeu %d", w.url, resp.StatusCode)
+	}
+	return nil
+}
+
+/*** arquivo NDJSON ***/
+
+// File acrescenta cada evento, uma linha JSON por evento, ao fim de um arquivo.
+// Cada linha é sincronizada com o disco (fsync) antes de a entrega ser confirmada.
+type File struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFile abre (ou cria) o arquivo para acrescentar eventos. Feche com Close.
+func NewFile(path string) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &File{f: f}, nil
+}
+
+func (f *File) Name() string { return "file" }
+
+func (f *File) Publish(_ context.Context, e domain.OutboxEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.f.Sync()
+}
+
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.f.Close()
+}
+
+/*** Postgres NOTIFY ***/
+
+// Notifier envia uma notificação a um canal do Postgres (storage.OutboxRepository).
+type Notifier interface {
+	Notify(ctx context.Context, channel, payload string) error
+}
+
+// NotifyEvent é o que vai na notificação: o NOTIFY aceita até 8000 bytes, então vai
+// só a identificação do evento; quem precisar dos dados os lê pelo id.
+type NotifyEvent struct {
+	ID          int64     `json:"id"`
+	Type        string    `json:"type"`
+	Aggregate   string    `json:"aggregate"`
+	AggregateID int64     `json:"aggregate_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Notify publica os eventos com pg_notify no canal informado (ouvido com LISTEN).
+// Notificações não são guardadas: quem não estiver ouvindo no momento não as recebe.
+type Notify struct {
+	n       Notifier
+	channel string
+}
+
+func NewNotify(n Notifier, channel string) *Notify {
+	return &Notify{n: n, channel: channel}
+}
+
+func (n *Notify) Name() string { return "notify" }
+
+func (n *Notify) Publish(ctx context.Context, e domain.OutboxEvent) error {
+	payload, err := json.Marshal(NotifyEvent{ID: e.ID, Type: e.Type, Aggregate: e.Aggregate, AggregateID: e.AggregateID, CreatedAt: e.CreatedAt})
+	if err != nil {
+		return err
+	}
+	return n.n.Notify(ctx, n.channel, string(payload))
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrInvalidStatus            = domain.ErrInvalidStatus
+	ErrApplicationNotFound      = domain.ErrApplicationNotFound
+	ErrApplicationDecided       = domain.ErrApplicationDecided
+	ErrApplicationExists        = domain.ErrApplicationExists
+	ErrNotAcceptingApplications = domain.ErrNotAcceptingApplications
+)
+
+// AdoptionRepository descreve a persistência do fluxo de adoção.
+type AdoptionRepository interface {
+	ChangeStatus(ctx context.Context, catID int64, in domain.StatusChange) (domain.Cat, error)
+	History(ctx context.Context, catID int64) ([]domain.StatusTransition, error)
+	Apply(ctx context.Context, catID int64, in domain.ApplicationCreate) (domain.AdoptionApplication, error)
+	GetApplication(ctx context.Context, id int64) (domain.AdoptionApplication, error)
+	Applications(ctx context.Context, f domain.ApplicationFilter, limit int, cursor *time.Time) ([]domain.AdoptionApplication, *time.Time, error)
+	Approve(ctx context.Context, id int64, in domain.ApplicationDecision, on domain.Date) (domain.AdoptionApplication, error)
+	Reject(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error)
+}
+
+// AdoptionService expõe o status de adoção dos gatos e as candidaturas.
+type AdoptionService interface {
+	ChangeStatus(ctx context.Context, catID int64, in domain.StatusChange) (domain.Cat, error)                                                    // Muda o status (máquina de estados)
+	History(ctx context.Context, catID int64) ([]domain.StatusTransition, error)                                                                  // Histórico de status
+	Apply(ctx context.Context, catID int64, in domain.ApplicationCreate) (domain.AdoptionApplication, error)                                      // Registra uma candidatura
+	GetApplication(ctx context.Context, id int64) (domain.AdoptionApplication, error)                                                             // Busca uma candidatura
+	Applications(ctx context.Context, f domain.ApplicationFilter, limit int, cursor *time.Time) ([]domain.AdoptionApplication, *time.Time, error) // Lista candidaturas
+	Approve(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error)                                     // Aprova (gato adotado)
+	Reject(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error)                                      // Rejeita
+}
+
+type adoptionService struct {
+	repo      AdoptionRepository
+	requestTO time.Duration
+}
+
+// NewAdoptionService cria o serviço de adoção com o timeout das requisições.
+func NewAdoptionService(repo AdoptionRepository, requestTimeout time.Duration) AdoptionService {
+	return &adoptionService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *adoptionService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+// ChangeStatus muda o status do gato; transições fora da máquina de estados
+// retornam *domain.TransitionError (errors.Is(err, ErrInvalidStatus)).
+func (s *adoptionService) ChangeStatus(ctx context.Context, catID int64, in domain.StatusChange) (domain.Cat, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.ChangeStatus(ctx, catID, in)
+}
+
+func (s *adoptionService) History(ctx context.Context, catID int64) ([]domain.StatusTransition, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.History(ctx, catID)
+}
+
+func (s *adoptionService) Apply(ctx context.Context, catID int64, in domain.ApplicationCreate) (domain.AdoptionApplication, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Apply(ctx, catID, in)
+}
+
+func (s *adoptionService) GetApplication(ctx context.Context, id int64) (domain.AdoptionApplication, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.GetApplication(ctx, id)
+}
+
+func (s *adoptionService) Applications(ctx context.Context, f domain.ApplicationFilter, limit int, cursor *time.Time) ([]domain.AdoptionApplication, *time.Time, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Applications(ctx, f, limit, cursor)
+}
+
+// Approve aprova a candidatura: o gato fica adopted e o candidato vira o tutor principal a partir de hoje.
+func (s *adoptionService) Approve(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Approve(ctx, id, in, domain.Today())
+}
+
+func (s *adoptionService) Reject(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Reject(ctx, id, in)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrAttributeNotFound  = domain.ErrAttributeNotFound
+	ErrInvalidAttributes  = domain.ErrInvalidAttributes
+	ErrInvalidAttributeID = domain.ErrInvalidAttributeID
+)
+
+// AttributeRepository descreve a persistência das definições de atributos por tenant.
+type AttributeRepository interface {
+	List(ctx context.Context, tenant string) ([]domain.AttributeDef, error)
+	Put(ctx context.Context, tenant, key string, in domain.AttributeDefInput) (domain.AttributeDef, error)
+	Delete(ctx context.Context, tenant, key string) error
+}
+
+// AttributeService gerencia o esquema de atributos personalizados do tenant da requisição (ver Tenant).
+type AttributeService interface {
+	List(ctx context.Context) ([]domain.AttributeDef, error)                                       // Definições do tenant
+	Put(ctx context.Context, key string, in domain.AttributeDefInput) (domain.AttributeDef, error) // Cria ou altera uma chave
+	Delete(ctx context.Context, key string) error                                                  // Remove uma chave
+}
+
+type attributeService struct {
+	repo      AttributeRepository
+	requestTO time.Duration
+}
+
+func NewAttributeService(repo AttributeRepository, requestTimeout time.Duration) AttributeService {
+	return &attributeService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *attributeService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *attributeService) List(ctx context.Context) ([]domain.AttributeDef, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx, Tenant(ctx))
+}
+
+func (s *attributeService) Put(ctx context.Context, key string, in domain.AttributeDefInput) (domain.AttributeDef, error) {
+	if err := domain.CheckAttributeKey(key); err != nil {
+		return domain.AttributeDef{}, err
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Put(ctx, Tenant(ctx), key, in)
+}
+
+func (s *attributeService) Delete(ctx context.Context, key string) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, Tenant(ctx), key)
+}
+
+// AttributeLister é o que o serviço de gatos precisa para conferir os atributos: as definições do tenant.
+type AttributeLister interface {
+	List(ctx context.Context, tenant string) ([]domain.AttributeDef, error)
+}
+
+// attributeChecker confere os atributos de um gato contra o esquema do tenant da requisição.
+type attributeChecker struct {
+	defs AttributeLister // nil desliga a conferência
+}
+
+// schema carrega o esquema do tenant do contexto.
+func (c attributeChecker) schema(ctx context.Context) (domain.AttributeSchema, error) {
+	defs, err := c.defs.List(ctx, Tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	schema := make(domain.AttributeSchema, len(defs))
+	for _, d := range defs {
+		schema[d.Key] = d
+	}
+	return schema, nil
+}
+
+// check devolve os atributos normalizados (domain.ErrInvalidAttributes se não baterem com o esquema).
+// Sem atributos, o esquema nem é lido.
+func (c attributeChecker) check(ctx context.Context, attrs map[string]any) (map[string]any, error) {
+	if len(attrs) == 0 || c.defs == nil {
+		return attrs, nil
+	}
+	schema, err := c.schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Check(attrs)
+}
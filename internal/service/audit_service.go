@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+)
+
+// AuditRepository descreve a leitura do registro de auditoria (a escrita é feita pelo banco).
+type AuditRepository interface {
+	List(ctx context.Context, f domain.AuditFilter, limit int, cursor *int64) ([]domain.AuditEvent, *int64, error)
+	Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEvent) error) error
+}
+
+// AuditService expõe o registro de auditoria das mudanças.
+type AuditService interface {
+	List(ctx context.Context, f domain.AuditFilter, limit int, cursor *int64) ([]domain.AuditEvent, *int64, error) // Página de eventos (mais recentes primeiro)
+	// ValidateExport confere o formato antes de começar a escrever a resposta.
+	ValidateExport(format string) error
+	// Export escreve os eventos do filtro em w, em ordem cronológica.
+	// flush (opcional) é chamado periodicamente para enviar o que já foi escrito.
+	Export(ctx context.Context, f domain.AuditFilter, format string, w io.Writer, flush func()) error
+}
+
+type auditService struct {
+	repo      AuditRepository
+	requestTO time.Duration
+}
+
+func NewAuditService(repo AuditRepository, requestTimeout time.Duration) AuditService {
+	return &auditService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *auditService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *auditService) List(ctx context.Context, f domain.AuditFilter, limit int, cursor *int64) ([]domain.AuditEvent, *int64, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx, f, limit, cursor)
+}
+
+func (s *auditService) ValidateExport(format string) error {
+	if _, err := exporter.NewAuditWriter(format, io.Discard); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	return nil
+}
+
+// Export não usa o timeout das requisições: como a exportação de gatos, dura o que o volume exigir.
+func (s *auditService) Export(ctx context.Context, f domain.AuditFilter, format string, w io.Writer, flush func()) error {
+	aw, err := exporter.NewAuditWriter(format, w)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	n := 0
+	err = s.repo.Stream(ctx, f, func(e domain.AuditEvent) error {
+		if err := aw.Write(e); err != nil {
+			return err
+		}
+		n++
+		if n%exportFlushEvery == 0 && flush != nil {
+			flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return aw.Close()
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrBreedNotFound = domain.ErrBreedNotFound
+	ErrUnknownBreed  = domain.ErrUnknownBreed // raça fora do catálogo com BREED_POLICY=reject
+)
+
+// BreedRepository descreve a leitura do catálogo de raças.
+type BreedRepository interface {
+	Match(ctx context.Context, name string) (domain.Breed, error)
+	GetByID(ctx context.Context, id int64) (domain.Breed, error)
+	Search(ctx context.Context, prefix string, limit int) ([]domain.Breed, error)
+	Stats(ctx context.Context) (domain.BreedStats, error)
+}
+
+// BreedService expõe o catálogo de raças.
+type BreedService interface {
+	Search(ctx context.Context, prefix string, limit int) ([]domain.Breed, error) // Autocomplete por prefixo
+	GetByID(ctx context.Context, id int64) (domain.Breed, error)                  // Busca uma raça
+	Stats(ctx context.Context) (domain.BreedStats, error)                         // Gatos por raça
+}
+
+type breedService struct {
+	repo      BreedRepository
+	requestTO time.Duration
+}
+
+func NewBreedService(repo BreedRepository, requestTimeout time.Duration) BreedService {
+	return &breedService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *breedService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *breedService) Search(ctx context.Context, prefix string, limit int) ([]domain.Breed, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Search(ctx, prefix, limit)
+}
+
+func (s *breedService) GetByID(ctx context.Context, id int64) (domain.Breed, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *breedService) Stats(ctx context.Context) (domain.BreedStats, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Stats(ctx)
+}
+
+// BreedMatcher é o que o serviço de gatos precisa do catálogo para normalizar breed.
+type BreedMatcher interface {
+	Match(ctx context.Context, name string) (domain.Breed, error)
+}
+
+// breedNormalizer troca o breed informado pelo nome canônico do catálogo e devolve o breed_id.
+// Raças fora do catálogo seguem a política: flag mantém o texto (sem breed_id), reject recusa.
+type breedNormalizer struct {
+	catalog BreedMatcher // nil desliga a normalização
+	policy  string
+}
+
+// normalize devolve o breed a gravar e o breed_id correspondente. breed nil (campo ausente) passa direto;
+// vazio também, sem breed_id: é "sem raça" (no PUT, remove a atual).
+func (n breedNormalizer) normalize(ctx context.Context, breed *string) (*string, *int64, error) {
+	if breed == nil || n.catalog == nil {
+		return breed, nil, nil
+	}
+	name := strings.TrimSpace(*breed)
+	if name == "" {
+		return &name, nil, nil
+	}
+	b, err := n.catalog.Match(ctx, name)
+	switch {
+	case err == nil:
+		return &b.Name, &b.ID, nil
+	case !errors.Is(err, domain.ErrBreedNotFound):
+		return nil, nil, err
+	case n.policy == domain.BreedPolicyReject:
+		return nil, nil, fmt.Errorf("%w: %q (veja GET /breeds)", ErrUnknownBreed, name)
+	}
+	return &name, nil, nil
+}
@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dya-andrade/cat-api/internal/domain"
@@ -10,43 +14,68 @@ import (
 )
 
 var (
-	ErrNotFound = errors.New("cat not found")
+	ErrNotFound           = domain.ErrNotFound           // gato não encontrado
+	ErrVersionMismatch    = domain.ErrVersionMismatch    // If-Match não corresponde à versão atual
+	ErrCatVersionNotFound = domain.ErrCatVersionNotFound // versão pedida não está no histórico
+	ErrInvalidBirthDate   = domain.ErrInvalidBirthDate   // birth_date no futuro ou acima da idade máxima
+	ErrInvalidCoat        = domain.ErrInvalidCoat        // cores da pelagem incompatíveis com o padrão
+	ErrBatchTooLarge      = errors.New("batch has too many operations")
+	ErrInvalidFields      = errors.New("invalid fields")
 )
 
 // CatRepository descreve o que o serviço precisa do repositório.
 // Define as operações que o serviço pode executar no banco de dados.
 type CatRepository interface {
-	Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error)                      // Cria um novo gato no banco
-	GetByID(ctx context.Context, id int64) (domain.Cat, error)                                // Busca um gato pelo ID
-	List(ctx context.Context, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) // Lista gatos com paginação
-	//Update(ctx context.Context, id int64, in domain.CatUpdate) (domain.Cat, error) // Atualiza um gato
-	//Delete(ctx context.Context, id int64) error                                   // Remove um gato
+	Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error)                                                                  // Cria um novo gato no banco
+	GetByID(ctx context.Context, id int64, p domain.CatProjection) (domain.Cat, error)                                                    // Busca um gato pelo ID
+	List(ctx context.Context, f domain.CatFilter, p domain.CatProjection, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) // Lista gatos com paginação
+	Update(ctx context.Context, id, expectedVersion int64, in domain.CatUpdate) (domain.Cat, error)                                       // Atualiza um gato (0 = sem checagem de versão)
+	Delete(ctx context.Context, id, expectedVersion int64) error                                                                          // Remove um gato (0 = sem checagem de versão)
+	Batch(ctx context.Context, ops []domain.CatBatchOp, atomic bool) ([]domain.CatBatchResult, error)                                     // Executa um lote (atômico ou parcial)
+	Versions(ctx context.Context, id int64, limit int, cursor *int64) ([]domain.CatVersion, *int64, error)                                // Versões guardadas do gato
+	GetAsOf(ctx context.Context, id int64, at time.Time, p domain.CatProjection) (domain.Cat, error)                                      // Gato como estava no instante at
+	Revert(ctx context.Context, id, version, expectedVersion int64) (domain.Cat, error)                                                   // Restaura uma versão como versão nova
+	AddThumbnail(ctx context.Context, catID int64, path string) (domain.CatThumbnail, error)                                              // Registra uma miniatura gerada (repetir não duplica)
+	CatsWithoutThumbnail(ctx context.Context, before time.Time, limit int) ([]int64, error)                                               // Gatos criados antes de before ainda sem miniatura
 }
 
 // CatService define as operações disponíveis para uso externo (ex: API).
 type CatService interface {
-	Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error)                      // Cria um novo gato
-	GetByID(ctx context.Context, id int64) (domain.Cat, error)                                // Busca um gato pelo ID
-	List(ctx context.Context, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) // Lista gatos
-	//Update(ctx context.Context, id int64, in domain.CatUpdate) (domain.Cat, error) // Atualiza um gato
-	//Delete(ctx context.Context, id int64) error                                   // Remove um gato
+	Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error)                                                                  // Cria um novo gato
+	GetByID(ctx context.Context, id int64, p domain.CatProjection) (domain.Cat, error)                                                    // Busca um gato pelo ID
+	List(ctx context.Context, f domain.CatFilter, p domain.CatProjection, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) // Lista gatos
+	Update(ctx context.Context, id, expectedVersion int64, in domain.CatUpdate) (domain.Cat, error)                                       // Atualiza um gato
+	Delete(ctx context.Context, id, expectedVersion int64) error                                                                          // Remove um gato
+	Batch(ctx context.Context, in domain.CatBatchRequest) ([]domain.CatBatchResult, error)                                                // Executa operações em lote
+	Versions(ctx context.Context, id int64, limit int, cursor *int64) ([]domain.CatVersion, *int64, error)                                // Lista as versões do gato
+	GetAsOf(ctx context.Context, id int64, at time.Time, p domain.CatProjection) (domain.Cat, error)                                      // Busca o gato como estava no instante at
+	Revert(ctx context.Context, id, version, expectedVersion int64) (domain.Cat, error)                                                   // Reverte o gato para uma versão anterior
+	SweepThumbnails(ctx context.Context) (int, error)                                                                                     // Agenda as miniaturas que ficaram para trás
 }
 
 // catService é a implementação concreta do CatService.
 // Usa um repositório para acessar o banco, um pool de workers para tarefas assíncronas e um timeout para requisições.
 type catService struct {
-	repo      CatRepository // Repositório para acessar dados dos gatos
-	wp        *worker.Pool  // Pool de workers para tarefas assíncronas
-	requestTO time.Duration // Tempo limite para cada requisição
+	repo      CatRepository    // Repositório para acessar dados dos gatos
+	wp        *worker.Pool     // Pool das miniaturas (separado do pool dos jobs, que também cria gatos)
+	requestTO time.Duration    // Tempo limite para cada requisição
+	batchMax  int              // Número máximo de operações por lote
+	breeds    breedNormalizer  // Normaliza breed pelo catálogo de raças
+	attrs     attributeChecker // Confere attributes contra o esquema do tenant
 }
 
 // NewCatService cria uma nova instância do serviço de gatos.
-// Recebe o repositório, o pool de workers e o timeout das requisições.
-func NewCatService(repo CatRepository, wp *worker.Pool, requestTimeout time.Duration) CatService {
+// Recebe o repositório, o pool que gera as miniaturas, o timeout das requisições, o tamanho máximo de um lote,
+// o catálogo de raças, a política para raças fora dele (domain.BreedPolicyFlag ou BreedPolicyReject)
+// e as definições de atributos personalizados de cada tenant.
+func NewCatService(repo CatRepository, wp *worker.Pool, requestTimeout time.Duration, batchMax int, breeds BreedMatcher, breedPolicy string, attrs AttributeLister) CatService {
 	return &catService{
 		repo:      repo,
 		wp:        wp,
 		requestTO: requestTimeout,
+		batchMax:  batchMax,
+		breeds:    breedNormalizer{catalog: breeds, policy: breedPolicy},
+		attrs:     attributeChecker{defs: attrs},
 	}
 }
 
@@ -60,55 +89,266 @@ func (s *catService) withTO(ctx context.Context) (context.Context, context.Cance
 }
 
 // Create cria um novo gato.
-// Usa contexto com timeout, chama o repositório para salvar o gato e dispara uma tarefa assíncrona (exemplo: gerar thumbnail).
+// Usa contexto com timeout, normaliza a raça pelo catálogo, confere os atributos, chama o repositório para salvar o gato
+// e dispara uma tarefa assíncrona (exemplo: gerar thumbnail).
 func (s *catService) Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error) {
 	ctx, cancel := s.withTO(ctx)
 	defer cancel()
 
+	var err error
+	if in.Breed, in.BreedID, err = s.breeds.normalize(ctx, in.Breed); err != nil {
+		return domain.Cat{}, err
+	}
+	if in.Attributes, err = s.attrs.check(ctx, in.Attributes); err != nil {
+		return domain.Cat{}, err
+	}
 	cat, err := s.repo.Create(ctx, in)
 	if err != nil {
 		return domain.Cat{}, err
 	}
 
-	// Exemplo de tarefa assíncrona: gerar thumbnail (simulado)
-	_ = s.wp.Submit(func() error {
+	if !s.enqueueThumbnail(cat.ID) {
+		log.Printf("thumbnail: cat %d: queue full, left for the sweep", cat.ID)
+	}
+	return cat, nil
+}
+
+// enqueueThumbnail dispara a tarefa assíncrona de gerar a thumbnail do gato (simulado).
+// Ao terminar, a miniatura é registrada; o registro grava o evento cat.thumbnail_ready na outbox.
+// Não bloqueia: o gato já foi gravado, então a resposta não espera vaga na fila. Com a fila cheia
+// (um lote grande, por exemplo), a miniatura fica para SweepThumbnails: o gato sem miniatura no
+// banco é o registro de que ela falta. Retorna false se a tarefa não entrou na fila.
+func (s *catService) enqueueThumbnail(catID int64) bool {
+	return s.wp.TrySubmit(func() error {
 		// aqui você faria trabalho pesado (ex.: imagem, chamada externa)
 		time.Sleep(200 * time.Millisecond)
-		return nil
+
+		ctx, cancel := s.withTO(context.Background())
+		defer cancel()
+		_, err := s.repo.AddThumbnail(ctx, catID, fmt.Sprintf("thumbnails/%d.jpg", catID))
+		if err != nil && !errors.Is(err, ErrNotFound) { // ErrNotFound: o gato foi removido antes
+			log.Printf("thumbnail: cat %d: %v", catID, err)
+		}
+		return err
 	})
+}
 
-	return cat, nil
+const (
+	thumbnailGrace = time.Minute // a varredura só pega gatos criados há mais tempo (a criação já agendou os outros)
+	thumbnailSweep = 500         // gatos lidos por varredura
+)
+
+// SweepThumbnails agenda a miniatura dos gatos que não a têm: as que não couberam na fila e as
+// que se perderam com o processo (a fila fica em memória). Para quando a fila enche; o resto fica
+// para a próxima rodada. Feito para worker.Every. Retorna quantas agendou.
+func (s *catService) SweepThumbnails(ctx context.Context) (int, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	ids, err := s.repo.CatsWithoutThumbnail(ctx, time.Now().Add(-thumbnailGrace), thumbnailSweep)
+	if err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		if !s.enqueueThumbnail(id) {
+			return i, nil
+		}
+	}
+	return len(ids), nil
 }
 
 // GetByID busca um gato pelo ID.
 // Usa contexto com timeout e chama o repositório para buscar o gato.
-func (c *catService) GetByID(ctx context.Context, id int64) (domain.Cat, error) {
+// p escolhe os campos lidos e as relações embutidas (valor zero = gato completo).
+func (c *catService) GetByID(ctx context.Context, id int64, p domain.CatProjection) (domain.Cat, error) {
+	if err := checkProjection(p); err != nil {
+		return domain.Cat{}, err
+	}
 	ctx, cancel := c.withTO(ctx)
 	defer cancel()
-	return c.repo.GetByID(ctx, id)
+	return c.repo.GetByID(ctx, id, p)
 }
 
-// List retorna uma lista de gatos com paginação.
+// List retorna uma lista de gatos filtrada e com paginação.
 // Usa contexto com timeout e chama o repositório para buscar os gatos.
-func (c *catService) List(ctx context.Context, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) {
+func (c *catService) List(ctx context.Context, f domain.CatFilter, p domain.CatProjection, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) {
+	if err := checkProjection(p); err != nil {
+		return nil, nil, err
+	}
 	ctx, cancel := c.withTO(ctx)
 	defer cancel()
-	return c.repo.List(ctx, limit, cursor)
+	return c.repo.List(ctx, f, p, limit, cursor)
 }
 
-/*
-func (s *catService) Update(ctx context.Context, id int64, in domain.UpdateCatInput) (domain.Cat, error) {
-    // Atualiza um gato usando contexto com timeout
-    ctx, cancel := s.withTO(ctx); defer cancel()
-    return s.repo.Update(ctx, id, in)
+// checkProjection rejeita campos que não existem em domain.CatFields (ErrInvalidFields).
+func checkProjection(p domain.CatProjection) error {
+	var unknown []string
+	for _, f := range p.Fields {
+		if !slices.Contains(domain.CatFields, f) {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s (disponíveis: %s)", ErrInvalidFields, strings.Join(unknown, ", "), strings.Join(domain.CatFields, ", "))
+	}
+	return nil
+}
+
+// Update atualiza um gato usando contexto com timeout.
+// expectedVersion vem do If-Match; se divergir da versão atual o repositório retorna ErrVersionMismatch.
+func (s *catService) Update(ctx context.Context, id, expectedVersion int64, in domain.CatUpdate) (domain.Cat, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	var err error
+	if in.Breed, in.BreedID, err = s.breeds.normalize(ctx, in.Breed); err != nil {
+		return domain.Cat{}, err
+	}
+	if in.Attributes, err = s.attrs.check(ctx, in.Attributes); err != nil {
+		return domain.Cat{}, err
+	}
+	return s.repo.Update(ctx, id, expectedVersion, in)
 }
 
-func (s *catService) Delete(ctx context.Context, id int64) error {
-    // Remove um gato usando contexto com timeout
-    ctx, cancel := s.withTO(ctx); defer cancel()
-    return s.repo.Delete(ctx, id)
+// Delete remove um gato usando contexto com timeout, com a mesma checagem de versão do Update.
+func (s *catService) Delete(ctx context.Context, id, expectedVersion int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, id, expectedVersion)
+}
+
+// Versions lista as versões guardadas do gato, da mais recente à mais antiga.
+func (s *catService) Versions(ctx context.Context, id int64, limit int, cursor *int64) ([]domain.CatVersion, *int64, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Versions(ctx, id, limit, cursor)
+}
+
+// GetAsOf busca o gato como estava no instante at (GET /cats/{id}?as_of=).
+// Aceita fields=, mas não include=: miniaturas e etiquetas não têm histórico (ErrInvalidFields).
+func (s *catService) GetAsOf(ctx context.Context, id int64, at time.Time, p domain.CatProjection) (domain.Cat, error) {
+	if p.Thumbnails || p.Tags {
+		return domain.Cat{}, fmt.Errorf("%w: include não é suportado com as_of (miniaturas e etiquetas não têm histórico)", ErrInvalidFields)
+	}
+	if err := checkProjection(p); err != nil {
+		return domain.Cat{}, err
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.GetAsOf(ctx, id, at, p)
+}
+
+// Revert restaura o cadastro da versão informada como uma nova versão, com a mesma checagem
+// de versão do Update. O status de adoção não é revertido.
+func (s *catService) Revert(ctx context.Context, id, version, expectedVersion int64) (domain.Cat, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Revert(ctx, id, version, expectedVersion)
+}
+
+// Batch executa um lote de operações (padrão: atômico).
+// Rejeita lotes acima de batchMax, normaliza as raças e, após sucesso, agenda a thumbnail de cada gato criado.
+func (s *catService) Batch(ctx context.Context, in domain.CatBatchRequest) ([]domain.CatBatchResult, error) {
+	if s.batchMax > 0 && len(in.Operations) > s.batchMax {
+		return nil, fmt.Errorf("%w: %d > %d", ErrBatchTooLarge, len(in.Operations), s.batchMax)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	atomic := in.Mode != domain.BatchPartial
+	ops, failed, err := s.normalizeBatch(ctx, in.Operations, atomic)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.repo.Batch(ctx, ops, atomic)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) > 0 {
+		results = mergeBatchResults(len(in.Operations), results, failed)
+	}
+	skipped := 0
+	for _, res := range results {
+		if res.Op == domain.BatchOpCreate && res.Err == nil && !s.enqueueThumbnail(res.Cat.ID) {
+			skipped++
+		}
+	}
+	if skipped > 0 {
+		log.Printf("thumbnail: batch: queue full, %d left for the sweep", skipped)
+	}
+	return results, nil
+}
+
+// normalizeBatch normaliza breed e confere attributes em cada create/update do lote, sem alterar
+// as operações recebidas. Uma raça recusada pela política ou atributos fora do esquema desfazem o
+// lote atômico (*domain.BatchError); no modo parcial, a operação sai do lote e o erro fica em failed,
+// com o índice original.
+func (s *catService) normalizeBatch(ctx context.Context, ops []domain.CatBatchOp, atomic bool) ([]domain.CatBatchOp, []domain.CatBatchResult, error) {
+	out := make([]domain.CatBatchOp, 0, len(ops))
+	var failed []domain.CatBatchResult
+
+	var schema domain.AttributeSchema // lido uma vez, na primeira operação com atributos
+	checkAttrs := func(attrs map[string]any) (map[string]any, error) {
+		if len(attrs) == 0 || s.attrs.defs == nil {
+			return attrs, nil
+		}
+		if schema == nil {
+			var err error
+			if schema, err = s.attrs.schema(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return schema.Check(attrs)
+	}
+
+	for i, op := range ops {
+		var err error
+		switch {
+		case op.Op == domain.BatchOpCreate && op.Cat != nil:
+			c := *op.Cat
+			if c.Breed, c.BreedID, err = s.breeds.normalize(ctx, c.Breed); err == nil {
+				c.Attributes, err = checkAttrs(c.Attributes)
+			}
+			op.Cat = &c
+		case op.Op == domain.BatchOpUpdate && op.Changes != nil:
+			u := *op.Changes
+			if u.Breed, u.BreedID, err = s.breeds.normalize(ctx, u.Breed); err == nil {
+				u.Attributes, err = checkAttrs(u.Attributes)
+			}
+			op.Changes = &u
+		}
+		switch {
+		case err == nil:
+			out = append(out, op)
+		case !errors.Is(err, ErrUnknownBreed) && !errors.Is(err, ErrInvalidAttributes):
+			return nil, nil, err
+		case atomic:
+			return nil, nil, &domain.BatchError{Index: i, Err: err}
+		default:
+			failed = append(failed, domain.CatBatchResult{Index: i, Op: op.Op, Err: err})
+		}
+	}
+	return out, failed, nil
+}
+
+// mergeBatchResults recoloca os resultados do repositório (sobre o lote sem as operações recusadas)
+// nas posições originais, junto com as recusadas.
+func mergeBatchResults(total int, results, failed []domain.CatBatchResult) []domain.CatBatchResult {
+	merged := make([]domain.CatBatchResult, 0, total)
+	for _, f := range failed {
+		for len(merged) < f.Index {
+			res := results[0]
+			results = results[1:]
+			res.Index = len(merged)
+			merged = append(merged, res)
+		}
+		merged = append(merged, f)
+	}
+	for _, res := range results {
+		res.Index = len(merged)
+		merged = append(merged, res)
+	}
+	return merged
 }
-*/
 
 /*
 	O defer cancel() serve para garantir que a função cancel() do contexto seja chamada ao final da execução do método, liberando recursos e evitando vazamentos de memória.
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/worker"
+)
+
+// fakeCatRepo cria os gatos do lote em memória e conta as miniaturas registradas.
+type fakeCatRepo struct {
+	CatRepository
+	thumbs atomic.Int64
+
+	mu      sync.Mutex
+	cats    []int64
+	thumbOf map[int64]int // miniaturas gravadas por gato (AddThumbnail não repete)
+}
+
+func (r *fakeCatRepo) Batch(ctx context.Context, ops []domain.CatBatchOp, atomic bool) ([]domain.CatBatchResult, error) {
+	results := make([]domain.CatBatchResult, len(ops))
+	for i, op := range ops {
+		cat := domain.Cat{ID: int64(i + 1), Name: op.Cat.Name}
+		r.mu.Lock()
+		r.cats = append(r.cats, cat.ID)
+		r.mu.Unlock()
+		results[i] = domain.CatBatchResult{Index: i, Op: op.Op, Cat: &cat}
+	}
+	return results, nil
+}
+
+func (r *fakeCatRepo) AddThumbnail(ctx context.Context, catID int64, path string) (domain.CatThumbnail, error) {
+	r.thumbs.Add(1)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.thumbOf == nil {
+		r.thumbOf = map[int64]int{}
+	}
+	r.thumbOf[catID] = 1
+	return domain.CatThumbnail{ID: catID, Path: path}, nil
+}
+
+func (r *fakeCatRepo) CatsWithoutThumbnail(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []int64
+	for _, id := range r.cats {
+		if r.thumbOf[id] == 0 && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func TestBatchDoesNotWaitForThumbnails(t *testing.T) {
+	wp := worker.NewPool(1) // fila de 4: um lote grande enche a fila na hora
+	wp.Start()
+	defer wp.Shutdown()
+	repo := &fakeCatRepo{}
+	svc := NewCatService(repo, wp, time.Second, 1000, nil, domain.BreedPolicyFlag, nil)
+
+	ops := make([]domain.CatBatchOp, 500)
+	for i := range ops {
+		ops[i] = domain.CatBatchOp{Op: domain.BatchOpCreate, Cat: &domain.CatCreate{Name: "Mingau"}}
+	}
+	start := time.Now()
+	results, err := svc.Batch(context.Background(), domain.CatBatchRequest{Operations: ops})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(ops) {
+		t.Fatalf("got %d results", len(results))
+	}
+	// cada miniatura leva 200ms: esperar por vagas na fila levaria 100s
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Batch took %v", elapsed)
+	}
+}
+
+// Com a fila cheia nenhuma miniatura se perde: a varredura agenda as que ficaram de fora.
+func TestThumbnailsSurviveFullQueue(t *testing.T) {
+	wp := worker.NewPoolQueue(1, 2)
+	wp.Start()
+	defer wp.Shutdown()
+	release := make(chan struct{})
+	busy := make(chan struct{})
+	_ = wp.Submit(func() error { close(busy); <-release; return nil })
+	<-busy // o worker está ocupado: só cabem 2 miniaturas na fila
+
+	repo := &fakeCatRepo{}
+	svc := NewCatService(repo, wp, time.Second, 1000, nil, domain.BreedPolicyFlag, nil)
+	ops := make([]domain.CatBatchOp, 5)
+	for i := range ops {
+		ops[i] = domain.CatBatchOp{Op: domain.BatchOpCreate, Cat: &domain.CatCreate{Name: "Mingau"}}
+	}
+	if _, err := svc.Batch(context.Background(), domain.CatBatchRequest{Operations: ops}); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := svc.SweepThumbnails(context.Background()); n != 0 {
+		t.Fatalf("sweep queued %d with the queue full", n)
+	}
+	close(release)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		ids, _ := repo.CatsWithoutThumbnail(context.Background(), time.Now(), 100)
+		if len(ids) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cats still without thumbnail: %v", ids)
+		}
+		_, _ = svc.SweepThumbnails(context.Background())
+		time.Sleep(50 * time.Millisecond)
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.thumbOf) != len(ops) {
+		t.Errorf("thumbnails for %d cats, want %d", len(repo.thumbOf), len(ops))
+	}
+}
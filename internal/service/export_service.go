@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dya-andrade/cat-api/internal/blob"
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/exporter"
+)
+
+var ErrInvalidExport = errors.New("invalid export request")
+
+// CatStreamer percorre os gatos sem carregar tudo em memória (ex: cursor do Postgres).
+type CatStreamer interface {
+	Stream(ctx context.Context, f domain.CatFilter, fn func(domain.Cat) error) error
+}
+
+// ExportService exporta gatos em CSV, NDJSON ou XLSX.
+type ExportService interface {
+	// Validate confere formato e colunas antes de começar a escrever a resposta.
+	Validate(in domain.ExportRequest) error
+	// Stream escreve a exportação diretamente em w, linha a linha.
+	// flush (opcional) é chamado periodicamente para enviar o que já foi escrito.
+	Stream(ctx context.Context, in domain.ExportRequest, w io.Writer, flush func()) error
+	// Start agenda a exportação como job; o arquivo fica no blob store.
+	Start(ctx context.Context, in domain.ExportRequest) (domain.Job, error)
+}
+
+// exportFlushEvery define de quantas em quantas linhas a resposta é enviada ao cliente.
+const exportFlushEvery = 500
+
+type exportService struct {
+	repo  CatStreamer
+	jobs  JobService
+	blobs blob.Store
+}
+
+func NewExportService(repo CatStreamer, jobs JobService, blobs blob.Store) ExportService {
+	return &exportService{repo: repo, jobs: jobs, blobs: blobs}
+}
+
+func (s *exportService) Validate(in domain.ExportRequest) error {
+	if _, err := exporter.NewWriter(in.Format, io.Discard, in.Columns); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	return nil
+}
+
+func (s *exportService) Stream(ctx context.Context, in domain.ExportRequest, w io.Writer, flush func()) error {
+	_, err := s.write(ctx, in, w, func(int) {
+		if flush != nil {
+			flush()
+		}
+	})
+	return err
+}
+
+// write faz a exportação em w e devolve quantos gatos foram escritos.
+// every é chamado a cada exportFlushEvery linhas com o total até o momento.
+func (s *exportService) write(ctx context.Context, in domain.ExportRequest, w io.Writer, every func(n int)) (int, error) {
+	ew, err := exporter.NewWriter(in.Format, w, in.Columns)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+	n := 0
+	err = s.repo.Stream(ctx, in.Filter, func(c domain.Cat) error {
+		if err := ew.Write(c); err != nil {
+			return err
+		}
+		n++
+		if n%exportFlushEvery == 0 {
+			every(n)
+		}
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, ew.Close()
+}
+
+func (s *exportService) Start(ctx context.Context, in domain.ExportRequest) (domain.Job, error) {
+	if err := s.Validate(in); err != nil {
+		return domain.Job{}, err
+	}
+	params := map[string]any{"format": in.Format}
+	if len(in.Columns) > 0 {
+		params["columns"] = in.Columns
+	}
+	return s.jobs.Submit(ctx, "cats.export", params, func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error) {
+		res := JobResult{
+			ResultKey:  fmt.Sprintf("exports/%d/cats.%s", job.ID, in.Format),
+			ResultType: exporter.ContentType(in.Format),
+		}
+		out, err := s.blobs.Create(ctx, res.ResultKey)
+		if err != nil {
+			return JobResult{}, err
+		}
+		n, err := s.write(ctx, in, out, func(n int) {
+			report(domain.JobProgress{Processed: n, Succeeded: n})
+		})
+		res.Progress = domain.JobProgress{Processed: n, Succeeded: n}
+		if err != nil {
+			_ = out.Close()
+			_ = s.blobs.Delete(context.Background(), res.ResultKey)
+			return JobResult{Progress: res.Progress}, err
+		}
+		return res, out.Close()
+	})
+}
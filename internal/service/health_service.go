@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrHealthRecordNotFound = domain.ErrHealthRecordNotFound
+	ErrHealthRecordExists   = domain.ErrHealthRecordExists
+	ErrHealthDates          = domain.ErrHealthDates
+)
+
+// JobKindVaccinationsDue é o tipo do job que recalcula o relatório de vacinas a vencer.
+const JobKindVaccinationsDue = "reports.vaccinations_due"
+
+// HealthRepository descreve a persistência do prontuário dos gatos.
+type HealthRepository interface {
+	Vaccinations(ctx context.Context, catID int64) ([]domain.Vaccination, error)
+	AddVaccination(ctx context.Context, catID int64, in domain.VaccinationCreate) (domain.Vaccination, error)
+	DeleteVaccination(ctx context.Context, catID, id int64) error
+	VetVisits(ctx context.Context, catID int64) ([]domain.VetVisit, error)
+	AddVetVisit(ctx context.Context, catID int64, in domain.VetVisitCreate) (domain.VetVisit, error)
+	DeleteVetVisit(ctx context.Context, catID, id int64) error
+	Medications(ctx context.Context, catID int64, activeOn *domain.Date) ([]domain.Medication, error)
+	AddMedication(ctx context.Context, catID int64, in domain.MedicationCreate) (domain.Medication, error)
+	DeleteMedication(ctx context.Context, catID, id int64) error
+	Allergies(ctx context.Context, catID int64) ([]domain.Allergy, error)
+	AddAllergy(ctx context.Context, catID int64, in domain.AllergyCreate) (domain.Allergy, error)
+	DeleteAllergy(ctx context.Context, catID, id int64) error
+	Neutering(ctx context.Context, catID int64) (domain.Neutering, error)
+	SetNeutering(ctx context.Context, catID int64, in domain.NeuteringUpdate) (domain.Neutering, error)
+	Summary(ctx context.Context, catID int64, today domain.Date) (domain.HealthSummary, error)
+
+	RefreshVaccinationsDue(ctx context.Context, today domain.Date, windowDays int) (int, error)
+	VaccinationsDue(ctx context.Context, status *string) (domain.VaccinationsDueReport, error)
+}
+
+// HealthService expõe o prontuário dos gatos e o relatório de vacinas a vencer.
+type HealthService interface {
+	Vaccinations(ctx context.Context, catID int64) ([]domain.Vaccination, error)                              // Doses aplicadas
+	AddVaccination(ctx context.Context, catID int64, in domain.VaccinationCreate) (domain.Vaccination, error) // Registra uma dose
+	DeleteVaccination(ctx context.Context, catID, id int64) error                                             // Remove uma dose
+	VetVisits(ctx context.Context, catID int64) ([]domain.VetVisit, error)                                    // Consultas
+	AddVetVisit(ctx context.Context, catID int64, in domain.VetVisitCreate) (domain.VetVisit, error)          // Registra uma consulta
+	DeleteVetVisit(ctx context.Context, catID, id int64) error                                                // Remove uma consulta
+	Medications(ctx context.Context, catID int64, activeOnly bool) ([]domain.Medication, error)               // Tratamentos
+	AddMedication(ctx context.Context, catID int64, in domain.MedicationCreate) (domain.Medication, error)    // Registra um tratamento
+	DeleteMedication(ctx context.Context, catID, id int64) error                                              // Remove um tratamento
+	Allergies(ctx context.Context, catID int64) ([]domain.Allergy, error)                                     // Alergias
+	AddAllergy(ctx context.Context, catID int64, in domain.AllergyCreate) (domain.Allergy, error)             // Registra uma alergia
+	DeleteAllergy(ctx context.Context, catID, id int64) error                                                 // Remove uma alergia
+	Neutering(ctx context.Context, catID int64) (domain.Neutering, error)                                     // Situação de castração
+	SetNeutering(ctx context.Context, catID int64, in domain.NeuteringUpdate) (domain.Neutering, error)       // Informa a castração
+	Summary(ctx context.Context, catID int64) (domain.HealthSummary, error)                                   // Resumo do prontuário
+
+	VaccinationsDue(ctx context.Context, status *string) (domain.VaccinationsDueReport, error) // Último relatório calculado
+	ScheduleVaccinationsDue(ctx context.Context) (domain.Job, error)                           // Agenda o recálculo do relatório
+}
+
+type healthService struct {
+	repo       HealthRepository
+	jobs       JobService
+	requestTO  time.Duration
+	windowDays int // janela do relatório: reforços que vencem em até N dias
+}
+
+// NewHealthService cria o serviço do prontuário.
+// windowDays define quantos dias à frente o relatório de vacinas considera "a vencer".
+func NewHealthService(repo HealthRepository, jobs JobService, requestTimeout time.Duration, windowDays int) HealthService {
+	return &healthService{repo: repo, jobs: jobs, requestTO: requestTimeout, windowDays: windowDays}
+}
+
+func (s *healthService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+/*** vacinas ***/
+
+func (s *healthService) Vaccinations(ctx context.Context, catID int64) ([]domain.Vaccination, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Vaccinations(ctx, catID)
+}
+
+// AddVaccination registra uma dose; o reforço não pode ser anterior à aplicação.
+func (s *healthService) AddVaccination(ctx context.Context, catID int64, in domain.VaccinationCreate) (domain.Vaccination, error) {
+	if in.NextDueOn != nil && in.NextDueOn.Before(*in.AdministeredOn) {
+		return domain.Vaccination{}, fmt.Errorf("%w: next_due_on antes de administered_on", ErrHealthDates)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.AddVaccination(ctx, catID, in)
+}
+
+func (s *healthService) DeleteVaccination(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.DeleteVaccination(ctx, catID, id)
+}
+
+/*** consultas ***/
+
+func (s *healthService) VetVisits(ctx context.Context, catID int64) ([]domain.VetVisit, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.VetVisits(ctx, catID)
+}
+
+func (s *healthService) AddVetVisit(ctx context.Context, catID int64, in domain.VetVisitCreate) (domain.VetVisit, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.AddVetVisit(ctx, catID, in)
+}
+
+func (s *healthService) DeleteVetVisit(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.DeleteVetVisit(ctx, catID, id)
+}
+
+/*** medicamentos ***/
+
+// Medications lista os tratamentos; activeOnly restringe aos que estão em curso hoje.
+func (s *healthService) Medications(ctx context.Context, catID int64, activeOnly bool) ([]domain.Medication, error) {
+	var activeOn *domain.Date
+	if activeOnly {
+		today := domain.Today()
+		activeOn = &today
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Medications(ctx, catID, activeOn)
+}
+
+// AddMedication registra um tratamento; o fim não pode ser anterior ao início.
+func (s *healthService) AddMedication(ctx context.Context, catID int64, in domain.MedicationCreate) (domain.Medication, error) {
+	if in.EndDate != nil && in.EndDate.Before(*in.StartDate) {
+		return domain.Medication{}, fmt.Errorf("%w: end_date antes de start_date", ErrHealthDates)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.AddMedication(ctx, catID, in)
+}
+
+func (s *healthService) DeleteMedication(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.DeleteMedication(ctx, catID, id)
+}
+
+/*** alergias ***/
+
+func (s *healthService) Allergies(ctx context.Context, catID int64) ([]domain.Allergy, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Allergies(ctx, catID)
+}
+
+func (s *healthService) AddAllergy(ctx context.Context, catID int64, in domain.AllergyCreate) (domain.Allergy, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.AddAllergy(ctx, catID, in)
+}
+
+func (s *healthService) DeleteAllergy(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.DeleteAllergy(ctx, catID, id)
+}
+
+/*** castração e resumo ***/
+
+func (s *healthService) Neutering(ctx context.Context, catID int64) (domain.Neutering, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Neutering(ctx, catID)
+}
+
+// SetNeutering informa a castração; neutered_on só faz sentido para gatos castrados e não pode estar no futuro.
+func (s *healthService) SetNeutering(ctx context.Context, catID int64, in domain.NeuteringUpdate) (domain.Neutering, error) {
+	if in.NeuteredOn != nil {
+		if !*in.Neutered {
+			return domain.Neutering{}, fmt.Errorf("%w: neutered_on informado para gato não castrado", ErrHealthDates)
+		}
+		if in.NeuteredOn.After(domain.Today()) {
+			return domain.Neutering{}, fmt.Errorf("%w: neutered_on no futuro", ErrHealthDates)
+		}
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.SetNeutering(ctx, catID, in)
+}
+
+func (s *healthService) Summary(ctx context.Context, catID int64) (domain.HealthSummary, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Summary(ctx, catID, domain.Today())
+}
+
+/*** relatório de vacinas a vencer ***/
+
+func (s *healthService) VaccinationsDue(ctx context.Context, status *string) (domain.VaccinationsDueReport, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.VaccinationsDue(ctx, status)
+}
+
+// ScheduleVaccinationsDue agenda o recálculo do relatório como job no worker pool.
+// É chamado periodicamente pelo agendador (worker.Every) e pode ser disparado pela API.
+func (s *healthService) ScheduleVaccinationsDue(ctx context.Context) (domain.Job, error) {
+	params := map[string]any{"window_days": s.windowDays}
+	return s.jobs.Submit(ctx, JobKindVaccinationsDue, params, func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error) {
+		n, err := s.repo.RefreshVaccinationsDue(ctx, domain.Today(), s.windowDays)
+		if err != nil {
+			return JobResult{}, err
+		}
+		return JobResult{Progress: domain.JobProgress{Processed: n, Succeeded: n}}, nil
+	})
+}
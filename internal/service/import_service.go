@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/dya-andrade/cat-api/internal/blob"
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/importer"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported import format")
+
+// importChunk é quantas linhas válidas são gravadas por lote (uma transação cada).
+const importChunk = 200
+
+// ImportService importa gatos a partir de arquivos CSV/NDJSON em background.
+type ImportService interface {
+	// Start guarda o arquivo no blob store e agenda o job de importação.
+	// O job valida cada linha com as regras de domain.CatCreate e gera um relatório CSV.
+	Start(ctx context.Context, in domain.ImportRequest, body io.Reader) (domain.Job, error)
+}
+
+type importService struct {
+	cats      CatService
+	jobs      JobService
+	blobs     blob.Store
+	validator *validator.Validate
+}
+
+func NewImportService(cats CatService, jobs JobService, blobs blob.Store) ImportService {
+	return &importService{cats: cats, jobs: jobs, blobs: blobs, validator: newValidator()}
+}
+
+func (s *importService) Start(ctx context.Context, in domain.ImportRequest, body io.Reader) (domain.Job, error) {
+	if in.Format != importer.FormatCSV && in.Format != importer.FormatNDJSON {
+		return domain.Job{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, in.Format)
+	}
+
+	// O corpo da requisição só existe enquanto o handler roda: salvamos antes de agendar
+	inputKey := fmt.Sprintf("imports/uploads/%d.%s", time.Now().UnixNano(), in.Format)
+	if _, err := blob.Put(ctx, s.blobs, inputKey, body); err != nil {
+		return domain.Job{}, err
+	}
+
+	params := map[string]any{"format": in.Format, "dry_run": in.DryRun}
+	if len(in.Mapping) > 0 {
+		params["mapping"] = in.Mapping
+	}
+	return s.jobs.Submit(ctx, "cats.import", params, s.run(in, inputKey))
+}
+
+// run devolve a função do job: lê o arquivo, valida, grava em lotes e escreve o relatório.
+func (s *importService) run(in domain.ImportRequest, inputKey string) JobFunc {
+	return func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error) {
+		defer s.blobs.Delete(context.Background(), inputKey) // o upload não é mais necessário
+
+		res := JobResult{
+			ResultKey:  fmt.Sprintf("imports/%d/report.csv", job.ID),
+			ResultType: "text/csv; charset=utf-8",
+		}
+
+		src, err := s.blobs.Open(ctx, inputKey)
+		if err != nil {
+			return JobResult{}, err
+		}
+		defer src.Close()
+
+		rows, err := importer.NewReader(in.Format, src, in.Mapping)
+		if err != nil {
+			return JobResult{}, err
+		}
+
+		out, err := s.blobs.Create(ctx, res.ResultKey)
+		if err != nil {
+			return JobResult{}, err
+		}
+		rep := csv.NewWriter(out)
+		_ = rep.Write([]string{"line", "status", "cat_id", "name", "reasons", "warnings"})
+		for _, w := range rows.Warnings() { // colunas ignoradas, uma vez para o arquivo (linha 1 = cabeçalho)
+			_ = rep.Write([]string{"1", "warning", "", "", "", w})
+		}
+
+		var pending []importer.Row
+		flush := func() error {
+			if len(pending) == 0 {
+				return nil
+			}
+			err := s.persist(ctx, in.DryRun, pending, rep, &res.Progress)
+			pending = pending[:0]
+			report(res.Progress)
+			return err
+		}
+
+		for {
+			row, err := rows.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				_ = out.Close()
+				return res, err
+			}
+			res.Progress.Processed++
+			if row.Err == nil {
+				if verr := s.validator.Struct(row.Cat); verr != nil {
+					row.Err = errors.New(describeValidation(verr))
+				} else if _, _, berr := row.Cat.ResolveBirth(domain.Today()); berr != nil {
+					row.Err = berr // também no dry_run, que não chega ao banco
+				} else if cerr := row.Cat.CheckCoat(); cerr != nil {
+					row.Err = cerr
+				} else if row.Cat.Microchip != nil {
+					if _, merr := domain.NormalizeMicrochip(*row.Cat.Microchip); merr != nil {
+						row.Err = merr
+					}
+				}
+			}
+			if row.Err != nil {
+				res.Progress.Failed++
+				_ = rep.Write([]string{strconv.Itoa(row.Line), "rejected", "", row.Cat.Name, row.Err.Error(), warnings(row)})
+				continue
+			}
+			pending = append(pending, row)
+			if len(pending) == importChunk {
+				if err := flush(); err != nil {
+					_ = out.Close()
+					return res, err
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			_ = out.Close()
+			return res, err
+		}
+
+		rep.Flush()
+		if err := rep.Error(); err != nil {
+			_ = out.Close()
+			return res, err
+		}
+		return res, out.Close()
+	}
+}
+
+// persist grava um lote de linhas válidas e registra o resultado de cada uma no relatório.
+// Tenta primeiro em modo atômico (uma transação); se alguma linha falhar no banco,
+// repete o lote em modo parcial para isolar as linhas problemáticas.
+func (s *importService) persist(ctx context.Context, dryRun bool, rows []importer.Row, rep *csv.Writer, p *domain.JobProgress) error {
+	if dryRun {
+		for _, row := range rows {
+			p.Succeeded++
+			_ = rep.Write([]string{strconv.Itoa(row.Line), "valid", "", row.Cat.Name, "", warnings(row)})
+		}
+		return nil
+	}
+
+	req := domain.CatBatchRequest{Mode: domain.BatchAtomic, Operations: make([]domain.CatBatchOp, len(rows))}
+	for i := range rows {
+		req.Operations[i] = domain.CatBatchOp{Op: domain.BatchOpCreate, Cat: &rows[i].Cat}
+	}
+	results, err := s.cats.Batch(ctx, req)
+	var batchErr *domain.BatchError
+	if errors.As(err, &batchErr) {
+		req.Mode = domain.BatchPartial
+		results, err = s.cats.Batch(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, r := range results {
+		line := strconv.Itoa(rows[i].Line)
+		if r.Err != nil {
+			p.Failed++
+			_ = rep.Write([]string{line, "rejected", "", rows[i].Cat.Name, r.Err.Error(), warnings(rows[i])})
+			continue
+		}
+		p.Succeeded++
+		_ = rep.Write([]string{line, "accepted", strconv.FormatInt(r.Cat.ID, 10), r.Cat.Name, "", warnings(rows[i])})
+	}
+	return nil
+}
+
+// warnings junta os avisos da linha para a coluna warnings do relatório.
+func warnings(row importer.Row) string {
+	return strings.Join(row.Warnings, "; ")
+}
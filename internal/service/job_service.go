@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/blob"
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/worker"
+)
+
+var ErrJobNotFound = domain.ErrJobNotFound
+
+// JobRepository descreve a persistência do estado dos jobs.
+type JobRepository interface {
+	Create(ctx context.Context, kind string, params map[string]any) (domain.Job, error)
+	GetByID(ctx context.Context, id int64) (domain.Job, error)
+	Start(ctx context.Context, id int64) error
+	Progress(ctx context.Context, id int64, p domain.JobProgress) error
+	Finish(ctx context.Context, id int64, p domain.JobProgress, resultKey, resultType string, jobErr error) error
+}
+
+// JobService expõe o acompanhamento dos jobs em background.
+type JobService interface {
+	GetByID(ctx context.Context, id int64) (domain.Job, error)                                       // Estado e progresso do job
+	OpenResult(ctx context.Context, id int64) (domain.Job, io.ReadCloser, error)                     // Abre o arquivo de resultado
+	Submit(ctx context.Context, kind string, params map[string]any, run JobFunc) (domain.Job, error) // Cria e agenda um job
+}
+
+// JobFunc é o trabalho de um job. report atualiza o progresso (persistido no banco).
+// Retorna a chave e o Content-Type do arquivo de resultado no blob store (podem ser vazios).
+type JobFunc func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error)
+
+// JobResult é o que um job produz ao terminar.
+type JobResult struct {
+	Progress   domain.JobProgress
+	ResultKey  string
+	ResultType string
+}
+
+type jobService struct {
+	repo    JobRepository
+	blobs   blob.Store
+	wp      *worker.Pool
+	timeout time.Duration // tempo máximo de execução de cada job
+}
+
+// NewJobService cria o serviço de jobs.
+// timeout limita a execução de cada job (os jobs não usam o timeout das requisições HTTP).
+func NewJobService(repo JobRepository, blobs blob.Store, wp *worker.Pool, timeout time.Duration) JobService {
+	return &jobService{repo: repo, blobs: blobs, wp: wp, timeout: timeout}
+}
+
+func (s *jobService) GetByID(ctx context.Context, id int64) (domain.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// OpenResult abre o arquivo de resultado de um job concluído.
+// Retorna ErrJobNotFound se o job não existir ou ainda não tiver resultado.
+func (s *jobService) OpenResult(ctx context.Context, id int64) (domain.Job, io.ReadCloser, error) {
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Job{}, nil, err
+	}
+	if job.ResultKey == nil {
+		return job, nil, ErrJobNotFound
+	}
+	rc, err := s.blobs.Open(ctx, *job.ResultKey)
+	if err != nil {
+		return job, nil, err
+	}
+	return job, rc, nil
+}
+
+// Submit cria o job (status queued) e agenda sua execução no worker pool.
+// ctx é usado para registrar o job no banco; a execução usa um contexto próprio,
+// pois continua depois que a requisição HTTP termina, mas mantém os valores de ctx
+// (tenant, quem fez a requisição para a auditoria).
+func (s *jobService) Submit(ctx context.Context, kind string, params map[string]any, run JobFunc) (domain.Job, error) {
+	job, err := s.repo.Create(ctx, kind, params)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	err = s.wp.Submit(func() error {
+		return s.execute(context.WithoutCancel(ctx), job, run)
+	})
+	return job, err
+}
+
+// execute roda o job marcando início, progresso e fim no banco.
+// parent só fornece os valores do contexto (não tem prazo nem cancelamento).
+func (s *jobService) execute(parent context.Context, job domain.Job, run JobFunc) error {
+	ctx, cancel := context.WithTimeout(parent, s.timeout)
+	defer cancel()
+
+	if err := s.repo.Start(ctx, job.ID); err != nil {
+		log.Printf("job %d: start: %v", job.ID, err)
+	}
+	report := func(p domain.JobProgress) {
+		if err := s.repo.Progress(ctx, job.ID, p); err != nil {
+			log.Printf("job %d: progress: %v", job.ID, err)
+		}
+	}
+
+	res, runErr := runRecovered(ctx, job, run, report)
+	if runErr != nil {
+		log.Printf("job %d (%s) failed: %v", job.ID, job.Kind, runErr)
+	}
+	// Usa um contexto novo: se o job estourou o timeout, ainda precisamos gravar o fim
+	finishCtx, cancelFinish := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFinish()
+	if err := s.repo.Finish(finishCtx, job.ID, res.Progress, res.ResultKey, res.ResultType, runErr); err != nil {
+		log.Printf("job %d: finish: %v", job.ID, err)
+	}
+	return runErr
+}
+
+// runRecovered roda o job transformando um panic em erro: o job é marcado como falho
+// e o processo continua (um panic em um worker do pool derrubaria a API inteira).
+func runRecovered(ctx context.Context, job domain.Job, run JobFunc, report func(domain.JobProgress)) (res JobResult, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("job %d (%s) panic: %v\n%s", job.ID, job.Kind, p, debug.Stack())
+			err = fmt.Errorf("internal error: %v", p)
+		}
+	}()
+	return run(ctx, job, report)
+}
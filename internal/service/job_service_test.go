@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/worker"
+)
+
+// fakeJobRepo guarda o resultado de Finish e avisa em done.
+type fakeJobRepo struct {
+	mu     sync.Mutex
+	jobErr error
+	done   chan struct{}
+}
+
+func (r *fakeJobRepo) Create(ctx context.Context, kind string, params map[string]any) (domain.Job, error) {
+	return domain.Job{ID: 1, Kind: kind}, nil
+}
+func (r *fakeJobRepo) GetByID(ctx context.Context, id int64) (domain.Job, error) {
+	return domain.Job{}, ErrJobNotFound
+}
+func (r *fakeJobRepo) Start(ctx context.Context, id int64) error { return nil }
+func (r *fakeJobRepo) Progress(ctx context.Context, id int64, p domain.JobProgress) error {
+	return nil
+}
+func (r *fakeJobRepo) Finish(ctx context.Context, id int64, p domain.JobProgress, key, typ string, jobErr error) error {
+	r.mu.Lock()
+	r.jobErr = jobErr
+	r.mu.Unlock()
+	close(r.done)
+	return nil
+}
+
+func TestJobPanicMarksJobFailed(t *testing.T) {
+	wp := worker.NewPool(1)
+	wp.Start()
+	defer wp.Shutdown()
+	repo := &fakeJobRepo{done: make(chan struct{})}
+	svc := NewJobService(repo, nil, wp, time.Second)
+
+	_, err := svc.Submit(context.Background(), "import", nil, func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error) {
+		var record []string
+		_ = record[0] // panic: index out of range
+		return JobResult{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-repo.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job did not finish")
+	}
+	if repo.jobErr == nil {
+		t.Fatal("job finished without error")
+	}
+
+	// o pool continua atendendo depois do panic
+	ran := make(chan struct{})
+	_ = wp.Submit(func() error { close(ran); return nil })
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pool stopped after panic")
+	}
+}
+
+func TestJobErrorIsRecorded(t *testing.T) {
+	wp := worker.NewPool(1)
+	wp.Start()
+	defer wp.Shutdown()
+	repo := &fakeJobRepo{done: make(chan struct{})}
+	svc := NewJobService(repo, nil, wp, time.Second)
+	boom := errors.New("boom")
+	_, _ = svc.Submit(context.Background(), "export", nil, func(ctx context.Context, job domain.Job, report func(domain.JobProgress)) (JobResult, error) {
+		return JobResult{}, boom
+	})
+	<-repo.done
+	if !errors.Is(repo.jobErr, boom) {
+		t.Fatalf("jobErr = %v", repo.jobErr)
+	}
+}
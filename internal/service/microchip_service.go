@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrInvalidMicrochip  = domain.ErrInvalidMicrochip
+	ErrMicrochipTaken    = domain.ErrMicrochipTaken // *domain.MicrochipConflictError traz o gato que já usa o microchip
+	ErrMicrochipNotFound = domain.ErrMicrochipNotFound
+)
+
+// MicrochipRepository descreve a consulta por microchip e o registro das consultas.
+type MicrochipRepository interface {
+	Lookup(ctx context.Context, chip string, req domain.Requester) (domain.Cat, error)
+	RecordInvalid(ctx context.Context, raw string, req domain.Requester) error
+	Lookups(ctx context.Context, f domain.MicrochipLookupFilter, limit int, cursor *time.Time) ([]domain.MicrochipLookup, *time.Time, error)
+}
+
+// MicrochipService expõe a busca de gatos perdidos pelo microchip e a auditoria dessas buscas.
+type MicrochipService interface {
+	Lookup(ctx context.Context, microchip string, req domain.Requester) (domain.Cat, error)                                                  // Busca (e registra a busca)
+	Lookups(ctx context.Context, f domain.MicrochipLookupFilter, limit int, cursor *time.Time) ([]domain.MicrochipLookup, *time.Time, error) // Registro das buscas
+}
+
+type microchipService struct {
+	repo      MicrochipRepository
+	requestTO time.Duration
+}
+
+func NewMicrochipService(repo MicrochipRepository, requestTimeout time.Duration) MicrochipService {
+	return &microchipService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *microchipService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+// maxLoggedMicrochip limita o texto guardado de uma consulta inválida.
+const maxLoggedMicrochip = 64
+
+// Lookup normaliza o número e busca o gato. Toda consulta é registrada, inclusive as inválidas.
+func (s *microchipService) Lookup(ctx context.Context, microchip string, req domain.Requester) (domain.Cat, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	chip, err := domain.NormalizeMicrochip(microchip)
+	if err != nil {
+		raw := []rune(microchip)
+		if len(raw) > maxLoggedMicrochip {
+			raw = raw[:maxLoggedMicrochip]
+		}
+		if rerr := s.repo.RecordInvalid(ctx, string(raw), req); rerr != nil {
+			return domain.Cat{}, rerr // sem registro, sem resposta
+		}
+		return domain.Cat{}, err
+	}
+	return s.repo.Lookup(ctx, chip, req)
+}
+
+func (s *microchipService) Lookups(ctx context.Context, f domain.MicrochipLookupFilter, limit int, cursor *time.Time) ([]domain.MicrochipLookup, *time.Time, error) {
+	if f.Microchip != nil {
+		if chip, err := domain.NormalizeMicrochip(*f.Microchip); err == nil {
+			f.Microchip = &chip
+		}
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Lookups(ctx, f, limit, cursor)
+}
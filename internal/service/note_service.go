@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrNoteNotFound          = domain.ErrNoteNotFound
+	ErrInvalidTimelineCursor = domain.ErrInvalidTimelineCursor
+)
+
+// NoteRepository descreve a persistência das notas e a leitura da linha do tempo.
+type NoteRepository interface {
+	List(ctx context.Context, catID int64) ([]domain.Note, error)
+	Create(ctx context.Context, catID int64, in domain.NoteCreate) (domain.Note, error)
+	Update(ctx context.Context, catID, id int64, in domain.NoteUpdate) (domain.Note, error)
+	Delete(ctx context.Context, catID, id int64) error
+	History(ctx context.Context, catID, id int64) ([]domain.NoteRevision, error)
+	Timeline(ctx context.Context, catID int64, kinds []string, limit int, cursor *domain.TimelineCursor) ([]domain.TimelineEvent, *domain.TimelineCursor, error)
+}
+
+// NoteService expõe as notas dos gatos e a linha do tempo de atividades.
+type NoteService interface {
+	List(ctx context.Context, catID int64) ([]domain.Note, error)                                                                                                // Notas do gato (fixadas primeiro)
+	Create(ctx context.Context, catID int64, in domain.NoteCreate) (domain.Note, error)                                                                          // Cria uma nota
+	Update(ctx context.Context, catID, id int64, in domain.NoteUpdate) (domain.Note, error)                                                                      // Edita ou fixa uma nota
+	Delete(ctx context.Context, catID, id int64) error                                                                                                           // Apaga uma nota
+	History(ctx context.Context, catID, id int64) ([]domain.NoteRevision, error)                                                                                 // Versões do texto de uma nota
+	Timeline(ctx context.Context, catID int64, kinds []string, limit int, cursor *domain.TimelineCursor) ([]domain.TimelineEvent, *domain.TimelineCursor, error) // Linha do tempo do gato
+}
+
+type noteService struct {
+	repo      NoteRepository
+	requestTO time.Duration
+}
+
+func NewNoteService(repo NoteRepository, requestTimeout time.Duration) NoteService {
+	return &noteService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *noteService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *noteService) List(ctx context.Context, catID int64) ([]domain.Note, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx, catID)
+}
+
+func (s *noteService) Create(ctx context.Context, catID int64, in domain.NoteCreate) (domain.Note, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Create(ctx, catID, in)
+}
+
+func (s *noteService) Update(ctx context.Context, catID, id int64, in domain.NoteUpdate) (domain.Note, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Update(ctx, catID, id, in)
+}
+
+func (s *noteService) Delete(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, catID, id)
+}
+
+func (s *noteService) History(ctx context.Context, catID, id int64) ([]domain.NoteRevision, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.History(ctx, catID, id)
+}
+
+func (s *noteService) Timeline(ctx context.Context, catID int64, kinds []string, limit int, cursor *domain.TimelineCursor) ([]domain.TimelineEvent, *domain.TimelineCursor, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Timeline(ctx, catID, kinds, limit, cursor)
+}
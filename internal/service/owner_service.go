@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrOwnerNotFound     = domain.ErrOwnerNotFound
+	ErrOwnerEmailTaken   = domain.ErrOwnerEmailTaken
+	ErrOwnerInUse        = domain.ErrOwnerInUse
+	ErrOwnershipNotFound = domain.ErrOwnershipNotFound
+	ErrOwnershipConflict = domain.ErrOwnershipConflict
+	ErrInvalidTransfer   = domain.ErrInvalidTransfer
+	ErrOwnershipDates    = domain.ErrOwnershipDates
+)
+
+// OwnerRepository descreve a persistência de tutores e dos vínculos com os gatos.
+type OwnerRepository interface {
+	Create(ctx context.Context, in domain.OwnerCreate) (domain.Owner, error)
+	GetByID(ctx context.Context, id int64) (domain.Owner, error)
+	List(ctx context.Context, f domain.OwnerFilter, limit int, cursor *time.Time) ([]domain.Owner, *time.Time, error)
+	Update(ctx context.Context, id int64, in domain.OwnerUpdate) (domain.Owner, error)
+	Delete(ctx context.Context, id int64) error
+
+	OwnersOf(ctx context.Context, catID int64, history bool) ([]domain.CatOwner, error)
+	CatsOf(ctx context.Context, ownerID int64, history bool) ([]domain.OwnedCat, error)
+	AddOwner(ctx context.Context, catID int64, in domain.OwnershipCreate, start domain.Date) (domain.Ownership, error)
+	EndOwnership(ctx context.Context, catID, ownerID int64, end domain.Date) (domain.Ownership, error)
+	Transfer(ctx context.Context, catID int64, in domain.TransferRequest, on domain.Date) (domain.Transfer, error)
+	Transfers(ctx context.Context, catID int64) ([]domain.Transfer, error)
+}
+
+// OwnerService expõe o cadastro de tutores e a tutela dos gatos.
+type OwnerService interface {
+	Create(ctx context.Context, in domain.OwnerCreate) (domain.Owner, error)                                          // Cria um tutor
+	GetByID(ctx context.Context, id int64) (domain.Owner, error)                                                      // Busca um tutor
+	List(ctx context.Context, f domain.OwnerFilter, limit int, cursor *time.Time) ([]domain.Owner, *time.Time, error) // Lista tutores
+	Update(ctx context.Context, id int64, in domain.OwnerUpdate) (domain.Owner, error)                                // Atualiza um tutor
+	Delete(ctx context.Context, id int64) error                                                                       // Remove um tutor sem histórico
+
+	OwnersOf(ctx context.Context, catID int64, history bool) ([]domain.CatOwner, error)                 // Tutores do gato
+	CatsOf(ctx context.Context, ownerID int64, history bool) ([]domain.OwnedCat, error)                 // Gatos do tutor
+	AddOwner(ctx context.Context, catID int64, in domain.OwnershipCreate) (domain.Ownership, error)     // Vincula um tutor ao gato
+	EndOwnership(ctx context.Context, catID, ownerID int64, end *domain.Date) (domain.Ownership, error) // Encerra um vínculo
+	Transfer(ctx context.Context, catID int64, in domain.TransferRequest) (domain.Transfer, error)      // Transfere a tutela principal
+	Transfers(ctx context.Context, catID int64) ([]domain.Transfer, error)                              // Histórico de transferências
+}
+
+type ownerService struct {
+	repo      OwnerRepository
+	requestTO time.Duration
+}
+
+// NewOwnerService cria o serviço de tutores com o timeout das requisições.
+func NewOwnerService(repo OwnerRepository, requestTimeout time.Duration) OwnerService {
+	return &ownerService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *ownerService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *ownerService) Create(ctx context.Context, in domain.OwnerCreate) (domain.Owner, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Create(ctx, in)
+}
+
+func (s *ownerService) GetByID(ctx context.Context, id int64) (domain.Owner, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ownerService) List(ctx context.Context, f domain.OwnerFilter, limit int, cursor *time.Time) ([]domain.Owner, *time.Time, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx, f, limit, cursor)
+}
+
+func (s *ownerService) Update(ctx context.Context, id int64, in domain.OwnerUpdate) (domain.Owner, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Update(ctx, id, in)
+}
+
+func (s *ownerService) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *ownerService) OwnersOf(ctx context.Context, catID int64, history bool) ([]domain.CatOwner, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.OwnersOf(ctx, catID, history)
+}
+
+func (s *ownerService) CatsOf(ctx context.Context, ownerID int64, history bool) ([]domain.OwnedCat, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.CatsOf(ctx, ownerID, history)
+}
+
+// AddOwner vincula um tutor ao gato; sem start_date o vínculo começa hoje.
+func (s *ownerService) AddOwner(ctx context.Context, catID int64, in domain.OwnershipCreate) (domain.Ownership, error) {
+	start := domain.Today()
+	if in.StartDate != nil {
+		start = *in.StartDate
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.AddOwner(ctx, catID, in, start)
+}
+
+// EndOwnership encerra o vínculo ativo do tutor com o gato (hoje, se end for nil).
+func (s *ownerService) EndOwnership(ctx context.Context, catID, ownerID int64, end *domain.Date) (domain.Ownership, error) {
+	on := domain.Today()
+	if end != nil {
+		on = *end
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.EndOwnership(ctx, catID, ownerID, on)
+}
+
+// Transfer passa a tutela principal para outro tutor (hoje, se a data não vier).
+// Transferências para datas futuras não são aceitas: o histórico registra fatos.
+func (s *ownerService) Transfer(ctx context.Context, catID int64, in domain.TransferRequest) (domain.Transfer, error) {
+	on := domain.Today()
+	if in.Date != nil {
+		if in.Date.After(on) {
+			return domain.Transfer{}, fmt.Errorf("%w: a data não pode estar no futuro", ErrInvalidTransfer)
+		}
+		on = *in.Date
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Transfer(ctx, catID, in, on)
+}
+
+func (s *ownerService) Transfers(ctx context.Context, catID int64) ([]domain.Transfer, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Transfers(ctx, catID)
+}
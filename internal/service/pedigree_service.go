@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrParentNotFound = domain.ErrParentNotFound
+	ErrLitterNotFound = domain.ErrLitterNotFound
+	ErrInvalidLineage = domain.ErrInvalidLineage
+	ErrLineageCycle   = domain.ErrLineageCycle
+)
+
+// PedigreeRepository descreve a leitura da genealogia e a persistência das ninhadas.
+type PedigreeRepository interface {
+	Ancestry(ctx context.Context, ids []int64, generations int) (domain.Ancestry, error)
+	Descendants(ctx context.Context, catID int64, generations int) ([]domain.Descendant, error)
+	CreateLitter(ctx context.Context, in domain.LitterCreate) (domain.Litter, error)
+	GetLitter(ctx context.Context, id int64) (domain.Litter, error)
+}
+
+// PedigreeService expõe a genealogia dos gatos, as ninhadas e o coeficiente de consanguinidade.
+type PedigreeService interface {
+	Pedigree(ctx context.Context, catID int64, generations int) (domain.Pedigree, error)           // Árvore de ancestrais e COI
+	Descendants(ctx context.Context, catID int64, generations int) ([]domain.Descendant, error)    // Filhos, netos...
+	MatingCOI(ctx context.Context, sireID, damID int64, generations int) (domain.MatingCOI, error) // COI previsto de um cruzamento
+	CreateLitter(ctx context.Context, in domain.LitterCreate) (domain.Litter, error)               // Registra uma ninhada
+	GetLitter(ctx context.Context, id int64, generations int) (domain.Litter, error)               // Ninhada com filhotes e COI
+}
+
+type pedigreeService struct {
+	repo      PedigreeRepository
+	requestTO time.Duration
+}
+
+func NewPedigreeService(repo PedigreeRepository, requestTimeout time.Duration) PedigreeService {
+	return &pedigreeService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *pedigreeService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+// generations aplica o padrão e o limite de gerações.
+func generations(n int) int {
+	if n <= 0 {
+		return domain.DefaultGenerations
+	}
+	return min(n, domain.MaxGenerations)
+}
+
+// Pedigree monta a árvore de ancestrais do gato; o COI considera os mesmos ancestrais.
+func (s *pedigreeService) Pedigree(ctx context.Context, catID int64, n int) (domain.Pedigree, error) {
+	n = generations(n)
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	ancestry, err := s.repo.Ancestry(ctx, []int64{catID}, n)
+	if err != nil {
+		return domain.Pedigree{}, err
+	}
+	if _, ok := ancestry[catID]; !ok {
+		return domain.Pedigree{}, ErrNotFound
+	}
+	return domain.Pedigree{Generations: n, COI: ancestry.COI(catID), Tree: ancestry.Tree(catID, n)}, nil
+}
+
+func (s *pedigreeService) Descendants(ctx context.Context, catID int64, n int) ([]domain.Descendant, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Descendants(ctx, catID, generations(n))
+}
+
+// MatingCOI prevê o COI dos filhotes de sire e dam: n gerações dos filhotes
+// são n-1 gerações dos pais.
+func (s *pedigreeService) MatingCOI(ctx context.Context, sireID, damID int64, n int) (domain.MatingCOI, error) {
+	if sireID == damID {
+		return domain.MatingCOI{}, fmt.Errorf("%w: o mesmo gato não pode ser pai e mãe", ErrInvalidLineage)
+	}
+	n = generations(n)
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	ancestry, err := s.repo.Ancestry(ctx, []int64{sireID, damID}, n-1)
+	if err != nil {
+		return domain.MatingCOI{}, err
+	}
+	if _, ok := ancestry[sireID]; !ok {
+		return domain.MatingCOI{}, fmt.Errorf("%w: sire_id", ErrParentNotFound)
+	}
+	if _, ok := ancestry[damID]; !ok {
+		return domain.MatingCOI{}, fmt.Errorf("%w: dam_id", ErrParentNotFound)
+	}
+	return domain.MatingCOI{SireID: sireID, DamID: damID, Generations: n, COI: ancestry.Kinship(&sireID, &damID)}, nil
+}
+
+func (s *pedigreeService) CreateLitter(ctx context.Context, in domain.LitterCreate) (domain.Litter, error) {
+	if in.SireID != nil && in.DamID != nil && *in.SireID == *in.DamID {
+		return domain.Litter{}, fmt.Errorf("%w: o mesmo gato não pode ser pai e mãe", ErrInvalidLineage)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	// o COI é calculado antes de gravar: a ninhada criada sempre volta completa
+	coi, err := s.litterCOI(ctx, in.SireID, in.DamID, domain.DefaultGenerations)
+	if err != nil {
+		return domain.Litter{}, err
+	}
+	l, err := s.repo.CreateLitter(ctx, in)
+	if err != nil {
+		return domain.Litter{}, err
+	}
+	l.COI = coi
+	return l, nil
+}
+
+// GetLitter devolve a ninhada com os filhotes e o COI deles (calculado sobre n gerações).
+func (s *pedigreeService) GetLitter(ctx context.Context, id int64, n int) (domain.Litter, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+
+	l, err := s.repo.GetLitter(ctx, id)
+	if err != nil {
+		return domain.Litter{}, err
+	}
+	l.COI, err = s.litterCOI(ctx, l.SireID, l.DamID, generations(n))
+	return l, err
+}
+
+// litterCOI calcula o COI dos filhotes de sire e dam; nil se algum dos dois for desconhecido.
+func (s *pedigreeService) litterCOI(ctx context.Context, sireID, damID *int64, n int) (*float64, error) {
+	if sireID == nil || damID == nil {
+		return nil, nil
+	}
+	ancestry, err := s.repo.Ancestry(ctx, []int64{*sireID, *damID}, n-1)
+	if err != nil {
+		return nil, err
+	}
+	coi := ancestry.Kinship(sireID, damID)
+	return &coi, nil
+}
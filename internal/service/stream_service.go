@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/outbox"
+)
+
+var ErrEventsMissed = domain.ErrEventsMissed
+
+// StreamRepository descreve o que o stream lê da outbox (storage.OutboxRepository).
+type StreamRepository interface {
+	Listen(ctx context.Context, channel string, ready func(), fn func(payload string)) error
+	Event(ctx context.Context, id int64) (domain.OutboxEvent, error)
+	DeliveredAfter(ctx context.Context, sink string, afterID int64, limit int) ([]domain.OutboxEvent, error)
+}
+
+// StreamService entrega os eventos dos gatos aos clientes do stream (GET /cats/stream).
+type StreamService interface {
+	Subscribe() (events <-chan domain.OutboxEvent, cancel func())                       // Eventos novos; o canal fecha se o cliente ficar para trás ou no desligamento
+	Replay(ctx context.Context, afterID int64, fn func(domain.OutboxEvent) error) error // Eventos publicados depois de afterID (ErrEventsMissed se ele já foi apagado)
+}
+
+/*
+StreamHub repassa os eventos da outbox aos clientes conectados nesta réplica.
+
+O relay da outbox publica cada evento no destino Notify (pg_notify no canal OUTBOX_CHANNEL).
+Run (agendado com worker.Every, que o chama de novo se a conexão cair) mantém um LISTEN nesse
+canal: cada aviso identifica um evento, que é lido da outbox e copiado para o canal de cada
+cliente. Um cliente que não consome os eventos a tempo tem o canal fechado em vez de segurar
+os outros; ele reconecta com o Last-Event-ID e recupera o que perdeu (Replay).
+
+A ordem do stream é a ordem em que o Notify recebeu os eventos, que o Replay reproduz a
+partir do registro de entregas da outbox.
+*/
+type StreamHub struct {
+	repo    StreamRepository
+	channel string // canal do destino Notify (OUTBOX_CHANNEL)
+	sink    string // nome do destino Notify no registro de entregas
+
+	mu     sync.Mutex
+	subs   map[chan domain.OutboxEvent]struct{}
+	closed bool
+
+	listened bool // só a goroutine de Run usa: o LISTEN já valeu antes (é uma reconexão)
+}
+
+const (
+	streamBuffer   = 256 // eventos guardados por cliente antes de desconectá-lo
+	streamPageSize = 500 // eventos lidos da outbox por vez no Replay
+)
+
+// NewStreamHub cria o hub que escuta o canal do destino Notify da outbox (channel) e retoma
+// pelas entregas registradas em nome dele (sink, ver outbox.Notify.Name).
+func NewStreamHub(repo StreamRepository, channel, sink string) *StreamHub {
+	return &StreamHub{repo: repo, channel: channel, sink: sink, subs: map[chan domain.OutboxEvent]struct{}{}}
+}
+
+// Subscribe registra um cliente. cancel remove o registro (pode ser chamado mais de uma vez).
+func (h *StreamHub) Subscribe() (<-chan domain.OutboxEvent, func()) {
+	ch := make(chan domain.OutboxEvent, streamBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subs[ch] = struct{}{}
+	return ch, func() { h.drop(ch) }
+}
+
+// drop remove e fecha o canal de um cliente.
+func (h *StreamHub) drop(ch chan domain.OutboxEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// Close encerra todos os clientes (registrado em http.Server.RegisterOnShutdown: as conexões
+// do stream não terminam sozinhas e segurariam o desligamento).
+func (h *StreamHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	h.dropAll()
+}
+
+// dropAll desconecta todos os clientes (com h.mu travado).
+func (h *StreamHub) dropAll() {
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// Replay chama fn, em ordem, para cada evento publicado no stream depois do evento afterID.
+// ErrEventsMissed se afterID já não está na outbox: não há como saber o que veio depois dele,
+// e o cliente precisa recarregar os gatos.
+func (h *StreamHub) Replay(ctx context.Context, afterID int64, fn func(domain.OutboxEvent) error) error {
+	if _, err := h.repo.Event(ctx, afterID); errors.Is(err, ErrNotFound) {
+		return ErrEventsMissed
+	} else if err != nil {
+		return err
+	}
+	for {
+		page, err := h.repo.DeliveredAfter(ctx, h.sink, afterID, streamPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range page {
+			if err := fn(e); err != nil {
+				return err
+			}
+			afterID = e.ID
+		}
+		if len(page) < streamPageSize {
+			return nil
+		}
+	}
+}
+
+// Run escuta os avisos do destino Notify até ctx ser cancelado ou a conexão cair.
+func (h *StreamHub) Run(ctx context.Context) {
+	err := h.repo.Listen(ctx, h.channel, h.ready, func(payload string) { h.fetch(ctx, payload) })
+	if err != nil && ctx.Err() == nil {
+		log.Printf("stream: listen: %v", err)
+	}
+}
+
+// ready roda quando o LISTEN começa a valer. Depois de uma reconexão, os avisos enviados enquanto a
+// conexão estava caída se perderam: os clientes são desconectados e, ao reconectar com o
+// Last-Event-ID, o Replay de cada um recupera o que faltou.
+func (h *StreamHub) ready() {
+	if h.listened {
+		h.mu.Lock()
+		h.dropAll()
+		h.mu.Unlock()
+	}
+	h.listened = true
+}
+
+// fetch lê o evento avisado e o repassa.
+func (h *StreamHub) fetch(ctx context.Context, payload string) {
+	var n outbox.NotifyEvent
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		log.Printf("stream: notification %q: %v", payload, err)
+		return
+	}
+	if n.Aggregate != "cat" {
+		return
+	}
+	e, err := h.repo.Event(ctx, n.ID)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) { // ErrNotFound: apagado da outbox antes de ser lido
+			log.Printf("stream: event %d: %v", n.ID, err)
+		}
+		return
+	}
+	h.publish(e)
+}
+
+// publish copia o evento para o canal de cada cliente; quem está com o canal cheio é desconectado.
+func (h *StreamHub) publish(e domain.OutboxEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/outbox"
+)
+
+// fakeStreamRepo guarda os eventos da outbox e a ordem em que o destino Notify os recebeu.
+type fakeStreamRepo struct {
+	events    map[int64]domain.OutboxEvent
+	delivered []int64  // ids na ordem das entregas ao Notify
+	notices   []string // payloads entregues por Listen antes de a conexão "cair"
+}
+
+func newFakeStreamRepo(delivered ...int64) *fakeStreamRepo {
+	r := &fakeStreamRepo{events: map[int64]domain.OutboxEvent{}, delivered: delivered}
+	for _, id := range delivered {
+		r.events[id] = domain.OutboxEvent{ID: id, Type: domain.EventCatUpdated, Aggregate: "cat", AggregateID: id}
+	}
+	return r
+}
+
+func (r *fakeStreamRepo) Listen(ctx context.Context, channel string, ready func(), fn func(payload string)) error {
+	ready()
+	for _, p := range r.notices {
+		fn(p)
+	}
+	return errors.New("conn closed")
+}
+
+func (r *fakeStreamRepo) Event(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+	e, ok := r.events[id]
+	if !ok {
+		return domain.OutboxEvent{}, domain.ErrNotFound
+	}
+	return e, nil
+}
+
+func (r *fakeStreamRepo) DeliveredAfter(ctx context.Context, sink string, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	if sink != "notify" {
+		return nil, errors.New("wrong sink " + sink)
+	}
+	i := slices.Index(r.delivered, afterID)
+	if i < 0 {
+		return nil, nil // entrega de afterID ainda não registrada
+	}
+	var out []domain.OutboxEvent
+	for _, id := range r.delivered[i+1:] {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, r.events[id])
+	}
+	return out, nil
+}
+
+func notice(t *testing.T, id int64, aggregate string) string {
+	b, err := json.Marshal(outbox.NotifyEvent{ID: id, Type: domain.EventCatUpdated, Aggregate: aggregate, AggregateID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestStreamReplay(t *testing.T) {
+	// 11 foi entregue antes de 10 (a transação de 10 confirmou depois)
+	repo := newFakeStreamRepo(9, 11, 10, 12)
+	repo.events[5] = domain.OutboxEvent{ID: 5} // ainda na outbox, mas sem entrega registrada ao Notify
+	h := NewStreamHub(repo, "cat_events", "notify")
+
+	tests := []struct {
+		name    string
+		after   int64
+		want    []int64
+		wantErr error
+	}{
+		{"segue a ordem das entregas", 11, []int64{10, 12}, nil},
+		{"id menor confirmado depois não se perde", 9, []int64{11, 10, 12}, nil},
+		{"último evento", 12, nil, nil},
+		{"entrega ainda não registrada", 5, nil, nil},
+		{"apagado da outbox", 3, nil, ErrEventsMissed},
+		{"id que nunca existiu", 99, nil, ErrEventsMissed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			err := h.Replay(context.Background(), tt.after, func(e domain.OutboxEvent) error {
+				got = append(got, e.ID)
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("replayed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamReplayPages(t *testing.T) {
+	ids := make([]int64, streamPageSize*2+3)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	h := NewStreamHub(newFakeStreamRepo(ids...), "cat_events", "notify")
+	n := 0
+	if err := h.Replay(context.Background(), 1, func(domain.OutboxEvent) error { n++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(ids)-1 {
+		t.Errorf("replayed %d events, want %d", n, len(ids)-1)
+	}
+}
+
+func TestStreamRunPublishes(t *testing.T) {
+	repo := newFakeStreamRepo(1, 2)
+	repo.notices = []string{notice(t, 1, "cat"), notice(t, 7, "owner"), "not json", notice(t, 404, "cat"), notice(t, 2, "cat")}
+	h := NewStreamHub(repo, "cat_events", "notify")
+	events, cancel := h.Subscribe()
+	defer cancel()
+
+	h.Run(context.Background())
+	var got []int64
+	for len(events) > 0 {
+		got = append(got, (<-events).ID)
+	}
+	if !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("published %v, want [1 2]", got)
+	}
+}
+
+func TestStreamDropsSlowSubscriber(t *testing.T) {
+	repo := newFakeStreamRepo(1)
+	for range streamBuffer + 1 {
+		repo.notices = append(repo.notices, notice(t, 1, "cat"))
+	}
+	h := NewStreamHub(repo, "cat_events", "notify")
+	events, cancel := h.Subscribe()
+	defer cancel()
+
+	h.Run(context.Background())
+	n := 0
+	for range events { // fecha depois do que coube no buffer
+		n++
+	}
+	if n != streamBuffer {
+		t.Errorf("received %d events before being dropped, want %d", n, streamBuffer)
+	}
+}
+
+func TestStreamReconnectDropsSubscribers(t *testing.T) {
+	h := NewStreamHub(newFakeStreamRepo(), "cat_events", "notify")
+	h.Run(context.Background()) // primeira conexão: ninguém é desconectado
+	events, _ := h.Subscribe()
+	h.Run(context.Background()) // reconexão: os avisos do intervalo se perderam
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("unexpected event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber kept after reconnect")
+	}
+}
+
+func TestStreamClose(t *testing.T) {
+	h := NewStreamHub(newFakeStreamRepo(), "cat_events", "notify")
+	events, cancel := h.Subscribe()
+	h.Close()
+	if _, ok := <-events; ok {
+		t.Fatal("channel open after Close")
+	}
+	cancel() // depois do Close não faz nada
+	late, _ := h.Subscribe()
+	if _, ok := <-late; ok {
+		t.Fatal("Subscribe after Close returned an open channel")
+	}
+}
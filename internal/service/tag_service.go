@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrTagNotFound     = domain.ErrTagNotFound
+	ErrTagNameTaken    = domain.ErrTagNameTaken
+	ErrInvalidTagName  = domain.ErrInvalidTagName
+	ErrInvalidTagMerge = domain.ErrInvalidTagMerge
+)
+
+// TagRepository descreve a persistência das etiquetas e do vínculo delas com os gatos.
+type TagRepository interface {
+	SetCatTags(ctx context.Context, catID int64, names []string) ([]string, error)
+	CatTags(ctx context.Context, catID int64) ([]string, error)
+	List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error)
+	Rename(ctx context.Context, id int64, name string) (domain.Tag, error)
+	Merge(ctx context.Context, id, into int64) (domain.Tag, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// TagService expõe as etiquetas dos gatos e a manutenção delas (renomear, mesclar, apagar).
+type TagService interface {
+	SetCatTags(ctx context.Context, catID int64, in domain.CatTags) ([]string, error) // Substitui as etiquetas do gato
+	CatTags(ctx context.Context, catID int64) ([]string, error)                       // Etiquetas do gato
+	List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error)         // Etiquetas por prefixo (autocomplete)
+	Rename(ctx context.Context, id int64, in domain.TagRename) (domain.Tag, error)    // Renomeia uma etiqueta
+	Merge(ctx context.Context, id int64, in domain.TagMerge) (domain.Tag, error)      // Junta uma etiqueta em outra
+	Delete(ctx context.Context, id int64) error                                       // Apaga uma etiqueta
+}
+
+type tagService struct {
+	repo      TagRepository
+	requestTO time.Duration
+}
+
+func NewTagService(repo TagRepository, requestTimeout time.Duration) TagService {
+	return &tagService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *tagService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *tagService) SetCatTags(ctx context.Context, catID int64, in domain.CatTags) ([]string, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.SetCatTags(ctx, catID, in.Tags)
+}
+
+func (s *tagService) CatTags(ctx context.Context, catID int64) ([]string, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.CatTags(ctx, catID)
+}
+
+func (s *tagService) List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx, prefix, limit)
+}
+
+func (s *tagService) Rename(ctx context.Context, id int64, in domain.TagRename) (domain.Tag, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return domain.Tag{}, fmt.Errorf("%w: nome vazio", ErrInvalidTagName)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Rename(ctx, id, name)
+}
+
+// Merge junta a etiqueta id em in.Into: os gatos de id passam a ter in.Into e id deixa de existir.
+func (s *tagService) Merge(ctx context.Context, id int64, in domain.TagMerge) (domain.Tag, error) {
+	if id == in.Into {
+		return domain.Tag{}, fmt.Errorf("%w: a etiqueta não pode ser mesclada nela mesma", ErrInvalidTagMerge)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Merge(ctx, id, in.Into)
+}
+
+func (s *tagService) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+// tenantKey guarda o tenant da requisição no contexto.
+type tenantKey struct{}
+
+// WithTenant devolve um contexto com o tenant (o handler lê do header X-Tenant-ID).
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant devolve o tenant do contexto (domain.DefaultTenant se não houver).
+func Tenant(ctx context.Context) string {
+	if t, ok := ctx.Value(tenantKey{}).(string); ok && t != "" {
+		return t
+	}
+	return domain.DefaultTenant
+}
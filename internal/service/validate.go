@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator cria um validador que usa o nome do campo no JSON nas mensagens.
+// É usado pelos serviços que recebem dados fora da camada HTTP (ex: importação).
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// describeValidation resume os erros do validator em texto: "name: min=2; age_years: lte=40".
+func describeValidation(err error) string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err.Error()
+	}
+	parts := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		parts = append(parts, fe.Field()+": "+rule)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/webhook"
+	"github.com/dya-andrade/cat-api/internal/worker"
+)
+
+var (
+	ErrWebhookNotFound  = domain.ErrWebhookNotFound
+	ErrDeliveryNotFound = domain.ErrDeliveryNotFound
+	ErrWebhookDisabled  = domain.ErrWebhookDisabled
+	ErrClaimExpired     = domain.ErrClaimExpired
+)
+
+// WebhookRepository descreve a persistência das assinaturas e das entregas.
+type WebhookRepository interface {
+	Create(ctx context.Context, in domain.WebhookCreate, secret string) (domain.Webhook, error)
+	List(ctx context.Context) ([]domain.Webhook, error)
+	GetByID(ctx context.Context, id int64) (domain.Webhook, error)
+	Update(ctx context.Context, id int64, in domain.WebhookUpdate) (domain.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	Deliveries(ctx context.Context, webhookID int64, status *string, limit int, cursor *int64) ([]domain.WebhookDelivery, *int64, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int64) (domain.WebhookDelivery, error)
+	Enqueue(ctx context.Context, e domain.OutboxEvent) (int64, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookSend, error)
+	Record(ctx context.Context, s domain.WebhookSend, r domain.WebhookResult, next *time.Time, disableAfter int) error
+	Release(ctx context.Context, s domain.WebhookSend) error
+}
+
+// WebhookService expõe as assinaturas de webhooks e o registro das entregas.
+type WebhookService interface {
+	Create(ctx context.Context, in domain.WebhookCreate) (domain.Webhook, error)                                                  // Cadastra (gera o segredo se não informado)
+	List(ctx context.Context) ([]domain.Webhook, error)                                                                           // Lista as assinaturas
+	GetByID(ctx context.Context, id int64) (domain.Webhook, error)                                                                // Busca uma assinatura
+	Update(ctx context.Context, id int64, in domain.WebhookUpdate) (domain.Webhook, error)                                        // Altera, reativa ou desativa
+	Delete(ctx context.Context, id int64) error                                                                                   // Remove a assinatura e as entregas
+	Deliveries(ctx context.Context, id int64, status *string, limit int, cursor *int64) ([]domain.WebhookDelivery, *int64, error) // Entregas, mais novas primeiro
+	Redeliver(ctx context.Context, id, deliveryID int64) (domain.WebhookDelivery, error)                                          // Agenda o reenvio de uma entrega
+}
+
+type webhookService struct {
+	repo      WebhookRepository
+	requestTO time.Duration
+}
+
+func NewWebhookService(repo WebhookRepository, requestTimeout time.Duration) WebhookService {
+	return &webhookService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *webhookService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+func (s *webhookService) Create(ctx context.Context, in domain.WebhookCreate) (domain.Webhook, error) {
+	var secret string
+	if in.Secret != nil {
+		secret = *in.Secret
+	} else {
+		var err error
+		if secret, err = webhook.NewSecret(); err != nil {
+			return domain.Webhook{}, err
+		}
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Create(ctx, in, secret)
+}
+
+func (s *webhookService) List(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.List(ctx)
+}
+
+func (s *webhookService) GetByID(ctx context.Context, id int64) (domain.Webhook, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *webhookService) Update(ctx context.Context, id int64, in domain.WebhookUpdate) (domain.Webhook, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Update(ctx, id, in)
+}
+
+func (s *webhookService) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *webhookService) Deliveries(ctx context.Context, id int64, status *string, limit int, cursor *int64) ([]domain.WebhookDelivery, *int64, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Deliveries(ctx, id, status, limit, cursor)
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, id, deliveryID int64) (domain.WebhookDelivery, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Redeliver(ctx, id, deliveryID)
+}
+
+/*
+WebhookDispatcher faz as entregas.
+
+Como destino da outbox (outbox.Sink), transforma cada evento em uma entrega para
+cada assinatura do tipo dele, gravada no banco. Run (agendado com worker.Every)
+reserva as entregas que já podem ser tentadas e envia cada uma por um worker.Pool
+próprio: o pool dos jobs roda importações longas, e um envio parado na fila dele
+passaria da reserva e seria feito de novo por outra rodada. Uma falha agenda a próxima tentativa com espera crescente até maxAttempts; as
+falhas seguidas de uma assinatura a desativam ao chegar a disableAfter.
+*/
+type WebhookDispatcher struct {
+	repo         WebhookRepository
+	wp           *worker.Pool
+	client       *http.Client
+	timeout      time.Duration
+	batch        int // entregas reservadas por rodada
+	maxAttempts  int // tentativas de cada entrega
+	disableAfter int // falhas seguidas que desativam a assinatura (0 = nunca)
+
+	inflight atomic.Int64 // envios entregues ao pool e ainda não gravados
+}
+
+const (
+	webhookUserAgent    = "cat-api-webhooks/1"
+	webhookResponseMax  = 1 << 10 // guarda até 1 KB da resposta
+	webhookFirstBackoff = 30 * time.Second
+	webhookMaxBackoff   = time.Hour
+)
+
+// NewWebhookDispatcher cria o dispatcher. timeout limita cada envio (inclui a resposta).
+// wp é só dos envios e deve ter batch workers: Run nunca tem mais de batch envios em
+// andamento, então cada envio reservado começa na hora, dentro da reserva.
+func NewWebhookDispatcher(repo WebhookRepository, wp *worker.Pool, timeout time.Duration, batch, maxAttempts, disableAfter int) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo: repo,
+		wp:   wp,
+		client: &http.Client{
+			Timeout: timeout,
+			// Um redirecionamento é tratado como falha: o POST não é repetido em outra URL
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		timeout:      timeout,
+		batch:        max(batch, 1),
+		maxAttempts:  max(maxAttempts, 1),
+		disableAfter: disableAfter,
+	}
+}
+
+// Name identifica o dispatcher no registro de entregas da outbox.
+func (d *WebhookDispatcher) Name() string { return "webhooks" }
+
+// Publish cria as entregas do evento (uma por assinatura do tipo dele). O envio fica para Run.
+func (d *WebhookDispatcher) Publish(ctx context.Context, e domain.OutboxEvent) error {
+	_, err := d.repo.Enqueue(ctx, e)
+	return err
+}
+
+// Run reserva as entregas que já podem ser tentadas, até completar batch envios em andamento,
+// e as envia pelo worker pool. A reserva dura o suficiente para o envio; se o processo cair,
+// a entrega é tentada de novo depois.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	free := d.batch - int(d.inflight.Load())
+	if free <= 0 {
+		return
+	}
+	sends, err := d.repo.Claim(ctx, free, d.timeout+30*time.Second)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("webhooks: claim: %v", err)
+		}
+		return
+	}
+	// O envio já reservado termina mesmo no desligamento (o pool é drenado)
+	ctx = context.WithoutCancel(ctx)
+	for _, s := range sends {
+		d.inflight.Add(1)
+		ok := d.wp.TrySubmit(func() error {
+			defer d.inflight.Add(-1)
+			d.deliver(ctx, s)
+			return nil
+		})
+		if !ok {
+			// pool sem vaga: devolve a reserva em vez de deixar o envio esperando além dela
+			d.inflight.Add(-1)
+			if err := d.repo.Release(ctx, s); err != nil {
+				log.Printf("webhooks: delivery %d: release: %v", s.DeliveryID, err)
+			}
+		}
+	}
+}
+
+// deliver faz uma tentativa e grava o resultado.
+func (d *WebhookDispatcher) deliver(ctx context.Context, s domain.WebhookSend) {
+	r := d.send(ctx, s)
+	var next *time.Time
+	if r.Error != nil && s.Attempts+1 < d.maxAttempts {
+		t := time.Now().Add(webhookBackoff(s.Attempts + 1))
+		next = &t
+	}
+	if err := d.repo.Record(ctx, s, r, next, d.disableAfter); errors.Is(err, ErrClaimExpired) {
+		log.Printf("webhooks: delivery %d: claim expired during the send, result discarded", s.DeliveryID)
+	} else if err != nil {
+		log.Printf("webhooks: delivery %d: record: %v", s.DeliveryID, err)
+	}
+}
+
+// send faz o POST assinado e devolve o resultado; só 2xx é sucesso.
+func (d *WebhookDispatcher) send(ctx context.Context, s domain.WebhookSend) domain.WebhookResult {
+	var r domain.WebhookResult
+	fail := func(format string, args ...any) domain.WebhookResult {
+		msg := fmt.Sprintf(format, args...)
+		r.Error = &msg
+		return r
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(s.Body))
+	if err != nil {
+		return fail("%v", err)
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(webhook.HeaderID, strconv.FormatInt(s.DeliveryID, 10))
+	req.Header.Set(webhook.HeaderEvent, s.EventType)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(s.Secret, now, s.Body))
+
+	resp, err := d.client.Do(req)
+	r.Duration = time.Since(now)
+	if err != nil {
+		return fail("%v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseMax))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // permite reaproveitar a conexão
+	code, body := resp.StatusCode, strings.ToValidUTF8(string(b), "")
+	r.ResponseCode, r.ResponseBody = &code, &body
+	if code < 200 || code > 299 {
+		return fail("unexpected status %d", code)
+	}
+	return r
+}
+
+// webhookBackoff dobra a espera a cada falha: 30s, 1min, 2min... até webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	if attempts > 10 {
+		return webhookMaxBackoff
+	}
+	return min(webhookFirstBackoff<<(attempts-1), webhookMaxBackoff)
+}
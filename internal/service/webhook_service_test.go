@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/dya-andrade/cat-api/internal/webhook"
+	"github.com/dya-andrade/cat-api/internal/worker"
+)
+
+const testSecret = "whsec_0123456789abcdef"
+
+// receiver é o destino local das entregas: confere a assinatura e responde status.
+type receiver struct {
+	*httptest.Server
+	t      *testing.T
+	mu     sync.Mutex
+	status int
+	got    []*http.Request
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	rc := &receiver{t: t, status: status}
+	rc.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := webhook.Verify(testSecret, r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, 5*time.Minute, time.Now())
+		if err != nil {
+			t.Errorf("receiver: %v", err)
+		}
+		rc.mu.Lock()
+		rc.got = append(rc.got, r)
+		status := rc.status
+		rc.mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, "status %d", status)
+	}))
+	t.Cleanup(rc.Close)
+	return rc
+}
+
+// fakeWebhookRepo guarda assinaturas e entregas em memória, com as regras de Enqueue, Claim e
+// Record do repositório: só assinaturas ativas recebem entregas e as falhas seguidas desativam.
+type fakeWebhookRepo struct {
+	WebhookRepository
+	mu         sync.Mutex
+	hooks      map[int64]*domain.Webhook
+	deliveries []*domain.WebhookDelivery
+	bodies     map[int64][]byte
+	tokens     map[int64]int64 // claim_token de cada entrega
+	released   []int64
+	recorded   chan struct{}
+}
+
+func newFakeWebhookRepo(hooks ...domain.Webhook) *fakeWebhookRepo {
+	r := &fakeWebhookRepo{hooks: map[int64]*domain.Webhook{}, bodies: map[int64][]byte{}, tokens: map[int64]int64{}, recorded: make(chan struct{}, 100)}
+	for _, h := range hooks {
+		r.hooks[h.ID] = &h
+	}
+	return r
+}
+
+func (r *fakeWebhookRepo) Enqueue(ctx context.Context, e domain.OutboxEvent) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, h := range r.hooks {
+		if !h.Active || !slices.Contains(h.Events, e.Type) {
+			continue
+		}
+		d := &domain.WebhookDelivery{ID: int64(len(r.deliveries) + 1), WebhookID: h.ID, EventID: e.ID, EventType: e.Type, Status: domain.DeliveryPending}
+		r.deliveries = append(r.deliveries, d)
+		r.bodies[d.ID] = e.Data
+		n++
+	}
+	return n, nil
+}
+
+// Claim devolve as entregas pendentes de assinaturas ativas (ignora next_attempt_at).
+func (r *fakeWebhookRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookSend, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sends []domain.WebhookSend
+	for _, d := range r.deliveries {
+		h := r.hooks[d.WebhookID]
+		if d.Status != domain.DeliveryPending || !h.Active || len(sends) == limit {
+			continue
+		}
+		r.tokens[d.ID]++
+		sends = append(sends, domain.WebhookSend{DeliveryID: d.ID, WebhookID: h.ID, EventType: d.EventType, URL: h.URL, Secret: testSecret, Body: r.bodies[d.ID], Attempts: d.Attempts, ClaimToken: r.tokens[d.ID]})
+	}
+	return sends, nil
+}
+
+func (r *fakeWebhookRepo) Record(ctx context.Context, s domain.WebhookSend, res domain.WebhookResult, next *time.Time, disableAfter int) error {
+	r.mu.Lock()
+	defer func() {
+		r.mu.Unlock()
+		r.recorded <- struct{}{}
+	}()
+	d := r.deliveries[s.DeliveryID-1]
+	if d.Status != domain.DeliveryPending || r.tokens[d.ID] != s.ClaimToken {
+		return ErrClaimExpired
+	}
+	r.tokens[d.ID]++ // o resultado encerra a reserva
+	d.Attempts++
+	d.ResponseCode, d.ResponseBody, d.Error, d.NextAttemptAt = res.ResponseCode, res.ResponseBody, res.Error, next
+	h := r.hooks[s.WebhookID]
+	switch {
+	case res.Error == nil:
+		d.Status = domain.DeliverySucceeded
+		h.ConsecutiveFailures = 0
+	default:
+		d.Status = domain.DeliveryFailed
+		if next != nil {
+			d.Status = domain.DeliveryPending
+		}
+		h.ConsecutiveFailures++
+		if h.Active && disableAfter > 0 && h.ConsecutiveFailures >= disableAfter {
+			h.Active = false
+			reason := fmt.Sprintf("disabled after %d consecutive failures", disableAfter)
+			h.DisabledReason = &reason
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) Release(ctx context.Context, s domain.WebhookSend) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, s.DeliveryID)
+	return nil
+}
+
+// runOnce faz uma rodada do dispatcher e espera as n entregas serem gravadas.
+func runOnce(t *testing.T, d *WebhookDispatcher, repo *fakeWebhookRepo, n int) {
+	t.Helper()
+	d.Run(context.Background())
+	for range n {
+		select {
+		case <-repo.recorded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("delivery not recorded")
+		}
+	}
+}
+
+func newDispatcher(t *testing.T, repo WebhookRepository, maxAttempts, disableAfter int) *WebhookDispatcher {
+	wp := worker.NewPool(2)
+	wp.Start()
+	t.Cleanup(wp.Shutdown)
+	return NewWebhookDispatcher(repo, wp, 2*time.Second, 50, maxAttempts, disableAfter)
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	rc := newReceiver(t, http.StatusOK)
+	d := newDispatcher(t, nil, 3, 0)
+	body := []byte(`{"id":41,"type":"cat.created"}`)
+
+	res := d.send(context.Background(), domain.WebhookSend{DeliveryID: 7, EventType: domain.EventCatCreated, URL: rc.URL, Secret: testSecret, Body: body})
+	if res.Error != nil {
+		t.Fatalf("send: %v", *res.Error)
+	}
+	if *res.ResponseCode != http.StatusOK || *res.ResponseBody != "status 200" {
+		t.Errorf("response = %d %q", *res.ResponseCode, *res.ResponseBody)
+	}
+	req := rc.got[0]
+	if req.Header.Get(webhook.HeaderID) != "7" || req.Header.Get(webhook.HeaderEvent) != domain.EventCatCreated {
+		t.Errorf("headers = %v", req.Header)
+	}
+	if req.Header.Get("Content-Type") != "application/json" || req.Header.Get("User-Agent") != webhookUserAgent {
+		t.Errorf("headers = %v", req.Header)
+	}
+}
+
+func TestSendFailures(t *testing.T) {
+	redirect := httptest.NewServer(http.RedirectHandler("http://example.com/", http.StatusFound))
+	t.Cleanup(redirect.Close)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	t.Cleanup(slow.Close)
+
+	tests := []struct {
+		name string
+		url  string
+		code int // 0 = sem resposta
+	}{
+		{"5xx", newReceiver(t, http.StatusInternalServerError).URL, 500},
+		{"4xx", newReceiver(t, http.StatusGone).URL, 410},
+		{"redirecionamento", redirect.URL, 302},
+		{"timeout", slow.URL, 0},
+		{"conexão recusada", "http://127.0.0.1:1", 0},
+	}
+	d := newDispatcher(t, nil, 3, 0)
+	d.client.Timeout = 200 * time.Millisecond
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := d.send(context.Background(), domain.WebhookSend{DeliveryID: 1, URL: tt.url, Secret: testSecret, Body: []byte(`{}`)})
+			if res.Error == nil {
+				t.Fatal("expected failure")
+			}
+			if tt.code == 0 && res.ResponseCode != nil || tt.code != 0 && (res.ResponseCode == nil || *res.ResponseCode != tt.code) {
+				t.Errorf("response code = %v, want %d", res.ResponseCode, tt.code)
+			}
+		})
+	}
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	rc := newReceiver(t, http.StatusServiceUnavailable)
+	repo := newFakeWebhookRepo(domain.Webhook{ID: 1, URL: rc.URL, Events: []string{domain.EventCatCreated}, Active: true})
+	d := newDispatcher(t, repo, 3, 0)
+	_ = d.Publish(context.Background(), domain.OutboxEvent{ID: 1, Type: domain.EventCatCreated, Data: []byte(`{"id":1}`)})
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		before := time.Now()
+		runOnce(t, d, repo, 1)
+		del := repo.deliveries[0]
+		if del.Attempts != attempt || *del.ResponseCode != http.StatusServiceUnavailable {
+			t.Fatalf("attempt %d: %+v", attempt, del)
+		}
+		if attempt < 3 {
+			if del.Status != domain.DeliveryPending || del.NextAttemptAt == nil {
+				t.Fatalf("attempt %d: retry not scheduled: %+v", attempt, del)
+			}
+			wait := del.NextAttemptAt.Sub(before)
+			want := webhookBackoff(attempt)
+			if wait < want || wait > want+time.Second {
+				t.Errorf("attempt %d: next in %v, want %v", attempt, wait, want)
+			}
+		} else if del.Status != domain.DeliveryFailed || del.NextAttemptAt != nil {
+			t.Fatalf("last attempt: %+v", del)
+		}
+	}
+}
+
+func TestDeliverSuccessResetsFailures(t *testing.T) {
+	rc := newReceiver(t, http.StatusNoContent)
+	repo := newFakeWebhookRepo(domain.Webhook{ID: 1, URL: rc.URL, Events: []string{domain.EventCatUpdated}, Active: true, ConsecutiveFailures: 4})
+	d := newDispatcher(t, repo, 3, 5)
+	_ = d.Publish(context.Background(), domain.OutboxEvent{ID: 1, Type: domain.EventCatUpdated, Data: []byte(`{}`)})
+	_ = d.Publish(context.Background(), domain.OutboxEvent{ID: 2, Type: domain.EventCatDeleted, Data: []byte(`{}`)}) // não assinado
+	runOnce(t, d, repo, 1)
+
+	if len(repo.deliveries) != 1 || repo.deliveries[0].Status != domain.DeliverySucceeded {
+		t.Fatalf("deliveries = %+v", repo.deliveries)
+	}
+	if repo.hooks[1].ConsecutiveFailures != 0 {
+		t.Errorf("consecutive_failures = %d", repo.hooks[1].ConsecutiveFailures)
+	}
+}
+
+func TestAutoDisable(t *testing.T) {
+	rc := newReceiver(t, http.StatusInternalServerError)
+	repo := newFakeWebhookRepo(domain.Webhook{ID: 1, URL: rc.URL, Events: domain.EventTypes, Active: true})
+	const disableAfter = 3
+	d := newDispatcher(t, repo, 1, disableAfter) // uma tentativa por entrega: cada rodada é uma falha
+
+	for i := 1; i <= disableAfter; i++ {
+		if !repo.hooks[1].Active {
+			t.Fatalf("disabled after %d failures", i-1)
+		}
+		_ = d.Publish(context.Background(), domain.OutboxEvent{ID: int64(i), Type: domain.EventCatCreated, Data: []byte(`{}`)})
+		runOnce(t, d, repo, 1)
+	}
+	h := repo.hooks[1]
+	if h.Active || h.DisabledReason == nil || *h.DisabledReason != "disabled after 3 consecutive failures" {
+		t.Fatalf("webhook = %+v", h)
+	}
+
+	// desativada: não recebe eventos novos nem tenta as pendentes
+	_ = d.Publish(context.Background(), domain.OutboxEvent{ID: 9, Type: domain.EventCatCreated, Data: []byte(`{}`)})
+	sends, _ := repo.Claim(context.Background(), 50, time.Minute)
+	if len(sends) != 0 || len(repo.deliveries) != disableAfter {
+		t.Errorf("sends = %d, deliveries = %d", len(sends), len(repo.deliveries))
+	}
+}
+
+// Um envio que terminou depois de a entrega ser reservada de novo não grava o resultado.
+func TestDeliverStaleClaimDiscarded(t *testing.T) {
+	rc := newReceiver(t, http.StatusInternalServerError)
+	repo := newFakeWebhookRepo(domain.Webhook{ID: 1, URL: rc.URL, Events: domain.EventTypes, Active: true})
+	d := newDispatcher(t, repo, 3, 1)
+	_ = d.Publish(context.Background(), domain.OutboxEvent{ID: 1, Type: domain.EventCatCreated, Data: []byte(`{}`)})
+
+	stale, _ := repo.Claim(context.Background(), 1, time.Minute)
+	current, _ := repo.Claim(context.Background(), 1, time.Minute) // a reserva venceu e outra rodada pegou
+	d.deliver(context.Background(), stale[0])
+	<-repo.recorded
+	if del, h := repo.deliveries[0], repo.hooks[1]; del.Attempts != 0 || h.ConsecutiveFailures != 0 || !h.Active {
+		t.Fatalf("stale result recorded: delivery %+v, webhook %+v", del, h)
+	}
+
+	d.deliver(context.Background(), current[0])
+	<-repo.recorded
+	if del := repo.deliveries[0]; del.Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", del.Attempts)
+	}
+	d.deliver(context.Background(), current[0]) // o mesmo resultado de novo também é descartado
+	<-repo.recorded
+	if del := repo.deliveries[0]; del.Attempts != 1 {
+		t.Errorf("attempts = %d after a repeated record, want 1", del.Attempts)
+	}
+}
+
+// Sem vaga no pool a reserva é devolvida, e Run não reserva além dos envios que cabem.
+func TestRunReleasesWhenPoolFull(t *testing.T) {
+	release := make(chan struct{})
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-release }))
+	t.Cleanup(blocked.Close)
+
+	repo := newFakeWebhookRepo(domain.Webhook{ID: 1, URL: blocked.URL, Events: domain.EventTypes, Active: true})
+	for i := range 3 {
+		_, _ = repo.Enqueue(context.Background(), domain.OutboxEvent{ID: int64(i + 1), Type: domain.EventCatCreated, Data: []byte(`{}`)})
+	}
+	wp := worker.NewPoolQueue(1, 1)
+	wp.Start()
+	t.Cleanup(wp.Shutdown)
+	t.Cleanup(func() { close(release) }) // roda antes do Shutdown
+	busy := make(chan struct{})
+	_ = wp.Submit(func() error { close(busy); <-release; return nil })
+	<-busy // o único worker está ocupado: só resta a vaga da fila
+
+	d := NewWebhookDispatcher(repo, wp, 5*time.Second, 3, 3, 0)
+	d.Run(context.Background())
+	repo.mu.Lock()
+	released := slices.Clone(repo.released)
+	repo.mu.Unlock()
+	if !slices.Equal(released, []int64{2, 3}) {
+		t.Fatalf("released %v, want [2 3]", released)
+	}
+	if n := d.inflight.Load(); n != 1 {
+		t.Fatalf("inflight = %d, want 1", n)
+	}
+
+	// com 1 envio em andamento e batch 3, a próxima rodada reserva no máximo 2
+	claims := &claimLimits{WebhookRepository: repo}
+	d.repo = claims
+	d.Run(context.Background())
+	if !slices.Equal(claims.limits, []int{2}) {
+		t.Errorf("claim limits = %v, want [2]", claims.limits)
+	}
+}
+
+// claimLimits registra o limite de cada Claim e não reserva nada.
+type claimLimits struct {
+	WebhookRepository
+	limits []int
+}
+
+func (c *claimLimits) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookSend, error) {
+	c.limits = append(c.limits, limit)
+	return nil, nil
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{11, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			if got := webhookBackoff(tt.attempts); got != tt.want {
+				t.Errorf("webhookBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+var (
+	ErrWeightNotFound      = domain.ErrWeightNotFound
+	ErrWeightAlertNotFound = domain.ErrWeightAlertNotFound
+	ErrWeightRange         = domain.ErrWeightRange
+)
+
+// WeightRepository descreve a persistência do histórico de peso.
+type WeightRepository interface {
+	Add(ctx context.Context, catID int64, in domain.WeightCreate, at time.Time) (domain.Weight, error)
+	Delete(ctx context.Context, catID, id int64) error
+	Series(ctx context.Context, catID int64, q domain.WeightQuery) (domain.WeightSeries, error)
+	Alerts(ctx context.Context, f domain.WeightAlertFilter, limit int, cursor *time.Time) ([]domain.WeightAlert, *time.Time, error)
+	Acknowledge(ctx context.Context, id int64) (domain.WeightAlert, error)
+}
+
+// WeightService expõe o histórico de peso e os alertas de variação.
+type WeightService interface {
+	Add(ctx context.Context, catID int64, in domain.WeightCreate) (domain.Weight, error)                                            // Registra uma medição
+	Delete(ctx context.Context, catID, id int64) error                                                                              // Apaga uma medição
+	Series(ctx context.Context, catID int64, q domain.WeightQuery) (domain.WeightSeries, error)                                     // Série, opcionalmente agrupada
+	Alerts(ctx context.Context, f domain.WeightAlertFilter, limit int, cursor *time.Time) ([]domain.WeightAlert, *time.Time, error) // Lista alertas
+	Acknowledge(ctx context.Context, id int64) (domain.WeightAlert, error)                                                          // Reconhece um alerta
+}
+
+type weightService struct {
+	repo      WeightRepository
+	requestTO time.Duration
+}
+
+func NewWeightService(repo WeightRepository, requestTimeout time.Duration) WeightService {
+	return &weightService{repo: repo, requestTO: requestTimeout}
+}
+
+func (s *weightService) withTO(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.requestTO <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.requestTO)
+}
+
+// clockSkew tolera pequenas diferenças de relógio entre o cliente e o servidor em measured_at.
+const clockSkew = time.Minute
+
+// Add registra uma medição; sem measured_at, vale o momento atual. Medições no futuro são recusadas.
+func (s *weightService) Add(ctx context.Context, catID int64, in domain.WeightCreate) (domain.Weight, error) {
+	now := time.Now()
+	at := now
+	if in.MeasuredAt != nil {
+		if in.MeasuredAt.After(now.Add(clockSkew)) {
+			return domain.Weight{}, fmt.Errorf("%w: measured_at no futuro", ErrWeightRange)
+		}
+		at = *in.MeasuredAt
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Add(ctx, catID, in, at)
+}
+
+func (s *weightService) Delete(ctx context.Context, catID, id int64) error {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Delete(ctx, catID, id)
+}
+
+// Series valida o período e o agrupamento antes de consultar.
+func (s *weightService) Series(ctx context.Context, catID int64, q domain.WeightQuery) (domain.WeightSeries, error) {
+	if q.From != nil && q.To != nil && q.From.After(*q.To) {
+		return domain.WeightSeries{}, fmt.Errorf("%w: from depois de to", ErrWeightRange)
+	}
+	switch q.Bucket {
+	case "", domain.WeightBucketDay, domain.WeightBucketWeek, domain.WeightBucketMonth:
+	default:
+		return domain.WeightSeries{}, fmt.Errorf("%w: bucket %q", ErrWeightRange, q.Bucket)
+	}
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Series(ctx, catID, q)
+}
+
+func (s *weightService) Alerts(ctx context.Context, f domain.WeightAlertFilter, limit int, cursor *time.Time) ([]domain.WeightAlert, *time.Time, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Alerts(ctx, f, limit, cursor)
+}
+
+func (s *weightService) Acknowledge(ctx context.Context, id int64) (domain.WeightAlert, error) {
+	ctx, cancel := s.withTO(ctx)
+	defer cancel()
+	return s.repo.Acknowledge(ctx, id)
+}
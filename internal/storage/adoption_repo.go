@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Fluxo de adoção: status do gato (cats.status), candidaturas (adoption_applications)
+e histórico de transições (cat_status_transitions).
+
+Toda mudança de status passa por setStatus, dentro de uma transação com o gato
+travado (SELECT ... FOR UPDATE): a transição é validada por domain.CheckTransition,
+a versão do gato é incrementada (o ETag muda) e o histórico recebe uma linha.
+*/
+
+// AdoptionRepository persiste o fluxo de adoção.
+type AdoptionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAdoptionRepository(db *pgxpool.Pool) *AdoptionRepository {
+	return &AdoptionRepository{db: db}
+}
+
+const applicationColumns = "id, cat_id, owner_id, status, message, decision_reason, decided_at, created_at, updated_at"
+
+func scanApplication(row pgx.Row) (domain.AdoptionApplication, error) {
+	var a domain.AdoptionApplication
+	err := row.Scan(&a.ID, &a.CatID, &a.OwnerID, &a.Status, &a.Message, &a.DecisionReason, &a.DecidedAt, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+const transitionColumns = "id, cat_id, from_status, to_status, reason, application_id, created_at"
+
+// lockCatStatus trava o gato até o fim da transação e devolve o status atual.
+func lockCatStatus(ctx context.Context, tx pgx.Tx, catID int64) (string, error) {
+	var status string
+	err := tx.QueryRow(ctx, "SELECT status FROM cats WHERE id=$1 FOR UPDATE", catID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound
+	}
+	return status, err
+}
+
+// setStatus valida e grava a transição from -> to, registrando-a no histórico.
+func setStatus(ctx context.Context, tx pgx.Tx, catID int64, from, to string, reason *string, applicationID *int64) (domain.Cat, error) {
+	if err := domain.CheckTransition(from, to); err != nil {
+		return domain.Cat{}, err
+	}
+	c, err := scanCat(tx.QueryRow(ctx,
+		"UPDATE cats SET status = $2, version = version + 1 WHERE id = $1 RETURNING "+catColumns,
+		catID, to,
+	))
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO cat_status_transitions (cat_id, from_status, to_status, reason, application_id) VALUES ($1,$2,$3,$4,$5)",
+		catID, from, to, reason, applicationID,
+	); err != nil {
+		return domain.Cat{}, err
+	}
+	return c, nil
+}
+
+// ChangeStatus muda o status do gato manualmente.
+// Retorna *domain.TransitionError se a transição não for permitida.
+// A devolução (returned) encerra o vínculo do tutor principal.
+func (repository *AdoptionRepository) ChangeStatus(ctx context.Context, catID int64, in domain.StatusChange) (domain.Cat, error) {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	from, err := lockCatStatus(ctx, tx, catID)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	c, err := setStatus(ctx, tx, catID, from, in.Status, in.Reason, nil)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	if in.Status == domain.StatusReturned {
+		if _, err := tx.Exec(ctx,
+			"UPDATE cat_owners SET end_date = current_date WHERE cat_id = $1 AND role = 'primary' AND end_date IS NULL",
+			catID,
+		); err != nil {
+			return domain.Cat{}, ownershipErr(err)
+		}
+	}
+	return c, tx.Commit(ctx)
+}
+
+// History devolve as transições de status do gato, da mais antiga para a mais recente.
+func (repository *AdoptionRepository) History(ctx context.Context, catID int64) ([]domain.StatusTransition, error) {
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+transitionColumns+" FROM cat_status_transitions WHERE cat_id=$1 ORDER BY id",
+		catID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.StatusTransition{}
+	for rows.Next() {
+		var t domain.StatusTransition
+		if err := rows.Scan(&t.ID, &t.CatID, &t.FromStatus, &t.ToStatus, &t.Reason, &t.ApplicationID, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, catExists(ctx, repository.db, catID)
+	}
+	return items, nil
+}
+
+// catExists devolve domain.ErrNotFound se o gato não existir.
+func catExists(ctx context.Context, q querier, catID int64) error {
+	var exists bool
+	if err := q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM cats WHERE id=$1)", catID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// applicationErr traduz erros de escrita em adoption_applications para erros de domínio.
+func applicationErr(err error) error {
+	switch code, _ := pgError(err); code {
+	case pgUniqueViolation:
+		return domain.ErrApplicationExists
+	case pgForeignKeyViolation:
+		return domain.ErrOwnerNotFound // o gato já foi travado, então só o tutor pode faltar
+	}
+	return err
+}
+
+// Apply registra a candidatura do tutor. O gato precisa estar available, reserved
+// ou adoption_pending; nos dois primeiros casos ele passa para adoption_pending.
+func (repository *AdoptionRepository) Apply(ctx context.Context, catID int64, in domain.ApplicationCreate) (domain.AdoptionApplication, error) {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	status, err := lockCatStatus(ctx, tx, catID)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	switch status {
+	case domain.StatusAvailable, domain.StatusReserved, domain.StatusAdoptionPending:
+	default:
+		return domain.AdoptionApplication{}, fmt.Errorf("%w: status atual %s", domain.ErrNotAcceptingApplications, status)
+	}
+
+	a, err := scanApplication(tx.QueryRow(ctx,
+		"INSERT INTO adoption_applications (cat_id, owner_id, message) VALUES ($1,$2,$3) RETURNING "+applicationColumns,
+		catID, in.OwnerID, in.Message,
+	))
+	if err != nil {
+		return domain.AdoptionApplication{}, applicationErr(err)
+	}
+	if status != domain.StatusAdoptionPending {
+		reason := "application submitted"
+		if _, err := setStatus(ctx, tx, catID, status, domain.StatusAdoptionPending, &reason, &a.ID); err != nil {
+			return domain.AdoptionApplication{}, err
+		}
+	}
+	return a, tx.Commit(ctx)
+}
+
+// GetApplication busca uma candidatura. Retorna domain.ErrApplicationNotFound se não existir.
+func (repository *AdoptionRepository) GetApplication(ctx context.Context, id int64) (domain.AdoptionApplication, error) {
+	a, err := scanApplication(repository.db.QueryRow(ctx, "SELECT "+applicationColumns+" FROM adoption_applications WHERE id=$1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AdoptionApplication{}, domain.ErrApplicationNotFound
+	}
+	return a, err
+}
+
+// Applications lista candidaturas com paginação por cursor (created_at).
+// Com f.CatID, uma página vazia de um gato inexistente retorna domain.ErrNotFound.
+func (repository *AdoptionRepository) Applications(ctx context.Context, f domain.ApplicationFilter, limit int, cursor *time.Time) ([]domain.AdoptionApplication, *time.Time, error) {
+	var (
+		args  []any
+		conds []string
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.CatID != nil {
+		add("cat_id = $%d", *f.CatID)
+	}
+	if f.OwnerID != nil {
+		add("owner_id = $%d", *f.OwnerID)
+	}
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if cursor != nil {
+		add("created_at < $%d", *cursor)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+applicationColumns+" FROM adoption_applications"+where+fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.AdoptionApplication
+	var last *time.Time
+	for rows.Next() {
+		a, err := scanApplication(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, a)
+		last = &a.CreatedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 {
+		if f.CatID != nil && cursor == nil {
+			return nil, nil, catExists(ctx, repository.db, *f.CatID)
+		}
+		return nil, nil, nil
+	}
+	return items, last, nil
+}
+
+// lockPendingApplication trava o gato e a candidatura (nessa ordem, a mesma de Apply e
+// ChangeStatus, para não haver deadlock) e confirma que ela ainda está pendente.
+// Devolve o gato, o candidato e o status atual do gato.
+func lockPendingApplication(ctx context.Context, tx pgx.Tx, id int64) (catID, ownerID int64, catStatus string, err error) {
+	err = tx.QueryRow(ctx, "SELECT cat_id FROM adoption_applications WHERE id=$1", id).Scan(&catID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, "", domain.ErrApplicationNotFound
+	}
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if catStatus, err = lockCatStatus(ctx, tx, catID); err != nil {
+		return 0, 0, "", err
+	}
+
+	var status string
+	if err := tx.QueryRow(ctx, "SELECT owner_id, status FROM adoption_applications WHERE id=$1 FOR UPDATE", id).Scan(&ownerID, &status); err != nil {
+		return 0, 0, "", err
+	}
+	if status != domain.ApplicationPending {
+		return 0, 0, "", fmt.Errorf("%w: %s", domain.ErrApplicationDecided, status)
+	}
+	return catID, ownerID, catStatus, nil
+}
+
+// decide grava a decisão (approved/rejected) da candidatura.
+func decide(ctx context.Context, tx pgx.Tx, id int64, status string, reason *string) (domain.AdoptionApplication, error) {
+	return scanApplication(tx.QueryRow(ctx,
+		"UPDATE adoption_applications SET status = $2, decision_reason = $3, decided_at = now() WHERE id = $1 RETURNING "+applicationColumns,
+		id, status, reason,
+	))
+}
+
+// Approve aprova a candidatura numa única transação:
+//  1. o gato vai para adopted (a partir de adoption_pending; senão *domain.TransitionError);
+//  2. as demais candidaturas pendentes do gato são rejeitadas;
+//  3. o candidato passa a ser o tutor principal (com registro em cat_transfers) a partir de on.
+func (repository *AdoptionRepository) Approve(ctx context.Context, id int64, in domain.ApplicationDecision, on domain.Date) (domain.AdoptionApplication, error) {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	catID, ownerID, status, err := lockPendingApplication(ctx, tx, id)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	if _, err := setStatus(ctx, tx, catID, status, domain.StatusAdopted, in.Reason, &id); err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+
+	a, err := decide(ctx, tx, id, domain.ApplicationApproved, in.Reason)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE adoption_applications SET status = 'rejected', decision_reason = $3, decided_at = now()
+		 WHERE cat_id = $1 AND id <> $2 AND status = 'pending'`,
+		catID, id, fmt.Sprintf("application %d approved", id),
+	); err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+
+	reason := "adoption"
+	if _, err := transferPrimary(ctx, tx, catID, ownerID, on, &reason); err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	return a, tx.Commit(ctx)
+}
+
+// Reject rejeita a candidatura. Se era a última pendente e o gato está em
+// adoption_pending, ele volta para available.
+func (repository *AdoptionRepository) Reject(ctx context.Context, id int64, in domain.ApplicationDecision) (domain.AdoptionApplication, error) {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	catID, _, status, err := lockPendingApplication(ctx, tx, id)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+
+	a, err := decide(ctx, tx, id, domain.ApplicationRejected, in.Reason)
+	if err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+
+	var pending bool
+	if err := tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM adoption_applications WHERE cat_id=$1 AND status='pending')",
+		catID,
+	).Scan(&pending); err != nil {
+		return domain.AdoptionApplication{}, err
+	}
+	if !pending && status == domain.StatusAdoptionPending {
+		reason := "no pending applications"
+		if _, err := setStatus(ctx, tx, catID, status, domain.StatusAvailable, &reason, &id); err != nil {
+			return domain.AdoptionApplication{}, err
+		}
+	}
+	return a, tx.Commit(ctx)
+}
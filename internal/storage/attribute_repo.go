@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AttributeRepository persiste as definições dos atributos personalizados de cada tenant.
+type AttributeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttributeRepository(db *pgxpool.Pool) *AttributeRepository {
+	return &AttributeRepository{db: db}
+}
+
+const attributeColumns = "key, type, description, created_at, updated_at"
+
+func scanAttribute(row pgx.Row) (domain.AttributeDef, error) {
+	var d domain.AttributeDef
+	err := row.Scan(&d.Key, &d.Type, &d.Description, &d.CreatedAt, &d.UpdatedAt)
+	return d, err
+}
+
+// List devolve as definições do tenant, em ordem alfabética de chave.
+func (repository *AttributeRepository) List(ctx context.Context, tenant string) ([]domain.AttributeDef, error) {
+	rows, err := repository.db.Query(ctx, "SELECT "+attributeColumns+" FROM attribute_definitions WHERE tenant = $1 ORDER BY key", tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.AttributeDef
+	for rows.Next() {
+		d, err := scanAttribute(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Put cria ou altera a definição da chave no tenant.
+// Valores já gravados nos gatos não são convertidos quando o tipo muda.
+func (repository *AttributeRepository) Put(ctx context.Context, tenant, key string, in domain.AttributeDefInput) (domain.AttributeDef, error) {
+	return scanAttribute(repository.db.QueryRow(ctx,
+		`INSERT INTO attribute_definitions (tenant, key, type, description) VALUES ($1,$2,$3,$4)
+		 ON CONFLICT (tenant, key) DO UPDATE SET type = EXCLUDED.type, description = EXCLUDED.description, updated_at = now()
+		 RETURNING `+attributeColumns,
+		tenant, key, in.Type, in.Description,
+	))
+}
+
+// Delete remove a definição. Os gatos mantêm os valores, que podem ser removidos com null.
+func (repository *AttributeRepository) Delete(ctx context.Context, tenant, key string) error {
+	tag, err := repository.db.Exec(ctx, "DELETE FROM attribute_definitions WHERE tenant = $1 AND key = $2", tenant, key)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAttributeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Auditoria (audit_events). Os eventos são gravados pelo trigger audit_row() (migração
+0016), não por este código: aqui só se informa ao banco quem está fazendo a mudança,
+em variáveis locais da transação, e se lê o registro.
+*/
+
+// beginAudited abre uma transação que identifica, para o trigger de auditoria, quem
+// fez a requisição (domain.RequesterFrom). Toda escrita em tabela auditada deve usá-la.
+func beginAudited(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := domain.RequesterFrom(ctx)
+	if _, err := tx.Exec(ctx,
+		`SELECT set_config('cat_api.actor', $1, true), set_config('cat_api.request_id', $2, true),
+		        set_config('cat_api.ip', $3, true), set_config('cat_api.user_agent', $4, true)`,
+		req.Actor, req.RequestID, req.IP, req.UserAgent,
+	); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// AuditRepository lê o registro de auditoria.
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+const auditColumns = "id, entity, entity_id, action, actor, request_id, ip, user_agent, before, after, created_at"
+
+func scanAuditEvent(row pgx.Row) (domain.AuditEvent, error) {
+	var e domain.AuditEvent
+	err := row.Scan(&e.ID, &e.Entity, &e.EntityID, &e.Action, &e.Actor, &e.RequestID, &e.IP, &e.UserAgent, &e.Before, &e.After, &e.CreatedAt)
+	return e, err
+}
+
+// auditFilterSQL monta as condições do filtro, acrescentando os valores em args ("" sem filtros).
+func auditFilterSQL(f domain.AuditFilter, args *[]any) string {
+	var conds []string
+	add := func(cond string, v any) {
+		*args = append(*args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(*args)))
+	}
+	if f.Entity != nil {
+		add("entity = $%d", *f.Entity)
+	}
+	if f.EntityID != nil {
+		add("entity_id = $%d", *f.EntityID)
+	}
+	if f.Action != nil {
+		add("action = $%d", *f.Action)
+	}
+	if f.Actor != nil {
+		add("actor = $%d", *f.Actor)
+	}
+	if f.RequestID != nil {
+		add("request_id = $%d", *f.RequestID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at < $%d", *f.To)
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// whereClause devolve " WHERE conds" (ou "" se não houver condições).
+func whereClause(conds string) string {
+	if conds == "" {
+		return ""
+	}
+	return " WHERE " + conds
+}
+
+// List devolve uma página de eventos, do mais recente ao mais antigo, e o cursor da próxima (nil na última).
+// O cursor é o id do último evento, e não created_at como nas outras listagens: todas as mudanças de
+// uma transação (um lote, por exemplo) têm o mesmo created_at, e o id é único e crescente.
+func (repository *AuditRepository) List(ctx context.Context, f domain.AuditFilter, limit int, cursor *int64) ([]domain.AuditEvent, *int64, error) {
+	var args []any
+	conds := auditFilterSQL(f, &args)
+	if cursor != nil {
+		args = append(args, *cursor)
+		conds = joinConds(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+auditColumns+" FROM audit_events"+whereClause(conds)+fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.AuditEvent{}
+	for rows.Next() {
+		e, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(items) < limit {
+		return items, nil, nil
+	}
+	return items, &items[len(items)-1].ID, nil
+}
+
+// Stream percorre os eventos do filtro em ordem cronológica, chamando fn para cada um,
+// com um cursor do servidor (como CatRepository.Stream). Se fn retornar erro, a leitura para.
+func (repository *AuditRepository) Stream(ctx context.Context, f domain.AuditFilter, fn func(domain.AuditEvent) error) error {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // a transação é só leitura: Rollback fecha o cursor
+
+	if _, err := tx.Exec(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+		return err
+	}
+	var args []any
+	conds := auditFilterSQL(f, &args)
+	if _, err := tx.Exec(ctx, "DECLARE audit_export NO SCROLL CURSOR FOR SELECT "+auditColumns+" FROM audit_events"+whereClause(conds)+" ORDER BY id", args...); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM audit_export", streamFetchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return err
+		}
+		n := 0
+		for rows.Next() {
+			e, err := scanAuditEvent(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			n++
+			if err := fn(e); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if n < streamFetchSize {
+			return nil // cursor esgotado
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+// auditTableCall casa as chamadas SELECT audit_table('tabela', 'entidade') das migrações.
+var auditTableCall = regexp.MustCompile(`(?m)^SELECT audit_table\('(\w+)',\s*'(\w+)'\);`)
+
+func TestMigrationsAuditEntities(t *testing.T) {
+	files, err := filepath.Glob("../../db/migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("migrations: %v (%d arquivos)", err, len(files))
+	}
+	tables := map[string]string{} // tabela -> entidade
+	var entities []string
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range auditTableCall.FindAllStringSubmatch(string(b), -1) {
+			tables[m[1]] = m[2]
+			entities = append(entities, m[2])
+		}
+	}
+
+	tests := []struct{ table, entity string }{
+		{"cats", domain.AuditEntityCat},
+		{"owners", domain.AuditEntityOwner},
+		{"cat_notes", domain.AuditEntityNote},
+		{"tags", domain.AuditEntityTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			if got := tables[tt.table]; got != tt.entity {
+				t.Errorf("audit_table(%q) = %q, want %q", tt.table, got, tt.entity)
+			}
+		})
+	}
+	slices.Sort(entities)
+	want := slices.Sorted(slices.Values(domain.AuditEntities))
+	if !slices.Equal(entities, want) {
+		t.Errorf("entidades nas migrações = %v, domain.AuditEntities = %v", entities, want)
+	}
+}
+
+// TestOwnerChangesAudited roda contra um banco migrado (make migrate-up) em TEST_DB_DSN.
+func TestOwnerChangesAudited(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN não definido")
+	}
+	ctx := context.Background()
+	pg, err := NewPostgres(ctx, dsn, 2, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pg.Pool.Close()
+	owners, audit := NewOwnerRepository(pg.Pool), NewAuditRepository(pg.Pool)
+
+	ctx = domain.WithRequester(ctx, domain.Requester{Actor: "teste", RequestID: "req-audit"})
+	email := "audit-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "@example.com"
+	o, err := owners.Create(ctx, domain.OwnerCreate{Name: "Tutora", Email: email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "Tutora Nova"
+	if _, err := owners.Update(ctx, o.ID, domain.OwnerUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if err := owners.Delete(ctx, o.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	entity := domain.AuditEntityOwner
+	events, _, err := audit.List(ctx, domain.AuditFilter{Entity: &entity, EntityID: &o.ID}, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actions []string
+	for _, e := range events {
+		if e.Actor == nil || *e.Actor != "teste" {
+			t.Errorf("evento %s: actor = %v, want teste", e.Action, e.Actor)
+		}
+		actions = append(actions, e.Action)
+	}
+	if want := []string{domain.AuditDelete, domain.AuditUpdate, domain.AuditCreate}; !slices.Equal(actions, want) {
+		t.Errorf("ações = %v, want %v", actions, want)
+	}
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Catálogo de raças. Toda comparação de nomes passa pela função breed_key do
+banco (minúsculas, sem acentos, pontuação vira espaço) e pela view breed_names,
+que junta nome canônico, traduções e apelidos; assim a regra de normalização
+fica em um lugar só, o mesmo usado pela migração que classificou os gatos antigos.
+*/
+
+// BreedRepository lê o catálogo de raças.
+type BreedRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBreedRepository(db *pgxpool.Pool) *BreedRepository {
+	return &BreedRepository{db: db}
+}
+
+// breedColumns lê a raça com as traduções (objeto JSON idioma -> nome) e os apelidos agregados.
+const breedColumns = `b.id, b.slug, b.name,
+	COALESCE((SELECT json_object_agg(t.lang, t.name) FROM breed_translations t WHERE t.breed_id = b.id), '{}'::json),
+	COALESCE((SELECT json_agg(a.alias ORDER BY a.alias) FROM breed_aliases a WHERE a.breed_id = b.id), '[]'::json)`
+
+func breedDest(b *domain.Breed) []any {
+	return []any{&b.ID, &b.Slug, &b.Name, &b.Translations, &b.Aliases}
+}
+
+// Match procura a raça por nome, tradução ou apelido. Retorna domain.ErrBreedNotFound se nenhum casar.
+func (repository *BreedRepository) Match(ctx context.Context, name string) (domain.Breed, error) {
+	var b domain.Breed
+	err := repository.db.QueryRow(ctx,
+		`SELECT `+breedColumns+`
+		 FROM breed_names n JOIN breeds b ON b.id = n.breed_id
+		 WHERE n.key = breed_key($1) AND n.key <> ''
+		 ORDER BY n.kind LIMIT 1`,
+		name,
+	).Scan(breedDest(&b)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Breed{}, domain.ErrBreedNotFound
+	}
+	return b, err
+}
+
+// GetByID busca uma raça do catálogo.
+func (repository *BreedRepository) GetByID(ctx context.Context, id int64) (domain.Breed, error) {
+	var b domain.Breed
+	err := repository.db.QueryRow(ctx, "SELECT "+breedColumns+" FROM breeds b WHERE b.id = $1", id).Scan(breedDest(&b)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Breed{}, domain.ErrBreedNotFound
+	}
+	return b, err
+}
+
+// Search lista as raças com algum nome, tradução ou apelido começando por prefix (autocomplete),
+// em ordem alfabética. Match traz o primeiro nome que casou, preferindo o canônico.
+// Prefixo vazio lista o catálogo inteiro.
+func (repository *BreedRepository) Search(ctx context.Context, prefix string, limit int) ([]domain.Breed, error) {
+	rows, err := repository.db.Query(ctx,
+		`SELECT `+breedColumns+`, m.name
+		 FROM (
+			SELECT DISTINCT ON (n.breed_id) n.breed_id, n.name
+			FROM breed_names n
+			WHERE n.key LIKE breed_key($1) || '%'
+			ORDER BY n.breed_id, n.kind, n.name
+		 ) m JOIN breeds b ON b.id = m.breed_id
+		 ORDER BY b.name LIMIT $2`,
+		prefix, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.Breed
+	for rows.Next() {
+		var b domain.Breed
+		if err := rows.Scan(append(breedDest(&b), &b.Match)...); err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// Stats conta os gatos por raça do catálogo (com a média de peso), os textos de raça
+// fora do catálogo (os 50 mais frequentes) e os gatos sem raça.
+func (repository *BreedRepository) Stats(ctx context.Context) (domain.BreedStats, error) {
+	stats := domain.BreedStats{Breeds: []domain.BreedCount{}, Unrecognized: []domain.UnrecognizedBreed{}}
+
+	rows, err := repository.db.Query(ctx,
+		`SELECT b.id, b.name, count(c.id), count(c.id) FILTER (WHERE c.status = $1), round(avg(c.weight_kg), 2)
+		 FROM breeds b LEFT JOIN cats c ON c.breed_id = b.id
+		 GROUP BY b.id, b.name
+		 ORDER BY count(c.id) DESC, b.name`,
+		domain.StatusAvailable,
+	)
+	if err != nil {
+		return domain.BreedStats{}, err
+	}
+	for rows.Next() {
+		var bc domain.BreedCount
+		if err := rows.Scan(&bc.BreedID, &bc.Name, &bc.Cats, &bc.Available, &bc.AvgWeightKG); err != nil {
+			rows.Close()
+			return domain.BreedStats{}, err
+		}
+		stats.Breeds = append(stats.Breeds, bc)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.BreedStats{}, err
+	}
+
+	rows, err = repository.db.Query(ctx,
+		`SELECT breed, count(*) FROM cats WHERE breed_unrecognized
+		 GROUP BY breed ORDER BY count(*) DESC, breed LIMIT 50`,
+	)
+	if err != nil {
+		return domain.BreedStats{}, err
+	}
+	for rows.Next() {
+		var u domain.UnrecognizedBreed
+		if err := rows.Scan(&u.Breed, &u.Cats); err != nil {
+			rows.Close()
+			return domain.BreedStats{}, err
+		}
+		stats.Unrecognized = append(stats.Unrecognized, u)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.BreedStats{}, err
+	}
+
+	err = repository.db.QueryRow(ctx, "SELECT count(*) FROM cats WHERE breed IS NULL").Scan(&stats.WithoutBreed)
+	return stats, err
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// Batch executa um lote de operações de criação/atualização/remoção.
+//
+// atomic=true: todas as operações vão em um único pgx.Batch (um round-trip) dentro de
+// uma transação. Na primeira falha a transação é desfeita e o erro retornado é um
+// *domain.BatchError com o índice da operação que falhou.
+//
+// atomic=false: cada operação roda de forma independente (em sua própria transação) e o erro de cada
+// uma fica em CatBatchResult.Err; o erro retornado só é preenchido em falhas gerais.
+func (repository *CatRepository) Batch(ctx context.Context, ops []domain.CatBatchOp, atomic bool) ([]domain.CatBatchResult, error) {
+	if !atomic {
+		return repository.batchPartial(ctx, ops), nil
+	}
+
+	tx, err := beginAudited(ctx, repository.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	// Enfileira todas as operações e envia de uma vez.
+	// Uma data de nascimento inválida falha antes de qualquer envio.
+	b := &pgx.Batch{}
+	for i, op := range ops {
+		switch op.Op {
+		case domain.BatchOpCreate:
+			args, err := insertCatArgs(*op.Cat)
+			if err != nil {
+				return nil, &domain.BatchError{Index: i, Err: err}
+			}
+			b.Queue(insertCatSQL, args...)
+		case domain.BatchOpUpdate:
+			args, err := updateCatArgs(op.ID, op.Version, *op.Changes)
+			if err != nil {
+				return nil, &domain.BatchError{Index: i, Err: err}
+			}
+			b.Queue(updateCatSQL, args...)
+		case domain.BatchOpDelete:
+			b.Queue(deleteCatSQL, op.ID, op.Version)
+		}
+	}
+
+	results := make([]domain.CatBatchResult, len(ops))
+	br := tx.SendBatch(ctx, b)
+	for i, op := range ops {
+		results[i] = domain.CatBatchResult{Index: i, Op: op.Op}
+		var opErr error
+		switch op.Op {
+		case domain.BatchOpCreate, domain.BatchOpUpdate:
+			c, err := scanCat(br.QueryRow())
+			if err == nil {
+				results[i].Cat = &c
+			}
+			opErr = err
+		case domain.BatchOpDelete:
+			tag, err := br.Exec()
+			if err == nil && tag.RowsAffected() == 0 {
+				err = pgx.ErrNoRows
+			}
+			opErr = err
+		}
+		if opErr == nil {
+			continue
+		}
+
+		// Fecha o batch antes de usar a transação de novo
+		_ = br.Close()
+		switch {
+		case errors.Is(opErr, pgx.ErrNoRows):
+			// UPDATE/DELETE sem linhas afetadas: gato inexistente ou versão divergente
+			opErr = missOrConflict(ctx, tx, op.ID)
+		case op.Op == domain.BatchOpCreate:
+			opErr = catWriteErr(ctx, repository.db, opErr, op.Cat.Microchip)
+		case op.Op == domain.BatchOpUpdate:
+			opErr = catWriteErr(ctx, repository.db, opErr, op.Changes.Microchip)
+		}
+		return nil, &domain.BatchError{Index: i, Err: opErr}
+	}
+	if err := br.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// batchPartial executa cada operação isoladamente, registrando o erro de cada uma.
+func (repository *CatRepository) batchPartial(ctx context.Context, ops []domain.CatBatchOp) []domain.CatBatchResult {
+	results := make([]domain.CatBatchResult, len(ops))
+	for i, op := range ops {
+		results[i] = domain.CatBatchResult{Index: i, Op: op.Op}
+		switch op.Op {
+		case domain.BatchOpCreate:
+			c, err := repository.Create(ctx, *op.Cat)
+			results[i].Cat, results[i].Err = &c, err
+		case domain.BatchOpUpdate:
+			c, err := repository.Update(ctx, op.ID, op.Version, *op.Changes)
+			results[i].Cat, results[i].Err = &c, err
+		case domain.BatchOpDelete:
+			results[i].Err = repository.Delete(ctx, op.ID, op.Version)
+		}
+		if results[i].Err != nil {
+			results[i].Cat = nil
+		}
+	}
+	return results
+}
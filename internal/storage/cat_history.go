@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+/*
+Histórico de versões (cats_history). As versões são gravadas pelo trigger
+cats_record_history() (migração 0017); aqui só se lê o histórico e se reverte.
+
+Cada versão guarda a linha inteira em JSON (data). jsonb_populate_record a
+transforma de volta em uma linha de cats, então as leituras reaproveitam as
+mesmas colunas (e o mesmo Scan) das consultas em cats. Colunas criadas depois
+de uma versão voltam NULL nela.
+*/
+
+// catSnapshotSQL expõe as versões como linhas de cats (c), ao lado dos dados da versão (h).
+const catSnapshotSQL = "cats_history h CROSS JOIN LATERAL jsonb_populate_record(NULL::cats, h.data) AS c"
+
+// Versions devolve as versões do gato, da mais recente à mais antiga, e o cursor da próxima
+// página (nil na última). O cursor é o número da última versão da página.
+// O histórico continua disponível depois que o gato é removido; sem nenhuma versão, domain.ErrNotFound.
+func (repository *CatRepository) Versions(ctx context.Context, id int64, limit int, cursor *int64) ([]domain.CatVersion, *int64, error) {
+	args := []any{id, limit}
+	conds := "h.cat_id = $1"
+	if cursor != nil {
+		args = append(args, *cursor)
+		conds = joinConds(conds, "h.version < $3")
+	}
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+qualify("c", catColumns)+", h.valid_from, h.valid_to FROM "+catSnapshotSQL+
+			" WHERE "+conds+" ORDER BY h.version DESC LIMIT $2",
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.CatVersion{}
+	for rows.Next() {
+		var v domain.CatVersion
+		if err := rows.Scan(append(catDest(&v.Cat), &v.ValidFrom, &v.ValidTo)...); err != nil {
+			return nil, nil, err
+		}
+		v.Version = v.Cat.Version
+		items = append(items, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 && cursor == nil {
+		return nil, nil, domain.ErrNotFound
+	}
+	if len(items) < limit {
+		return items, nil, nil
+	}
+	return items, &items[len(items)-1].Version, nil
+}
+
+// GetAsOf busca o gato como ele estava no instante at: a versão que era a atual naquele momento.
+// Retorna domain.ErrNotFound se o gato ainda não existia (ou já tinha sido removido) em at.
+// Só lê colunas: miniaturas e etiquetas não têm histórico (a projeção não deve pedi-las).
+func (repository *CatRepository) GetAsOf(ctx context.Context, id int64, at time.Time, p domain.CatProjection) (domain.Cat, error) {
+	cols, dest, err := catSelect(p)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	// A subconsulta se chama cats para que as colunas da projeção valham como em GetByID
+	row := repository.db.QueryRow(ctx,
+		"SELECT "+cols+" FROM (SELECT c.* FROM "+catSnapshotSQL+
+			" WHERE h.cat_id = $1 AND h.valid_from <= $2 AND (h.valid_to IS NULL OR h.valid_to > $2)) AS cats",
+		id, at,
+	)
+	var c domain.Cat
+	if err := row.Scan(dest(&c)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Cat{}, domain.ErrNotFound
+		}
+		return domain.Cat{}, err
+	}
+	return c, nil
+}
+
+// revertCatSQL copia para o gato os campos do cadastro guardados na versão $2, como uma versão nova.
+// status fica como está: ele só muda pelas transições de adoção (POST /cats/{id}/status).
+var revertCatSQL = `UPDATE cats SET
+			name                 = c.name,
+			birth_date           = c.birth_date,
+			birth_date_precision = c.birth_date_precision,
+			breed                = c.breed,
+			breed_id             = c.breed_id,
+			breed_unrecognized   = c.breed_unrecognized,
+			coat_color           = c.coat_color,
+			coat_colors          = c.coat_colors,
+			coat_pattern         = c.coat_pattern,
+			weight_kg            = c.weight_kg,
+			microchip            = c.microchip,
+			sire_id              = c.sire_id,
+			dam_id               = c.dam_id,
+			litter_id            = c.litter_id,
+			attributes           = c.attributes,
+			version              = cats.version + 1
+		FROM ` + catSnapshotSQL + `
+		WHERE cats.id = $1 AND h.cat_id = $1 AND h.version = $2 AND ($3 = 0 OR cats.version = $3)
+		RETURNING ` + qualify("cats", catColumns)
+
+// Revert restaura o conteúdo da versão informada como uma nova versão do gato (o histórico não é
+// reescrito). expectedVersion > 0 ativa a checagem otimista, como em Update.
+// Retorna domain.ErrNotFound se o gato não existir, domain.ErrCatVersionNotFound se a versão não
+// existir e domain.ErrVersionMismatch se a versão atual divergir de expectedVersion.
+func (repository *CatRepository) Revert(ctx context.Context, id, version, expectedVersion int64) (domain.Cat, error) {
+	tx, err := beginAudited(ctx, repository.db)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	// O microchip da versão serve para apontar o gato que o usa agora, se houver conflito
+	var chip *string
+	err = tx.QueryRow(ctx, "SELECT data->>'microchip' FROM cats_history WHERE cat_id = $1 AND version = $2", id, version).Scan(&chip)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if err := catExists(ctx, tx, id); err != nil {
+			return domain.Cat{}, err
+		}
+		return domain.Cat{}, fmt.Errorf("%w: %d", domain.ErrCatVersionNotFound, version)
+	}
+	if err != nil {
+		return domain.Cat{}, err
+	}
+
+	c, err := scanCat(tx.QueryRow(ctx, revertCatSQL, id, version, expectedVersion))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Cat{}, missOrConflict(ctx, tx, id)
+	}
+	if err != nil {
+		return domain.Cat{}, catWriteErr(ctx, repository.db, err, chip)
+	}
+	return c, tx.Commit(ctx)
+}
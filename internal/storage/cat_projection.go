@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+/*
+Sparse fieldsets (fields=id,name) e relações embutidas (include=thumbnails,tags).
+
+Os campos pedidos viram a lista de colunas do SELECT: o banco só lê e envia o
+que o cliente vai usar. id, created_at e version são sempre lidos, pois a
+paginação (cursor) e o ETag dependem deles; a resposta descarta o que não foi pedido.
+
+As miniaturas (em JSON) e as etiquetas (em array) vêm na mesma consulta, agregadas por
+subconsultas correlacionadas, em vez de um SELECT por gato (o problema N+1).
+*/
+
+// catFieldColumns associa cada campo de domain.CatFields à coluna e ao destino do Scan.
+var catFieldColumns = map[string]struct {
+	column string
+	dest   func(c *domain.Cat) any
+}{
+	"id":                   {"id", func(c *domain.Cat) any { return &c.ID }},
+	"name":                 {"name", func(c *domain.Cat) any { return &c.Name }},
+	"birth_date":           {"birth_date", func(c *domain.Cat) any { return &catBirth{c} }},
+	"birth_date_precision": {"birth_date_precision", func(c *domain.Cat) any { return &c.BirthDatePrecision }},
+	"age_years":            {"birth_date", func(c *domain.Cat) any { return &catBirth{c} }}, // idade calculada na leitura
+	"age_months":           {"birth_date", func(c *domain.Cat) any { return &catBirth{c} }},
+	"breed":                {"breed", func(c *domain.Cat) any { return &c.Breed }},
+	"breed_id":             {"breed_id", func(c *domain.Cat) any { return &c.BreedID }},
+	"breed_unrecognized":   {"breed_unrecognized", func(c *domain.Cat) any { return &c.BreedUnrecognized }},
+	"coat_color":           {"coat_color", func(c *domain.Cat) any { return &c.CoatColor }},
+	"coat_colors":          {"coat_colors", func(c *domain.Cat) any { return &c.CoatColors }},
+	"coat_pattern":         {"coat_pattern", func(c *domain.Cat) any { return &c.CoatPattern }},
+	"weight_kg":            {"weight_kg", func(c *domain.Cat) any { return &c.WeightKG }},
+	"microchip":            {"microchip", func(c *domain.Cat) any { return &c.Microchip }},
+	"sire_id":              {"sire_id", func(c *domain.Cat) any { return &c.SireID }},
+	"dam_id":               {"dam_id", func(c *domain.Cat) any { return &c.DamID }},
+	"litter_id":            {"litter_id", func(c *domain.Cat) any { return &c.LitterID }},
+	"attributes":           {"attributes", func(c *domain.Cat) any { return &c.Attributes }},
+	"status":               {"status", func(c *domain.Cat) any { return &c.Status }},
+	"created_at":           {"created_at", func(c *domain.Cat) any { return &c.CreatedAt }},
+	"updated_at":           {"updated_at", func(c *domain.Cat) any { return &c.UpdatedAt }},
+	"version":              {"version", func(c *domain.Cat) any { return &c.Version }},
+}
+
+// catAlwaysFields são lidos mesmo quando não pedidos (cursor e ETag).
+var catAlwaysFields = []string{"id", "created_at", "version"}
+
+// thumbnailsSQL agrega as miniaturas de cada gato em um array JSON (vazio se não houver).
+const thumbnailsSQL = `COALESCE((
+		SELECT json_agg(json_build_object('id', t.id, 'path', t.path, 'created_at', t.created_at) ORDER BY t.id)
+		FROM cat_thumbnails t WHERE t.cat_id = cats.id
+	), '[]'::json)`
+
+// tagsSQL agrega os nomes das etiquetas de cada gato, em ordem alfabética (vazio se não houver).
+const tagsSQL = `ARRAY(
+		SELECT t.name FROM cat_tags ct JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.cat_id = cats.id ORDER BY t.key
+	)`
+
+// catSelect monta a lista de colunas do SELECT para a projeção e a função
+// que devolve os destinos do Scan na mesma ordem.
+func catSelect(p domain.CatProjection) (string, func(c *domain.Cat) []any, error) {
+	fields := p.Fields
+	if len(fields) == 0 {
+		fields = domain.CatFields
+	}
+	for _, f := range catAlwaysFields {
+		if !slices.Contains(fields, f) {
+			fields = append(slices.Clip(fields), f)
+		}
+	}
+
+	cols := make([]string, 0, len(fields)+1)
+	dests := make([]func(c *domain.Cat) any, 0, len(fields)+1)
+	for _, f := range fields {
+		fc, ok := catFieldColumns[f]
+		if !ok {
+			return "", nil, fmt.Errorf("campo desconhecido: %s", f)
+		}
+		cols = append(cols, fc.column)
+		dests = append(dests, fc.dest)
+	}
+	if p.Thumbnails {
+		cols = append(cols, thumbnailsSQL)
+		dests = append(dests, func(c *domain.Cat) any { return &c.Thumbnails })
+	}
+	if p.Tags {
+		cols = append(cols, tagsSQL)
+		dests = append(dests, func(c *domain.Cat) any { return &c.Tags })
+	}
+
+	scan := func(c *domain.Cat) []any {
+		out := make([]any, len(dests))
+		for i, d := range dests {
+			out[i] = d(c)
+		}
+		return out
+	}
+	return strings.Join(cols, ", "), scan, nil
+}
@@ -2,14 +2,26 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dya-andrade/cat-api/internal/domain"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// querier é o que pgxpool.Pool e pgx.Tx têm em comum.
+// Permite que a mesma função rode direto no pool ou dentro de uma transação.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 /*
 * indica ponteiro, ou seja, uma referência para o valor de uma variável (pode ser nulo).
 & obtém o endereço de memória de uma variável, criando um ponteiro para ela.
@@ -19,6 +31,34 @@ Exemplo:
 &CatRepository{db: db} cria um ponteiro para a struct inicializada.
 */
 
+// catColumns lista as colunas retornadas em todas as consultas de gatos,
+// na mesma ordem esperada por scanCat.
+const catColumns = "id, name, birth_date, birth_date_precision, breed, breed_id, breed_unrecognized, coat_color, coat_colors, coat_pattern, weight_kg, microchip, sire_id, dam_id, litter_id, attributes, status, created_at, updated_at, version"
+
+// catDest devolve os destinos do Scan para catColumns (útil quando a linha traz outras colunas depois).
+func catDest(c *domain.Cat) []any {
+	return []any{&c.ID, &c.Name, &catBirth{c}, &c.BirthDatePrecision, &c.Breed, &c.BreedID, &c.BreedUnrecognized, &c.CoatColor, &c.CoatColors, &c.CoatPattern, &c.WeightKG, &c.Microchip, &c.SireID, &c.DamID, &c.LitterID, &c.Attributes, &c.Status, &c.CreatedAt, &c.UpdatedAt, &c.Version}
+}
+
+// catBirth é o destino do Scan de birth_date: guarda a data e já calcula a idade
+// (age_years e age_months), que não é gravada no banco.
+type catBirth struct{ c *domain.Cat }
+
+func (b *catBirth) Scan(src any) error {
+	if err := b.c.BirthDate.Scan(src); err != nil {
+		return err
+	}
+	b.c.AgeYears, b.c.AgeMonths = domain.AgeOn(b.c.BirthDate, domain.Today())
+	return nil
+}
+
+// scanCat lê uma linha (pgx.Row ou pgx.Rows) e preenche a struct Cat.
+func scanCat(row pgx.Row) (domain.Cat, error) {
+	var c domain.Cat
+	err := row.Scan(catDest(&c)...)
+	return c, err
+}
+
 type CatRepository struct {
 	db *pgxpool.Pool // Conexão com o banco de dados PostgreSQL
 }
@@ -37,43 +77,50 @@ Assim, evita travamentos e libera recursos corretamente.
 func (repository *CatRepository) Create(ctx context.Context, in domain.CatCreate) (domain.Cat, error) {
 	// Cria um novo registro de gato no banco de dados
 
-	row := repository.db.QueryRow(
-		ctx,
-		// Executa o comando SQL para inserir um novo gato e retorna os dados inseridos
-		"INSERT INTO cats (name, age_years, breed, coat_color, weight_kg) VALUES ($1,$2,$3,$4,$5) RETURNING id, name, age_years, breed, coat_color, weight_kg, created_at, updated_at",
-		in.Name, in.AgeYears, in.Breed, in.CoatColor, in.WeightKG,
-		// Passa os valores do novo gato para os parâmetros da query
-	)
-
-	var c domain.Cat
-	// Cria uma variável para armazenar o resultado retornado do banco
+	args, err := insertCatArgs(in)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	// A transação leva quem fez a requisição para o registro de auditoria (beginAudited)
+	tx, err := beginAudited(ctx, repository.db)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
 
-	err := row.Scan(&c.ID, &c.Name, &c.AgeYears, &c.Breed, &c.CoatColor, &c.WeightKG, &c.CreatedAt, &c.UpdatedAt)
-	// Lê os dados retornados pela query e preenche a struct Cat
+	// Executa o comando SQL para inserir um novo gato e retorna os dados inseridos
+	row := tx.QueryRow(ctx, insertCatSQL, args...)
 
-	return c, err
+	c, err := scanCat(row)
+	if err != nil {
+		// Microchip repetido vira um conflito com o ID do gato que já o usa; genealogia inválida, erro de domínio
+		return domain.Cat{}, catWriteErr(ctx, repository.db, err, in.Microchip)
+	}
+	return c, tx.Commit(ctx)
 	// Retorna o gato criado e um erro (se houver)
 }
 
-func (repository *CatRepository) GetByID(ctx context.Context, id int64) (domain.Cat, error) {
-	// Busca um gato pelo ID no banco de dados
+func (repository *CatRepository) GetByID(ctx context.Context, id int64, p domain.CatProjection) (domain.Cat, error) {
+	// Busca um gato pelo ID no banco de dados, lendo apenas as colunas da projeção
+	cols, dest, err := catSelect(p)
+	if err != nil {
+		return domain.Cat{}, err
+	}
 
 	row := repository.db.QueryRow(
 		ctx,
 		// Executa o comando SQL para selecionar o gato pelo ID
-		"SELECT id, name, age_years, breed, coat_color, weight_kg, created_at, updated_at FROM cats WHERE id=$1",
+		"SELECT "+cols+" FROM cats WHERE id=$1",
 		id,
 		// Passa o ID como parâmetro para a query
 	)
 
 	var c domain.Cat
-	// Cria uma variável para armazenar o resultado retornado do banco
-
-	if err := row.Scan(&c.ID, &c.Name, &c.AgeYears, &c.Breed, &c.CoatColor, &c.WeightKG, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	if err := row.Scan(dest(&c)...); err != nil {
 		// Lê os dados retornados pela query e preenche a struct Cat
 		if errors.Is(err, pgx.ErrNoRows) {
-			// Se não encontrar nenhum registro, retorna struct vazia e erro
-			return domain.Cat{}, err
+			// Se não encontrar nenhum registro, retorna struct vazia e ErrNotFound
+			return domain.Cat{}, domain.ErrNotFound
 		}
 		// Se ocorrer outro erro, retorna struct vazia e erro
 		return domain.Cat{}, err
@@ -83,29 +130,74 @@ func (repository *CatRepository) GetByID(ctx context.Context, id int64) (domain.
 	// Retorna o gato encontrado e nil para erro
 }
 
-// Paginação por cursor baseado em created_at/id
-func (repository *CatRepository) List(ctx context.Context, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) {
-	// Lista gatos com paginação usando cursor
+// catFilterSQL monta as condições do WHERE para o filtro, acrescentando os valores em args.
+// Retorna "" quando não há filtros.
+func catFilterSQL(f domain.CatFilter, args *[]any) string {
+	var conds []string
+	add := func(cond string, v any) {
+		*args = append(*args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(*args)))
+	}
+	if f.Name != nil {
+		add("name ILIKE '%%' || $%d || '%%'", *f.Name)
+	}
+	if f.Breed != nil {
+		// o texto gravado ou qualquer nome/apelido da raça no catálogo ("vira-lata" acha os SRD)
+		add("(lower(breed) = lower($%[1]d) OR breed_id = (SELECT breed_id FROM breed_names WHERE key = breed_key($%[1]d) ORDER BY kind LIMIT 1))", *f.Breed)
+	}
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if len(f.Coat) > 0 {
+		add("coat_colors @> $%d", f.Coat)
+	}
+	if len(f.Pattern) > 0 {
+		add("coat_pattern = ANY($%d)", f.Pattern)
+	}
+	if tags := tagKeys(f.Tags); len(tags) > 0 {
+		if f.TagMatch == domain.TagMatchAll {
+			add("(SELECT count(*) FROM cat_tags ct JOIN tags t ON t.id = ct.tag_id WHERE ct.cat_id = cats.id AND t.key = ANY($%[1]d)) = cardinality($%[1]d::text[])", tags)
+		} else {
+			add("EXISTS (SELECT 1 FROM cat_tags ct JOIN tags t ON t.id = ct.tag_id WHERE ct.cat_id = cats.id AND t.key = ANY($%d))", tags)
+		}
+	}
+	for _, a := range f.Attributes {
+		if a.Value == nil {
+			add("attributes ? $%d", a.Key)
+			continue
+		}
+		// o valor é comparado como texto (->>): "3", "true", "2020-01-31"
+		*args = append(*args, a.Key)
+		add(fmt.Sprintf("attributes ->> $%d = $%%d", len(*args)), *a.Value)
+	}
+	return strings.Join(conds, " AND ")
+}
 
-	var rows pgx.Rows
-	var err error
+// Paginação por cursor baseado em created_at
+func (repository *CatRepository) List(ctx context.Context, f domain.CatFilter, p domain.CatProjection, limit int, cursor *time.Time) ([]domain.Cat, *time.Time, error) {
+	// Lista gatos com paginação usando cursor, lendo apenas as colunas da projeção
+	cols, dest, err := catSelect(p)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if cursor == nil {
-		// Se não houver cursor, busca os primeiros registros
-		rows, err = repository.db.Query(
-			ctx,
-			"SELECT id, name, age_years, breed, coat_color, weight_kg, created_at, updated_at FROM cats ORDER BY created_at DESC LIMIT $1",
-			limit,
-		)
-	} else {
+	var args []any
+	where := catFilterSQL(f, &args)
+	if cursor != nil {
 		// Se houver cursor, busca registros após o cursor
-		rows, err = repository.db.Query(
-			ctx,
-			"SELECT id, name, age_years, breed, coat_color, weight_kg, created_at, updated_at FROM cats WHERE created_at < $1 ORDER BY created_at DESC LIMIT $2",
-			*cursor,
-			limit,
-		)
+		args = append(args, *cursor)
+		where = joinConds(where, fmt.Sprintf("created_at < $%d", len(args)))
 	}
+	if where != "" {
+		where = " WHERE " + where
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(
+		ctx,
+		"SELECT "+cols+" FROM cats"+where+fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args)),
+		args...,
+	)
 
 	// Verifica se houve erro na consulta
 	if err != nil {
@@ -120,12 +212,15 @@ func (repository *CatRepository) List(ctx context.Context, limit int, cursor *ti
 
 	for rows.Next() {
 		var c domain.Cat
-		if err := rows.Scan(&c.ID, &c.Name, &c.AgeYears, &c.Breed, &c.CoatColor, &c.WeightKG, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err := rows.Scan(dest(&c)...); err != nil {
 			return nil, nil, err
 		}
 		cats = append(cats, c)
 		lastCreatedAt = &c.CreatedAt // Atualiza o último created_at encontrado
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
 
 	if len(cats) == 0 {
 		return nil, nil, nil // Retorna nil se não encontrar gatos
@@ -133,3 +228,294 @@ func (repository *CatRepository) List(ctx context.Context, limit int, cursor *ti
 
 	return cats, lastCreatedAt, nil // Retorna a lista de gatos e o último created_at
 }
+
+// joinConds junta duas condições SQL com AND, ignorando as vazias.
+func joinConds(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + " AND " + b
+}
+
+// Update aplica uma atualização parcial (campos nil mantêm o valor atual) e incrementa a versão.
+// expectedVersion > 0 ativa a checagem otimista: o UPDATE só acontece se a versão no banco for a mesma.
+// Retorna domain.ErrNotFound se o gato não existir e domain.ErrVersionMismatch se a versão divergir.
+func (repository *CatRepository) Update(ctx context.Context, id, expectedVersion int64, in domain.CatUpdate) (domain.Cat, error) {
+	tx, err := beginAudited(ctx, repository.db)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	c, err := updateCat(ctx, tx, repository.db, id, expectedVersion, in)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	return c, tx.Commit(ctx)
+}
+
+// Delete remove um gato. Assim como Update, respeita expectedVersion quando > 0.
+func (repository *CatRepository) Delete(ctx context.Context, id, expectedVersion int64) error {
+	tx, err := beginAudited(ctx, repository.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	if err := deleteCat(ctx, tx, id, expectedVersion); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// AddThumbnail registra uma miniatura gerada para o gato (domain.ErrNotFound se ele foi removido antes).
+func (repository *CatRepository) AddThumbnail(ctx context.Context, catID int64, path string) (domain.CatThumbnail, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return domain.CatThumbnail{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	t, err := addThumbnail(ctx, tx, catID, path)
+	if err != nil {
+		return domain.CatThumbnail{}, err
+	}
+	return t, tx.Commit(ctx)
+}
+
+// addThumbnail trava a linha do gato antes de gravar a miniatura. A chave estrangeira só pega
+// um KEY SHARE, que não conflita com o UPDATE de Update: sem o lock, o evento cat.thumbnail_ready
+// (gravado por trigger) poderia ganhar um id fora da ordem de confirmação de um cat.updated
+// concorrente do mesmo gato. Com ele, as escritas no gato entram na outbox uma depois da outra.
+func addThumbnail(ctx context.Context, q querier, catID int64, path string) (domain.CatThumbnail, error) {
+	var id int64
+	err := q.QueryRow(ctx, "SELECT id FROM cats WHERE id = $1 FOR NO KEY UPDATE", catID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.CatThumbnail{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.CatThumbnail{}, err
+	}
+
+	// A mesma miniatura pode ser agendada duas vezes (pela criação e pela varredura): com a linha
+	// do gato travada, só a primeira grava (e só ela gera o evento)
+	var t domain.CatThumbnail
+	err = q.QueryRow(ctx,
+		`INSERT INTO cat_thumbnails (cat_id, path)
+		 SELECT $1, $2 WHERE NOT EXISTS (SELECT 1 FROM cat_thumbnails WHERE cat_id = $1 AND path = $2)
+		 RETURNING id, path, created_at`,
+		catID, path,
+	).Scan(&t.ID, &t.Path, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = q.QueryRow(ctx,
+			"SELECT id, path, created_at FROM cat_thumbnails WHERE cat_id = $1 AND path = $2 ORDER BY id LIMIT 1",
+			catID, path,
+		).Scan(&t.ID, &t.Path, &t.CreatedAt)
+	}
+	return t, err
+}
+
+// CatsWithoutThumbnail devolve até limit gatos criados antes de before que ainda não têm miniatura,
+// do mais antigo ao mais novo.
+func (repository *CatRepository) CatsWithoutThumbnail(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	rows, err := repository.db.Query(ctx,
+		`SELECT c.id FROM cats c
+		  WHERE c.created_at < $1 AND NOT EXISTS (SELECT 1 FROM cat_thumbnails t WHERE t.cat_id = c.id)
+		  ORDER BY c.id LIMIT $2`,
+		before, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+const (
+	insertCatSQL = `INSERT INTO cats (name, birth_date, birth_date_precision, breed, coat_color, weight_kg, breed_id, coat_colors, coat_pattern, microchip, sire_id, dam_id, litter_id, attributes)
+		VALUES ($1,$2,$3,NULLIF($4, ''),NULLIF($5, ''),$6,$7,COALESCE($8, '{}'::text[]),$9,$10,$11,$12,$13,jsonb_strip_nulls(COALESCE($14::jsonb, '{}'))) RETURNING ` + catColumns
+	updateCatSQL = `UPDATE cats SET
+			name                 = COALESCE($3, name),
+			birth_date           = COALESCE($4, birth_date),
+			birth_date_precision = COALESCE($5, birth_date_precision),
+			breed                = CASE WHEN $6::text IS NULL THEN breed ELSE NULLIF($6, '') END,
+			coat_color           = CASE WHEN $7::text IS NULL THEN coat_color ELSE NULLIF($7, '') END,
+			weight_kg            = COALESCE($8, weight_kg),
+			breed_id             = CASE WHEN $6::text IS NULL THEN breed_id ELSE $9::bigint END,
+			coat_colors          = COALESCE($10, coat_colors),
+			coat_pattern         = COALESCE($11, coat_pattern),
+			microchip            = CASE WHEN $12::text IS NULL THEN microchip ELSE NULLIF($12, '') END,
+			sire_id              = CASE WHEN $13::bigint IS NULL THEN sire_id ELSE NULLIF($13, 0) END,
+			dam_id               = CASE WHEN $14::bigint IS NULL THEN dam_id ELSE NULLIF($14, 0) END,
+			litter_id            = CASE WHEN $15::bigint IS NULL THEN litter_id ELSE NULLIF($15, 0) END,
+			attributes           = CASE WHEN $16::jsonb IS NULL THEN attributes ELSE jsonb_strip_nulls(attributes || $16::jsonb) END,
+			version              = version + 1
+		WHERE id = $1 AND ($2 = 0 OR version = $2)
+		RETURNING ` + catColumns
+	deleteCatSQL = "DELETE FROM cats WHERE id = $1 AND ($2 = 0 OR version = $2)"
+)
+
+// insertCatArgs devolve os parâmetros de insertCatSQL, com a data de nascimento já resolvida
+// (domain.ErrInvalidBirthDate se ela for inválida), a pelagem conferida (domain.ErrInvalidCoat)
+// e o microchip normalizado (domain.ErrInvalidMicrochip).
+func insertCatArgs(in domain.CatCreate) ([]any, error) {
+	birth, precision, err := in.ResolveBirth(domain.Today())
+	if err != nil {
+		return nil, err
+	}
+	if err := in.CheckCoat(); err != nil {
+		return nil, err
+	}
+	chip, err := microchipArg(in.Microchip)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := attributesArg(in.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return []any{in.Name, birth, precision, in.Breed, in.CoatColor, in.WeightKG, in.BreedID, in.CoatColors, in.CoatPattern, chip, in.SireID, in.DamID, in.LitterID, attrs}, nil
+}
+
+// updateCatArgs devolve os parâmetros de updateCatSQL (campos nil mantêm o valor atual).
+// breed_id acompanha breed: quando breed muda, breed_id passa a ser in.BreedID (nil = fora do catálogo).
+// breed, coat_color e microchip vazios limpam o campo, como sire_id, dam_id e litter_id iguais a 0;
+// attributes é mesclado aos atuais (null remove a chave).
+func updateCatArgs(id, expectedVersion int64, in domain.CatUpdate) ([]any, error) {
+	birth, precision, err := in.ResolveBirth(domain.Today())
+	if err != nil {
+		return nil, err
+	}
+	if err := in.CheckCoat(); err != nil {
+		return nil, err
+	}
+	var chip *string
+	if in.Microchip != nil && strings.TrimSpace(*in.Microchip) == "" {
+		chip = new(string) // vazio remove o microchip (NULLIF no SQL)
+	} else if chip, err = microchipArg(in.Microchip); err != nil {
+		return nil, err
+	}
+	attrs, err := attributesArg(in.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return []any{id, expectedVersion, in.Name, birth, precision, in.Breed, in.CoatColor, in.WeightKG, in.BreedID, in.CoatColors, in.CoatPattern, chip, in.SireID, in.DamID, in.LitterID, attrs}, nil
+}
+
+// attributesArg serializa os atributos para a coluna JSONB (vazio = nil, mantém os atuais).
+// Os valores já vêm conferidos pelo serviço contra o esquema do tenant.
+func attributesArg(attrs map[string]any) (*string, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidAttributes, err)
+	}
+	s := string(b)
+	return &s, nil
+}
+
+// microchipArg normaliza o microchip informado (nil continua nil).
+func microchipArg(raw *string) (*string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	chip, err := domain.NormalizeMicrochip(*raw)
+	if err != nil {
+		return nil, err
+	}
+	return &chip, nil
+}
+
+// catWriteErr traduz os erros de escrita em cats para erros de domínio: genealogia inválida
+// (pai, mãe ou ninhada inexistente, pais diferentes dos da ninhada, ciclo; ver a migração 0013)
+// e microchip repetido (microchipConflict). Outros erros passam direto.
+func catWriteErr(ctx context.Context, q querier, err error, chip *string) error {
+	switch code, constraint := pgError(err); {
+	case code == pgForeignKeyViolation && constraint == "cats_sire_id_fkey":
+		return fmt.Errorf("%w: sire_id", domain.ErrParentNotFound)
+	case code == pgForeignKeyViolation && constraint == "cats_dam_id_fkey":
+		return fmt.Errorf("%w: dam_id", domain.ErrParentNotFound)
+	case code == pgForeignKeyViolation && constraint == "cats_litter_id_fkey":
+		return domain.ErrLitterNotFound
+	case code == pgCheckViolation && constraint == "cats_lineage_cycle":
+		return fmt.Errorf("%w: o gato seria ancestral de si mesmo", domain.ErrLineageCycle)
+	case code == pgCheckViolation && constraint == "cats_parents_check":
+		return fmt.Errorf("%w: o gato não pode ser pai/mãe de si mesmo, nem ter o mesmo gato como pai e mãe", domain.ErrInvalidLineage)
+	case code == pgCheckViolation && constraint == "cats_litter_parents":
+		return fmt.Errorf("%w: pai/mãe diferentes dos da ninhada", domain.ErrInvalidLineage)
+	}
+	return microchipConflict(ctx, q, err, chip)
+}
+
+// microchipConflict troca a violação de cats_microchip_key por um *domain.MicrochipConflictError
+// com o ID do gato que já usa o microchip. Outros erros passam direto.
+// q precisa enxergar o banco fora da transação que falhou (ela já está abortada).
+func microchipConflict(ctx context.Context, q querier, err error, raw *string) error {
+	if code, constraint := pgError(err); code != pgUniqueViolation || constraint != "cats_microchip_key" || raw == nil {
+		return err
+	}
+	chip, _ := domain.NormalizeMicrochip(*raw)
+	conflict := &domain.MicrochipConflictError{Microchip: chip}
+	// sem linha: o conflito é com outra operação do mesmo lote, que não chegou a ser gravada
+	if qerr := q.QueryRow(ctx, "SELECT id FROM cats WHERE microchip = $1", chip).Scan(&conflict.CatID); qerr != nil && !errors.Is(qerr, pgx.ErrNoRows) {
+		return qerr
+	}
+	return conflict
+}
+
+// updateCat aplica a atualização com q. lookup precisa enxergar o banco fora de q, que já estará
+// abortada quando catWriteErr for procurar o dono de um microchip repetido.
+func updateCat(ctx context.Context, q, lookup querier, id, expectedVersion int64, in domain.CatUpdate) (domain.Cat, error) {
+	args, err := updateCatArgs(id, expectedVersion, in)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	row := q.QueryRow(ctx, updateCatSQL, args...)
+	c, err := scanCat(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Nenhuma linha afetada: descobre se o gato não existe ou se a versão mudou
+		return domain.Cat{}, missOrConflict(ctx, q, id)
+	}
+	if err != nil {
+		return domain.Cat{}, catWriteErr(ctx, lookup, err, in.Microchip)
+	}
+	return c, nil
+}
+
+func deleteCat(ctx context.Context, q querier, id, expectedVersion int64) error {
+	tag, err := q.Exec(ctx, deleteCatSQL, id, expectedVersion)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return missOrConflict(ctx, q, id)
+	}
+	return nil
+}
+
+// missOrConflict é chamado quando um UPDATE/DELETE condicional não afetou linhas.
+// Se o gato existe, a falha foi de versão; caso contrário, ele não existe.
+func missOrConflict(ctx context.Context, q querier, id int64) error {
+	var exists bool
+	if err := q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM cats WHERE id=$1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrVersionMismatch
+	}
+	return domain.ErrNotFound
+}
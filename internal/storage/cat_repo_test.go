@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestUpdateCatArgsMicrochip(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		chip    *string
+		want    *string // nil = mantém o atual
+		wantErr error
+	}{
+		{"ausente mantém", nil, nil, nil},
+		{"vazio remove", str(""), str(""), nil},
+		{"só espaços remove", str("  "), str(""), nil},
+		{"normaliza", str("985 1120-0000.1234"), str("985112000001234"), nil},
+		{"inválido", str("12ab"), nil, domain.ErrInvalidMicrochip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := updateCatArgs(1, 0, domain.CatUpdate{Microchip: tt.chip})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := args[11].(*string) // $12 em updateCatSQL
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("microchip arg = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// recorder guarda os comandos recebidos; QueryRow devolve os erros de rows, na ordem.
+type recorder struct {
+	sql  []string
+	rows []error
+}
+
+func (r *recorder) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	r.sql = append(r.sql, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (r *recorder) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	r.sql = append(r.sql, sql)
+	return nil, errors.New("not supported")
+}
+
+func (r *recorder) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	r.sql = append(r.sql, sql)
+	var err error
+	if len(r.rows) > 0 {
+		err, r.rows = r.rows[0], r.rows[1:]
+	}
+	return recordedRow{err}
+}
+
+type recordedRow struct{ err error }
+
+func (r recordedRow) Scan(dest ...any) error { return r.err }
+
+func TestAddThumbnailLocksCat(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []error
+		wantErr error
+		inserts bool
+	}{
+		{"gato existe", nil, nil, true},
+		{"gato removido", []error{pgx.ErrNoRows}, domain.ErrNotFound, false},
+		{"miniatura já gravada", []error{nil, pgx.ErrNoRows}, nil, true}, // devolve a existente
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &recorder{rows: tt.rows}
+			_, err := addThumbnail(context.Background(), q, 7, "thumbnails/7.jpg")
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			// o lock vem antes do INSERT, que dispara o evento da outbox
+			if len(q.sql) == 0 || !strings.Contains(q.sql[0], "FOR NO KEY UPDATE") {
+				t.Fatalf("first statement = %v, want the row lock", q.sql)
+			}
+			inserted := slices.ContainsFunc(q.sql, func(s string) bool { return strings.HasPrefix(s, "INSERT INTO cat_thumbnails") })
+			if inserted != tt.inserts {
+				t.Errorf("statements = %v", q.sql)
+			}
+		})
+	}
+}
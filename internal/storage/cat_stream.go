@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+)
+
+// streamFetchSize é quantas linhas são buscadas do cursor por vez.
+const streamFetchSize = 1000
+
+// Stream percorre todos os gatos que casam com o filtro, chamando fn para cada um,
+// sem carregar a tabela inteira em memória: usa um cursor do servidor (DECLARE/FETCH)
+// dentro de uma transação somente leitura. Se fn retornar erro, a leitura para.
+func (repository *CatRepository) Stream(ctx context.Context, f domain.CatFilter, fn func(domain.Cat) error) error {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // a transação é só leitura: Rollback fecha o cursor
+
+	if _, err := tx.Exec(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+		return err
+	}
+
+	var args []any
+	where := catFilterSQL(f, &args)
+	if where != "" {
+		where = " WHERE " + where
+	}
+	if _, err := tx.Exec(ctx, "DECLARE cats_export NO SCROLL CURSOR FOR SELECT "+catColumns+" FROM cats"+where+" ORDER BY id", args...); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM cats_export", streamFetchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return err
+		}
+		n := 0
+		for rows.Next() {
+			c, err := scanCat(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			n++
+			if err := fn(c); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if n < streamFetchSize {
+			return nil // cursor esgotado
+		}
+	}
+}
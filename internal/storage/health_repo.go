@@ -0,0 +1,375 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Prontuário dos gatos: cat_vaccinations, cat_vet_visits, cat_medications,
+cat_allergies e cat_neutering, além do relatório de vacinas a vencer
+(vaccinations_due_runs / vaccinations_due).
+
+Todas as tabelas de registros seguem o mesmo formato (id, cat_id, ..., created_at);
+listByCat e deleteRecord cuidam do que é comum entre elas.
+*/
+
+// HealthRepository persiste o prontuário dos gatos.
+type HealthRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewHealthRepository(db *pgxpool.Pool) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+// healthErr traduz erros de escrita nas tabelas do prontuário para erros de domínio.
+func healthErr(err error) error {
+	switch code, _ := pgError(err); code {
+	case pgForeignKeyViolation:
+		return domain.ErrNotFound // cat_id inexistente
+	case pgCheckViolation:
+		return domain.ErrHealthDates
+	case pgUniqueViolation:
+		return domain.ErrHealthRecordExists
+	}
+	return err
+}
+
+// listByCat executa a consulta (com o gato em $1) e lê cada linha com scan.
+// Uma lista vazia de um gato inexistente retorna domain.ErrNotFound.
+func listByCat[T any](ctx context.Context, q querier, sql string, scan func(pgx.Row) (T, error), catID int64, args ...any) ([]T, error) {
+	rows, err := q.Query(ctx, sql, append([]any{catID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []T{}
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, catExists(ctx, q, catID)
+	}
+	return items, nil
+}
+
+// deleteRecord remove o registro id do gato na tabela.
+// Retorna domain.ErrHealthRecordNotFound se ele não existir ou for de outro gato.
+func (repository *HealthRepository) deleteRecord(ctx context.Context, table string, catID, id int64) error {
+	tag, err := repository.db.Exec(ctx, "DELETE FROM "+table+" WHERE id=$1 AND cat_id=$2", id, catID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrHealthRecordNotFound
+	}
+	return nil
+}
+
+/*** vacinas ***/
+
+const vaccinationColumns = "id, cat_id, vaccine, administered_on, batch, next_due_on, vet, notes, created_at"
+
+func scanVaccination(row pgx.Row) (domain.Vaccination, error) {
+	var v domain.Vaccination
+	err := row.Scan(&v.ID, &v.CatID, &v.Vaccine, &v.AdministeredOn, &v.Batch, &v.NextDueOn, &v.Vet, &v.Notes, &v.CreatedAt)
+	return v, err
+}
+
+// Vaccinations lista as doses do gato, da mais recente para a mais antiga.
+func (repository *HealthRepository) Vaccinations(ctx context.Context, catID int64) ([]domain.Vaccination, error) {
+	return listByCat(ctx, repository.db,
+		"SELECT "+vaccinationColumns+" FROM cat_vaccinations WHERE cat_id=$1 ORDER BY administered_on DESC, id DESC",
+		scanVaccination, catID)
+}
+
+func (repository *HealthRepository) AddVaccination(ctx context.Context, catID int64, in domain.VaccinationCreate) (domain.Vaccination, error) {
+	v, err := scanVaccination(repository.db.QueryRow(ctx,
+		`INSERT INTO cat_vaccinations (cat_id, vaccine, administered_on, batch, next_due_on, vet, notes)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING `+vaccinationColumns,
+		catID, in.Vaccine, in.AdministeredOn, in.Batch, in.NextDueOn, in.Vet, in.Notes,
+	))
+	if err != nil {
+		return domain.Vaccination{}, healthErr(err)
+	}
+	return v, nil
+}
+
+func (repository *HealthRepository) DeleteVaccination(ctx context.Context, catID, id int64) error {
+	return repository.deleteRecord(ctx, "cat_vaccinations", catID, id)
+}
+
+/*** consultas ***/
+
+const vetVisitColumns = "id, cat_id, visited_on, reason, clinic, vet, diagnosis, notes, created_at"
+
+func scanVetVisit(row pgx.Row) (domain.VetVisit, error) {
+	var v domain.VetVisit
+	err := row.Scan(&v.ID, &v.CatID, &v.VisitedOn, &v.Reason, &v.Clinic, &v.Vet, &v.Diagnosis, &v.Notes, &v.CreatedAt)
+	return v, err
+}
+
+// VetVisits lista as consultas do gato, da mais recente para a mais antiga.
+func (repository *HealthRepository) VetVisits(ctx context.Context, catID int64) ([]domain.VetVisit, error) {
+	return listByCat(ctx, repository.db,
+		"SELECT "+vetVisitColumns+" FROM cat_vet_visits WHERE cat_id=$1 ORDER BY visited_on DESC, id DESC",
+		scanVetVisit, catID)
+}
+
+func (repository *HealthRepository) AddVetVisit(ctx context.Context, catID int64, in domain.VetVisitCreate) (domain.VetVisit, error) {
+	v, err := scanVetVisit(repository.db.QueryRow(ctx,
+		`INSERT INTO cat_vet_visits (cat_id, visited_on, reason, clinic, vet, diagnosis, notes)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING `+vetVisitColumns,
+		catID, in.VisitedOn, in.Reason, in.Clinic, in.Vet, in.Diagnosis, in.Notes,
+	))
+	if err != nil {
+		return domain.VetVisit{}, healthErr(err)
+	}
+	return v, nil
+}
+
+func (repository *HealthRepository) DeleteVetVisit(ctx context.Context, catID, id int64) error {
+	return repository.deleteRecord(ctx, "cat_vet_visits", catID, id)
+}
+
+/*** medicamentos ***/
+
+const medicationColumns = "id, cat_id, name, dosage, frequency, start_date, end_date, notes, created_at"
+
+func scanMedication(row pgx.Row) (domain.Medication, error) {
+	var m domain.Medication
+	err := row.Scan(&m.ID, &m.CatID, &m.Name, &m.Dosage, &m.Frequency, &m.StartDate, &m.EndDate, &m.Notes, &m.CreatedAt)
+	return m, err
+}
+
+// activeMedicationsSQL lista os tratamentos em curso na data $2.
+const activeMedicationsSQL = "SELECT " + medicationColumns + " FROM cat_medications" +
+	" WHERE cat_id=$1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $2) ORDER BY start_date DESC, id DESC"
+
+// Medications lista os tratamentos do gato. Com activeOn, só os que estão em curso nessa data.
+func (repository *HealthRepository) Medications(ctx context.Context, catID int64, activeOn *domain.Date) ([]domain.Medication, error) {
+	if activeOn != nil {
+		return listByCat(ctx, repository.db, activeMedicationsSQL, scanMedication, catID, *activeOn)
+	}
+	return listByCat(ctx, repository.db,
+		"SELECT "+medicationColumns+" FROM cat_medications WHERE cat_id=$1 ORDER BY start_date DESC, id DESC",
+		scanMedication, catID)
+}
+
+func (repository *HealthRepository) AddMedication(ctx context.Context, catID int64, in domain.MedicationCreate) (domain.Medication, error) {
+	m, err := scanMedication(repository.db.QueryRow(ctx,
+		`INSERT INTO cat_medications (cat_id, name, dosage, frequency, start_date, end_date, notes)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING `+medicationColumns,
+		catID, in.Name, in.Dosage, in.Frequency, in.StartDate, in.EndDate, in.Notes,
+	))
+	if err != nil {
+		return domain.Medication{}, healthErr(err)
+	}
+	return m, nil
+}
+
+func (repository *HealthRepository) DeleteMedication(ctx context.Context, catID, id int64) error {
+	return repository.deleteRecord(ctx, "cat_medications", catID, id)
+}
+
+/*** alergias ***/
+
+const allergyColumns = "id, cat_id, allergen, severity, reaction, created_at"
+
+func scanAllergy(row pgx.Row) (domain.Allergy, error) {
+	var a domain.Allergy
+	err := row.Scan(&a.ID, &a.CatID, &a.Allergen, &a.Severity, &a.Reaction, &a.CreatedAt)
+	return a, err
+}
+
+// allergiesSQL lista as alergias do gato, das mais graves para as mais leves.
+const allergiesSQL = "SELECT " + allergyColumns + " FROM cat_allergies" +
+	" WHERE cat_id=$1 ORDER BY array_position(ARRAY['severe','moderate','mild'], severity), allergen"
+
+func (repository *HealthRepository) Allergies(ctx context.Context, catID int64) ([]domain.Allergy, error) {
+	return listByCat(ctx, repository.db, allergiesSQL, scanAllergy, catID)
+}
+
+// AddAllergy registra uma alergia. Retorna domain.ErrHealthRecordExists se o alérgeno já estiver registrado.
+func (repository *HealthRepository) AddAllergy(ctx context.Context, catID int64, in domain.AllergyCreate) (domain.Allergy, error) {
+	a, err := scanAllergy(repository.db.QueryRow(ctx,
+		"INSERT INTO cat_allergies (cat_id, allergen, severity, reaction) VALUES ($1,$2,$3,$4) RETURNING "+allergyColumns,
+		catID, in.Allergen, in.Severity, in.Reaction,
+	))
+	if err != nil {
+		return domain.Allergy{}, healthErr(err)
+	}
+	return a, nil
+}
+
+func (repository *HealthRepository) DeleteAllergy(ctx context.Context, catID, id int64) error {
+	return repository.deleteRecord(ctx, "cat_allergies", catID, id)
+}
+
+/*** castração ***/
+
+// Neutering devolve a situação de castração (valor zero se não informada).
+func (repository *HealthRepository) Neutering(ctx context.Context, catID int64) (domain.Neutering, error) {
+	return neutering(ctx, repository.db, catID)
+}
+
+func neutering(ctx context.Context, q querier, catID int64) (domain.Neutering, error) {
+	var n domain.Neutering
+	err := q.QueryRow(ctx, "SELECT neutered, neutered_on, updated_at FROM cat_neutering WHERE cat_id=$1", catID).
+		Scan(&n.Neutered, &n.NeuteredOn, &n.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Neutering{}, catExists(ctx, q, catID)
+	}
+	return n, err
+}
+
+// SetNeutering grava (ou substitui) a situação de castração do gato.
+func (repository *HealthRepository) SetNeutering(ctx context.Context, catID int64, in domain.NeuteringUpdate) (domain.Neutering, error) {
+	var n domain.Neutering
+	err := repository.db.QueryRow(ctx,
+		`INSERT INTO cat_neutering (cat_id, neutered, neutered_on) VALUES ($1,$2,$3)
+		 ON CONFLICT (cat_id) DO UPDATE SET neutered = EXCLUDED.neutered, neutered_on = EXCLUDED.neutered_on, updated_at = now()
+		 RETURNING neutered, neutered_on, updated_at`,
+		catID, in.Neutered, in.NeuteredOn,
+	).Scan(&n.Neutered, &n.NeuteredOn, &n.UpdatedAt)
+	if err != nil {
+		return domain.Neutering{}, healthErr(err)
+	}
+	return n, nil
+}
+
+/*** resumo ***/
+
+// Summary monta o resumo do prontuário em today: castração, alergias, tratamentos em curso,
+// a dose mais recente de cada vacina e a última consulta.
+// As leituras usam uma transação somente leitura para que o resumo seja consistente.
+func (repository *HealthRepository) Summary(ctx context.Context, catID int64, today domain.Date) (domain.HealthSummary, error) {
+	tx, err := repository.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return domain.HealthSummary{}, err
+	}
+	defer tx.Rollback(ctx) // transação só de leitura: nada a confirmar
+
+	s := domain.HealthSummary{CatID: catID}
+	if s.Neutering, err = neutering(ctx, tx, catID); err != nil { // também confirma que o gato existe
+		return domain.HealthSummary{}, err
+	}
+	if s.Allergies, err = listByCat(ctx, tx, allergiesSQL, scanAllergy, catID); err != nil {
+		return domain.HealthSummary{}, err
+	}
+	if s.ActiveMedications, err = listByCat(ctx, tx, activeMedicationsSQL, scanMedication, catID, today); err != nil {
+		return domain.HealthSummary{}, err
+	}
+	if s.Vaccinations, err = listByCat(ctx, tx,
+		"SELECT DISTINCT ON (lower(vaccine)) "+vaccinationColumns+" FROM cat_vaccinations WHERE cat_id=$1"+
+			" ORDER BY lower(vaccine), administered_on DESC, id DESC",
+		scanVaccination, catID); err != nil {
+		return domain.HealthSummary{}, err
+	}
+
+	visit, err := scanVetVisit(tx.QueryRow(ctx,
+		"SELECT "+vetVisitColumns+" FROM cat_vet_visits WHERE cat_id=$1 ORDER BY visited_on DESC, id DESC LIMIT 1",
+		catID,
+	))
+	switch {
+	case err == nil:
+		s.LastVisit = &visit
+	case !errors.Is(err, pgx.ErrNoRows):
+		return domain.HealthSummary{}, err
+	}
+	return s, nil
+}
+
+/*** relatório de vacinas a vencer ***/
+
+// RefreshVaccinationsDue recalcula o relatório: para cada gato e vacina, a dose mais recente
+// cujo reforço já venceu (overdue) ou vence em até windowDays a partir de today (upcoming).
+// Grava uma nova execução, descarta as anteriores e devolve o número de linhas do relatório.
+func (repository *HealthRepository) RefreshVaccinationsDue(ctx context.Context, today domain.Date, windowDays int) (int, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	var runID int64
+	if err := tx.QueryRow(ctx, "INSERT INTO vaccinations_due_runs (window_days) VALUES ($1) RETURNING id", windowDays).Scan(&runID); err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO vaccinations_due (run_id, cat_id, cat_name, vaccination_id, vaccine, due_on, status)
+		 SELECT $1, c.id, c.name, v.id, v.vaccine, v.next_due_on,
+		        CASE WHEN v.next_due_on < $2::date THEN 'overdue' ELSE 'upcoming' END
+		 FROM (
+		     SELECT DISTINCT ON (cat_id, lower(vaccine)) id, cat_id, vaccine, next_due_on
+		     FROM cat_vaccinations
+		     ORDER BY cat_id, lower(vaccine), administered_on DESC, id DESC
+		 ) v
+		 JOIN cats c ON c.id = v.cat_id
+		 WHERE v.next_due_on IS NOT NULL AND v.next_due_on <= $2::date + $3::int`,
+		runID, today, windowDays,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM vaccinations_due_runs WHERE id <> $1", runID); err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), tx.Commit(ctx)
+}
+
+// VaccinationsDue lê o relatório mais recente (status nil = vencidas e a vencer).
+// Os dias até o vencimento são contados a partir da data em que o relatório foi gerado.
+func (repository *HealthRepository) VaccinationsDue(ctx context.Context, status *string) (domain.VaccinationsDueReport, error) {
+	report := domain.VaccinationsDueReport{Items: []domain.VaccinationDue{}}
+
+	var (
+		runID       int64
+		generatedAt time.Time
+	)
+	err := repository.db.QueryRow(ctx, "SELECT id, generated_at, window_days FROM vaccinations_due_runs ORDER BY id DESC LIMIT 1").
+		Scan(&runID, &generatedAt, &report.WindowDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return report, nil // ainda não calculado
+	}
+	if err != nil {
+		return domain.VaccinationsDueReport{}, err
+	}
+	report.GeneratedAt = &generatedAt
+	generatedOn := domain.NewDate(generatedAt)
+
+	rows, err := repository.db.Query(ctx,
+		`SELECT cat_id, cat_name, vaccination_id, vaccine, due_on, status FROM vaccinations_due
+		 WHERE run_id = $1 AND ($2::text IS NULL OR status = $2)
+		 ORDER BY due_on, cat_id`,
+		runID, status,
+	)
+	if err != nil {
+		return domain.VaccinationsDueReport{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d domain.VaccinationDue
+		if err := rows.Scan(&d.CatID, &d.CatName, &d.VaccinationID, &d.Vaccine, &d.DueOn, &d.Status); err != nil {
+			return domain.VaccinationsDueReport{}, err
+		}
+		d.DaysUntilDue = int(d.DueOn.Time().Sub(generatedOn.Time()).Hours() / 24)
+		report.Items = append(report.Items, d)
+	}
+	return report, rows.Err()
+}
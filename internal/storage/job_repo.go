@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// JobRepository persiste o estado dos jobs em background.
+type JobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewJobRepository(db *pgxpool.Pool) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+const jobColumns = "id, kind, status, params, processed, succeeded, failed, error, result_key, result_type, created_at, started_at, finished_at"
+
+func scanJob(row pgx.Row) (domain.Job, error) {
+	var j domain.Job
+	err := row.Scan(&j.ID, &j.Kind, &j.Status, &j.Params, &j.Processed, &j.Succeeded, &j.Failed,
+		&j.Error, &j.ResultKey, &j.ResultType, &j.CreatedAt, &j.StartedAt, &j.FinishedAt)
+	return j, err
+}
+
+// Create registra um novo job na fila (status queued).
+func (repository *JobRepository) Create(ctx context.Context, kind string, params map[string]any) (domain.Job, error) {
+	if params == nil {
+		params = map[string]any{}
+	}
+	row := repository.db.QueryRow(ctx,
+		"INSERT INTO jobs (kind, params) VALUES ($1, $2) RETURNING "+jobColumns,
+		kind, params,
+	)
+	return scanJob(row)
+}
+
+// GetByID busca um job. Retorna domain.ErrJobNotFound se não existir.
+func (repository *JobRepository) GetByID(ctx context.Context, id int64) (domain.Job, error) {
+	j, err := scanJob(repository.db.QueryRow(ctx, "SELECT "+jobColumns+" FROM jobs WHERE id=$1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Job{}, domain.ErrJobNotFound
+	}
+	return j, err
+}
+
+// Start marca o job como em execução.
+func (repository *JobRepository) Start(ctx context.Context, id int64) error {
+	_, err := repository.db.Exec(ctx, "UPDATE jobs SET status='running', started_at=now() WHERE id=$1", id)
+	return err
+}
+
+// Progress atualiza os contadores de um job em execução.
+func (repository *JobRepository) Progress(ctx context.Context, id int64, p domain.JobProgress) error {
+	_, err := repository.db.Exec(ctx,
+		"UPDATE jobs SET processed=$2, succeeded=$3, failed=$4 WHERE id=$1",
+		id, p.Processed, p.Succeeded, p.Failed,
+	)
+	return err
+}
+
+// Finish encerra o job: succeeded se jobErr for nil, failed caso contrário.
+// resultKey/resultType apontam para o arquivo de resultado no blob store (podem ser vazios).
+func (repository *JobRepository) Finish(ctx context.Context, id int64, p domain.JobProgress, resultKey, resultType string, jobErr error) error {
+	status := domain.JobSucceeded
+	var errMsg *string
+	if jobErr != nil {
+		status = domain.JobFailed
+		msg := jobErr.Error()
+		errMsg = &msg
+	}
+	_, err := repository.db.Exec(ctx,
+		`UPDATE jobs SET status=$2, processed=$3, succeeded=$4, failed=$5,
+			result_key=NULLIF($6, ''), result_type=NULLIF($7, ''), error=$8, finished_at=now()
+		WHERE id=$1`,
+		id, status, p.Processed, p.Succeeded, p.Failed, resultKey, resultType, errMsg,
+	)
+	return err
+}
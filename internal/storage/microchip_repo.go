@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MicrochipRepository atende a consulta por microchip e o registro dessas consultas.
+type MicrochipRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMicrochipRepository(db *pgxpool.Pool) *MicrochipRepository {
+	return &MicrochipRepository{db: db}
+}
+
+const lookupColumns = "id, microchip, cat_id, result, ip, user_agent, request_id, created_at"
+
+func scanLookup(row pgx.Row) (domain.MicrochipLookup, error) {
+	var l domain.MicrochipLookup
+	err := row.Scan(&l.ID, &l.Microchip, &l.CatID, &l.Result, &l.IP, &l.UserAgent, &l.RequestID, &l.CreatedAt)
+	return l, err
+}
+
+const insertLookupSQL = "INSERT INTO microchip_lookups (microchip, cat_id, result, ip, user_agent, request_id) VALUES ($1,$2,$3,$4,$5,$6)"
+
+// Lookup busca o gato pelo microchip (já normalizado) e registra a consulta na mesma transação:
+// não há consulta sem registro. Retorna domain.ErrMicrochipNotFound se nenhum gato usar o microchip
+// (a consulta sem resultado também fica registrada).
+func (repository *MicrochipRepository) Lookup(ctx context.Context, chip string, req domain.Requester) (domain.Cat, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return domain.Cat{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	c, err := scanCat(tx.QueryRow(ctx, "SELECT "+catColumns+" FROM cats WHERE microchip = $1", chip))
+	found := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.Cat{}, err
+	}
+
+	var catID *int64
+	result := domain.LookupNotFound
+	if found {
+		catID, result = &c.ID, domain.LookupFound
+	}
+	if _, err := tx.Exec(ctx, insertLookupSQL, chip, catID, result, req.IP, req.UserAgent, req.RequestID); err != nil {
+		return domain.Cat{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Cat{}, err
+	}
+	if !found {
+		return domain.Cat{}, domain.ErrMicrochipNotFound
+	}
+	return c, nil
+}
+
+// RecordInvalid registra uma consulta com número inválido (que nem chega a buscar o gato).
+func (repository *MicrochipRepository) RecordInvalid(ctx context.Context, raw string, req domain.Requester) error {
+	_, err := repository.db.Exec(ctx, insertLookupSQL, raw, nil, domain.LookupInvalid, req.IP, req.UserAgent, req.RequestID)
+	return err
+}
+
+// Lookups lista o registro de consultas com paginação por cursor (created_at), das mais recentes para as mais antigas.
+func (repository *MicrochipRepository) Lookups(ctx context.Context, f domain.MicrochipLookupFilter, limit int, cursor *time.Time) ([]domain.MicrochipLookup, *time.Time, error) {
+	var (
+		args  []any
+		conds []string
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Microchip != nil {
+		add("microchip = $%d", *f.Microchip)
+	}
+	if f.CatID != nil {
+		add("cat_id = $%d", *f.CatID)
+	}
+	if cursor != nil {
+		add("created_at < $%d", *cursor)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+lookupColumns+" FROM microchip_lookups"+where+fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.MicrochipLookup
+	var last *time.Time
+	for rows.Next() {
+		l, err := scanLookup(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, l)
+		last = &l.CreatedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return items, last, nil
+}
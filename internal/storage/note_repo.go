@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Notas dos gatos (cat_notes) e o histórico de edição delas (cat_note_revisions).
+Toda versão do texto, inclusive a original, vira uma linha em cat_note_revisions,
+gravada na mesma transação que a nota.
+*/
+
+// NoteRepository persiste as notas e monta a linha do tempo dos gatos.
+type NoteRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNoteRepository(db *pgxpool.Pool) *NoteRepository {
+	return &NoteRepository{db: db}
+}
+
+const noteColumns = "id, cat_id, author, body, pinned, version, edited_by, created_at, updated_at"
+
+func scanNote(row pgx.Row) (domain.Note, error) {
+	var n domain.Note
+	err := row.Scan(&n.ID, &n.CatID, &n.Author, &n.Body, &n.Pinned, &n.Version, &n.EditedBy, &n.CreatedAt, &n.UpdatedAt)
+	return n, err
+}
+
+func scanNoteRevision(row pgx.Row) (domain.NoteRevision, error) {
+	var r domain.NoteRevision
+	err := row.Scan(&r.Version, &r.Body, &r.Editor, &r.CreatedAt)
+	return r, err
+}
+
+// List devolve as notas do gato: fixadas primeiro, depois as mais recentes.
+func (repository *NoteRepository) List(ctx context.Context, catID int64) ([]domain.Note, error) {
+	return listByCat(ctx, repository.db,
+		"SELECT "+noteColumns+" FROM cat_notes WHERE cat_id = $1 ORDER BY pinned DESC, created_at DESC, id DESC",
+		scanNote, catID,
+	)
+}
+
+// Create grava a nota e a versão 1 do texto. Retorna domain.ErrNotFound se o gato não existir.
+func (repository *NoteRepository) Create(ctx context.Context, catID int64, in domain.NoteCreate) (domain.Note, error) {
+	tx, err := beginAudited(ctx, repository.db) // cat_notes é auditada
+	if err != nil {
+		return domain.Note{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	n, err := scanNote(tx.QueryRow(ctx,
+		"INSERT INTO cat_notes (cat_id, author, body, pinned) VALUES ($1,$2,$3,$4) RETURNING "+noteColumns,
+		catID, in.Author, in.Body, in.Pinned,
+	))
+	if err != nil {
+		if code, _ := pgError(err); code == pgForeignKeyViolation {
+			return domain.Note{}, domain.ErrNotFound
+		}
+		return domain.Note{}, err
+	}
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO cat_note_revisions (note_id, version, body, editor, created_at) VALUES ($1,$2,$3,$4,$5)",
+		n.ID, n.Version, n.Body, n.Author, n.CreatedAt,
+	); err != nil {
+		return domain.Note{}, err
+	}
+	return n, tx.Commit(ctx)
+}
+
+// Update altera a nota do gato. Um texto diferente do atual vira uma nova versão no histórico.
+// Retorna domain.ErrNoteNotFound se a nota não existir ou for de outro gato.
+func (repository *NoteRepository) Update(ctx context.Context, catID, id int64, in domain.NoteUpdate) (domain.Note, error) {
+	tx, err := beginAudited(ctx, repository.db) // cat_notes é auditada
+	if err != nil {
+		return domain.Note{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	// trava a nota: duas edições simultâneas não geram a mesma versão
+	cur, err := scanNote(tx.QueryRow(ctx,
+		"SELECT "+noteColumns+" FROM cat_notes WHERE id = $1 AND cat_id = $2 FOR UPDATE",
+		id, catID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Note{}, domain.ErrNoteNotFound
+	}
+	if err != nil {
+		return domain.Note{}, err
+	}
+
+	body, version, editedBy := cur.Body, cur.Version, cur.EditedBy
+	if in.Body != nil && *in.Body != cur.Body {
+		body, version, editedBy = *in.Body, cur.Version+1, &in.Editor
+	}
+	pinned := cur.Pinned
+	if in.Pinned != nil {
+		pinned = *in.Pinned
+	}
+
+	n, err := scanNote(tx.QueryRow(ctx,
+		`UPDATE cat_notes SET body = $2, pinned = $3, version = $4, edited_by = $5, updated_at = now()
+		 WHERE id = $1 RETURNING `+noteColumns,
+		id, body, pinned, version, editedBy,
+	))
+	if err != nil {
+		return domain.Note{}, err
+	}
+	if version != cur.Version {
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO cat_note_revisions (note_id, version, body, editor, created_at) VALUES ($1,$2,$3,$4,$5)",
+			id, version, body, in.Editor, n.UpdatedAt,
+		); err != nil {
+			return domain.Note{}, err
+		}
+	}
+	return n, tx.Commit(ctx)
+}
+
+// Delete apaga a nota do gato (e o histórico dela).
+func (repository *NoteRepository) Delete(ctx context.Context, catID, id int64) error {
+	tx, err := beginAudited(ctx, repository.db) // cat_notes é auditada
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	tag, err := tx.Exec(ctx, "DELETE FROM cat_notes WHERE id = $1 AND cat_id = $2", id, catID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNoteNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// History devolve as versões do texto da nota, da original à atual.
+func (repository *NoteRepository) History(ctx context.Context, catID, id int64) ([]domain.NoteRevision, error) {
+	rows, err := repository.db.Query(ctx,
+		`SELECT r.version, r.body, r.editor, r.created_at
+		 FROM cat_note_revisions r JOIN cat_notes n ON n.id = r.note_id
+		 WHERE r.note_id = $1 AND n.cat_id = $2
+		 ORDER BY r.version`,
+		id, catID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.NoteRevision{}
+	for rows.Next() {
+		r, err := scanNoteRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 { // toda nota tem ao menos a versão 1
+		return nil, domain.ErrNoteNotFound
+	}
+	return items, nil
+}
+
+/*
+Linha do tempo: um UNION ALL das tabelas que registram algo sobre o gato, cada
+ramo com o mesmo formato (kind, at, id, data). data é montado com
+json_build_object, então cada tipo traz os seus próprios campos.
+
+cat_updated vem de cats_history (migração 0017): um evento por versão a partir da 2,
+com id = número da versão. Alterações anteriores ao histórico não aparecem.
+*/
+const timelineSQL = `
+SELECT 'cat_created' AS kind, c.created_at AS at, c.id, json_build_object('name', c.name, 'status', c.status) AS data
+FROM cats c WHERE c.id = $1
+UNION ALL
+SELECT 'cat_updated', h.valid_from, h.version, json_build_object('version', h.version)
+FROM cats_history h WHERE h.cat_id = $1 AND h.version > 1
+UNION ALL
+SELECT 'note', n.created_at, n.id, json_build_object('author', n.author, 'body', r.body, 'pinned', n.pinned)
+FROM cat_notes n JOIN cat_note_revisions r ON r.note_id = n.id AND r.version = 1
+WHERE n.cat_id = $1
+UNION ALL
+SELECT 'note_edited', r.created_at, r.id, json_build_object('note_id', n.id, 'editor', r.editor, 'version', r.version)
+FROM cat_note_revisions r JOIN cat_notes n ON n.id = r.note_id
+WHERE n.cat_id = $1 AND r.version > 1
+UNION ALL
+SELECT 'photo', t.created_at, t.id, json_build_object('path', t.path)
+FROM cat_thumbnails t WHERE t.cat_id = $1
+UNION ALL
+SELECT 'weight', w.measured_at, w.id, json_build_object('weight_kg', w.weight_kg, 'source', w.source, 'notes', w.notes)
+FROM cat_weights w WHERE w.cat_id = $1
+UNION ALL
+SELECT 'status', s.created_at, s.id, json_build_object('from', s.from_status, 'to', s.to_status, 'reason', s.reason, 'application_id', s.application_id)
+FROM cat_status_transitions s WHERE s.cat_id = $1`
+
+func scanTimelineEvent(row pgx.Row) (domain.TimelineEvent, error) {
+	var e domain.TimelineEvent
+	err := row.Scan(&e.Kind, &e.At, &e.ID, &e.Data)
+	return e, err
+}
+
+// Timeline devolve uma página da linha do tempo do gato (mais recente primeiro), só com os
+// tipos em kinds (todos se vazio), e o cursor da próxima página (nil na última).
+// Retorna domain.ErrNotFound se o gato não existir.
+func (repository *NoteRepository) Timeline(ctx context.Context, catID int64, kinds []string, limit int, cursor *domain.TimelineCursor) ([]domain.TimelineEvent, *domain.TimelineCursor, error) {
+	var (
+		args  []any
+		conds []string
+	)
+	if len(kinds) > 0 {
+		args = append(args, kinds)
+		conds = append(conds, fmt.Sprintf("kind = ANY($%d)", len(args)+1)) // $1 é o gato
+	}
+	if cursor != nil {
+		args = append(args, cursor.At, cursor.Kind, cursor.ID)
+		n := len(args) + 1
+		conds = append(conds, fmt.Sprintf("(at, kind, id) < ($%d, $%d, $%d)", n-2, n-1, n))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+
+	items, err := listByCat(ctx, repository.db,
+		"SELECT kind, at, id, data FROM ("+timelineSQL+") e"+where+
+			fmt.Sprintf(" ORDER BY at DESC, kind DESC, id DESC LIMIT $%d", len(args)+1),
+		scanTimelineEvent, catID, args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items) < limit {
+		return items, nil, nil
+	}
+	last := items[len(items)-1]
+	return items, &domain.TimelineCursor{At: last.At, Kind: last.Kind, ID: last.ID}, nil
+}
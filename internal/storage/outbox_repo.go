@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Outbox (migrações 0018 e 0020). Os eventos são gravados pelos triggers cats_outbox() e
+cat_thumbnails_outbox(), na mesma transação das mudanças; aqui ficam as operações do relay
+(internal/outbox): ler os pendentes, registrar as entregas e limpar os eventos antigos.
+O stream SSE também lê daqui: escuta os avisos do destino Notify (Listen) e retoma a partir
+de um evento na ordem em que o Notify os recebeu (DeliveredAfter).
+*/
+
+// OutboxRepository lê e atualiza a outbox para o relay e para o stream.
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// outboxLockSQL identifica o lock (advisory lock) que garante um único relay ativo entre as réplicas.
+const outboxLockSQL = "hashtext('cat_api.outbox_relay')"
+
+// Lock tenta pegar o lock do relay, em uma conexão reservada até unlock ser chamado.
+// ok=false (sem erro) quando outra instância já está entregando os eventos.
+func (repository *OutboxRepository) Lock(ctx context.Context) (unlock func(), ok bool, err error) {
+	conn, err := repository.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock("+outboxLockSQL+")").Scan(&ok); err != nil || !ok {
+		conn.Release()
+		return nil, false, err
+	}
+	return func() {
+		// O lock é da sessão: se não der para liberá-lo, a conexão é fechada em vez de voltar ao pool
+		ctx := context.WithoutCancel(ctx)
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock("+outboxLockSQL+")"); err != nil {
+			_ = conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}, true, nil
+}
+
+// Pending devolve até limit eventos ainda não publicados com id > afterID, em ordem de gravação,
+// com os destinos que já os receberam.
+func (repository *OutboxRepository) Pending(ctx context.Context, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := repository.db.Query(ctx,
+		`SELECT o.id, o.event_type, o.aggregate, o.aggregate_id, o.payload, o.created_at, o.attempts, o.next_attempt_at,
+		        ARRAY(SELECT d.sink FROM outbox_deliveries d WHERE d.event_id = o.id)
+		   FROM outbox o
+		  WHERE o.published_at IS NULL AND o.id > $1
+		  ORDER BY o.id LIMIT $2`,
+		afterID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.OutboxEvent
+	for rows.Next() {
+		var e domain.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.Aggregate, &e.AggregateID, &e.Data, &e.CreatedAt, &e.Attempts, &e.NextAttemptAt, &e.Delivered); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
+// MarkDelivered registra que o destino recebeu o evento (repetir não tem efeito).
+func (repository *OutboxRepository) MarkDelivered(ctx context.Context, eventID int64, sink string) error {
+	_, err := repository.db.Exec(ctx,
+		"INSERT INTO outbox_deliveries (event_id, sink) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		eventID, sink,
+	)
+	return err
+}
+
+// MarkPublished marca o evento como entregue a todos os destinos.
+func (repository *OutboxRepository) MarkPublished(ctx context.Context, eventID int64) error {
+	_, err := repository.db.Exec(ctx,
+		"UPDATE outbox SET published_at = now(), last_error = NULL WHERE id = $1 AND published_at IS NULL",
+		eventID,
+	)
+	return err
+}
+
+// MarkFailed registra a falha de uma entrega e quando tentar de novo.
+func (repository *OutboxRepository) MarkFailed(ctx context.Context, eventID int64, msg string, next time.Time) error {
+	_, err := repository.db.Exec(ctx,
+		"UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1",
+		eventID, msg, next,
+	)
+	return err
+}
+
+// Purge apaga os eventos publicados antes de before (e as suas entregas). Retorna quantos apagou.
+func (repository *OutboxRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := repository.db.Exec(ctx, "DELETE FROM outbox WHERE published_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Notify envia payload aos ouvintes (LISTEN) do canal.
+func (repository *OutboxRepository) Notify(ctx context.Context, channel, payload string) error {
+	_, err := repository.db.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload)
+	return err
+}
+
+// Listen escuta o canal até ctx ser cancelado ou a conexão cair, chamando fn com o payload de cada
+// aviso. ready é chamado quando o LISTEN já vale: avisos enviados a partir daí não se perdem.
+// A conexão sai do pool, já que fica presa ao LISTEN, e é fechada no fim.
+func (repository *OutboxRepository) Listen(ctx context.Context, channel string, ready func(), fn func(payload string)) error {
+	pc, err := repository.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pc.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	ready()
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn(n.Payload)
+	}
+}
+
+const outboxEventColumns = "o.id, o.event_type, o.aggregate, o.aggregate_id, o.payload, o.created_at"
+
+func scanOutboxEvent(row pgx.Row) (domain.OutboxEvent, error) {
+	var e domain.OutboxEvent
+	err := row.Scan(&e.ID, &e.Type, &e.Aggregate, &e.AggregateID, &e.Data, &e.CreatedAt)
+	return e, err
+}
+
+// Event lê um evento pelo id (domain.ErrNotFound se já foi apagado).
+func (repository *OutboxRepository) Event(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+	e, err := scanOutboxEvent(repository.db.QueryRow(ctx, "SELECT "+outboxEventColumns+" FROM outbox o WHERE o.id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.OutboxEvent{}, domain.ErrNotFound
+	}
+	return e, err
+}
+
+// DeliveredAfter devolve até limit eventos entregues ao destino sink depois do evento afterID, na ordem
+// das entregas. Essa é a ordem de confirmação, não a dos ids: um evento cuja transação demorou a
+// confirmar tem id menor, mas é entregue depois. O relay entrega um evento por vez, então duas
+// entregas não têm o mesmo delivered_at. Sem a entrega de afterID registrada, não devolve nada.
+func (repository *OutboxRepository) DeliveredAfter(ctx context.Context, sink string, afterID int64, limit int) ([]domain.OutboxEvent, error) {
+	rows, err := repository.db.Query(ctx,
+		`SELECT `+outboxEventColumns+`
+		   FROM outbox_deliveries d
+		   JOIN outbox o ON o.id = d.event_id
+		   JOIN outbox_deliveries p ON p.event_id = $2 AND p.sink = d.sink
+		  WHERE d.sink = $1 AND (d.delivered_at, d.event_id) > (p.delivered_at, p.event_id)
+		  ORDER BY d.delivered_at, d.event_id LIMIT $3`,
+		sink, afterID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.OutboxEvent
+	for rows.Next() {
+		e, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Códigos de erro do Postgres tratados pelos repositórios.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+// pgError devolve o código e a constraint de um erro do Postgres ("" se não for um).
+func pgError(err error) (code, constraint string) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code, pgErr.ConstraintName
+	}
+	return "", ""
+}
+
+// qualify prefixa cada coluna da lista com o alias da tabela ("id, name" -> "c.id, c.name").
+func qualify(alias, columns string) string {
+	cols := strings.Split(columns, ", ")
+	for i, c := range cols {
+		cols[i] = alias + "." + c
+	}
+	return strings.Join(cols, ", ")
+}
+
+// OwnerRepository persiste os tutores e o vínculo deles com os gatos (cat_owners, cat_transfers).
+type OwnerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOwnerRepository(db *pgxpool.Pool) *OwnerRepository {
+	return &OwnerRepository{db: db}
+}
+
+const ownerColumns = "id, name, email, phone, address, created_at, updated_at"
+
+func scanOwner(row pgx.Row) (domain.Owner, error) {
+	var o domain.Owner
+	err := row.Scan(&o.ID, &o.Name, &o.Email, &o.Phone, &o.Address, &o.CreatedAt, &o.UpdatedAt)
+	return o, err
+}
+
+// ownerErr traduz erros de escrita em owners para erros de domínio.
+func ownerErr(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrOwnerNotFound
+	}
+	switch code, constraint := pgError(err); {
+	case code == pgUniqueViolation && constraint == "idx_owners_email":
+		return domain.ErrOwnerEmailTaken
+	case code == pgForeignKeyViolation:
+		return domain.ErrOwnerInUse // DELETE de tutor referenciado por cat_owners/cat_transfers/adoption_applications
+	}
+	return err
+}
+
+func (repository *OwnerRepository) Create(ctx context.Context, in domain.OwnerCreate) (domain.Owner, error) {
+	tx, err := beginAudited(ctx, repository.db) // owners é auditada
+	if err != nil {
+		return domain.Owner{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	o, err := scanOwner(tx.QueryRow(ctx,
+		"INSERT INTO owners (name, email, phone, address) VALUES ($1,$2,$3,$4) RETURNING "+ownerColumns,
+		in.Name, in.Email, in.Phone, in.Address,
+	))
+	if err != nil {
+		return domain.Owner{}, ownerErr(err)
+	}
+	return o, tx.Commit(ctx)
+}
+
+// GetByID busca um tutor. Retorna domain.ErrOwnerNotFound se não existir.
+func (repository *OwnerRepository) GetByID(ctx context.Context, id int64) (domain.Owner, error) {
+	o, err := scanOwner(repository.db.QueryRow(ctx, "SELECT "+ownerColumns+" FROM owners WHERE id=$1", id))
+	if err != nil {
+		return domain.Owner{}, ownerErr(err)
+	}
+	return o, nil
+}
+
+// List lista tutores com paginação por cursor (created_at), como a listagem de gatos.
+func (repository *OwnerRepository) List(ctx context.Context, f domain.OwnerFilter, limit int, cursor *time.Time) ([]domain.Owner, *time.Time, error) {
+	var (
+		args  []any
+		conds []string
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Name != nil {
+		add("name ILIKE '%%' || $%d || '%%'", *f.Name)
+	}
+	if f.Email != nil {
+		add("lower(email) = lower($%d)", *f.Email)
+	}
+	if cursor != nil {
+		add("created_at < $%d", *cursor)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+ownerColumns+" FROM owners"+where+fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var owners []domain.Owner
+	var last *time.Time
+	for rows.Next() {
+		o, err := scanOwner(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		owners = append(owners, o)
+		last = &o.CreatedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(owners) == 0 {
+		return nil, nil, nil
+	}
+	return owners, last, nil
+}
+
+// Update aplica uma atualização parcial (campos nil mantêm o valor atual).
+func (repository *OwnerRepository) Update(ctx context.Context, id int64, in domain.OwnerUpdate) (domain.Owner, error) {
+	tx, err := beginAudited(ctx, repository.db) // owners é auditada
+	if err != nil {
+		return domain.Owner{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	o, err := scanOwner(tx.QueryRow(ctx, `UPDATE owners SET
+			name    = COALESCE($2, name),
+			email   = COALESCE($3, email),
+			phone   = COALESCE($4, phone),
+			address = COALESCE($5, address)
+		WHERE id = $1
+		RETURNING `+ownerColumns,
+		id, in.Name, in.Email, in.Phone, in.Address,
+	))
+	if err != nil {
+		return domain.Owner{}, ownerErr(err)
+	}
+	return o, tx.Commit(ctx)
+}
+
+// Delete remove um tutor sem histórico de tutela.
+// Retorna domain.ErrOwnerInUse se ele aparece em cat_owners, cat_transfers ou adoption_applications.
+func (repository *OwnerRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := beginAudited(ctx, repository.db) // owners é auditada
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	tag, err := tx.Exec(ctx, "DELETE FROM owners WHERE id=$1", id)
+	if err != nil {
+		return ownerErr(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOwnerNotFound
+	}
+	return tx.Commit(ctx)
+}
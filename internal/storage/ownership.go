@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+/*
+Vínculos gato-tutor (cat_owners) e histórico de transferências (cat_transfers).
+
+Cada vínculo é um período [start_date, end_date]; end_date NULL = ativo.
+Índices únicos parciais garantem no banco que há no máximo um tutor principal
+ativo por gato e um vínculo ativo por par gato/tutor.
+*/
+
+const ownershipColumns = "id, cat_id, owner_id, role, start_date, end_date, created_at"
+
+func ownershipDest(o *domain.Ownership) []any {
+	return []any{&o.ID, &o.CatID, &o.OwnerID, &o.Role, &o.StartDate, &o.EndDate, &o.CreatedAt}
+}
+
+const transferColumns = "id, cat_id, from_owner_id, to_owner_id, transferred_on, reason, created_at"
+
+func scanTransfer(row pgx.Row) (domain.Transfer, error) {
+	var t domain.Transfer
+	err := row.Scan(&t.ID, &t.CatID, &t.FromOwnerID, &t.ToOwnerID, &t.TransferredOn, &t.Reason, &t.CreatedAt)
+	return t, err
+}
+
+// ownershipErr traduz erros de escrita em cat_owners para erros de domínio.
+func ownershipErr(err error) error {
+	switch code, constraint := pgError(err); {
+	case code == pgUniqueViolation:
+		return domain.ErrOwnershipConflict
+	case code == pgCheckViolation:
+		return domain.ErrOwnershipDates
+	case code == pgForeignKeyViolation && constraint == "cat_owners_cat_id_fkey":
+		return domain.ErrNotFound
+	case code == pgForeignKeyViolation:
+		return domain.ErrOwnerNotFound
+	}
+	return err
+}
+
+// activeOnly restringe a consulta aos vínculos ativos quando history=false.
+func activeOnly(history bool) string {
+	if history {
+		return ""
+	}
+	return " AND co.end_date IS NULL"
+}
+
+// ownershipOrder: ativos primeiro, o principal no topo, depois os mais recentes.
+const ownershipOrder = " ORDER BY co.end_date IS NOT NULL, co.role <> 'primary', co.start_date DESC, co.id DESC"
+
+// OwnersOf lista os tutores do gato (history=true inclui vínculos encerrados).
+// Retorna domain.ErrNotFound se o gato não existir.
+func (repository *OwnerRepository) OwnersOf(ctx context.Context, catID int64, history bool) ([]domain.CatOwner, error) {
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+qualify("o", ownerColumns)+", "+qualify("co", ownershipColumns)+
+			" FROM cat_owners co JOIN owners o ON o.id = co.owner_id"+
+			" WHERE co.cat_id = $1"+activeOnly(history)+ownershipOrder,
+		catID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.CatOwner{}
+	for rows.Next() {
+		var co domain.CatOwner
+		o := &co.Owner
+		dest := append([]any{&o.ID, &o.Name, &o.Email, &o.Phone, &o.Address, &o.CreatedAt, &o.UpdatedAt}, ownershipDest(&co.Ownership)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, co)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, repository.mustExist(ctx, "cats", catID, domain.ErrNotFound)
+	}
+	return items, nil
+}
+
+// CatsOf lista os gatos do tutor (history=true inclui vínculos encerrados).
+// Retorna domain.ErrOwnerNotFound se o tutor não existir.
+func (repository *OwnerRepository) CatsOf(ctx context.Context, ownerID int64, history bool) ([]domain.OwnedCat, error) {
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+qualify("c", catColumns)+", "+qualify("co", ownershipColumns)+
+			" FROM cat_owners co JOIN cats c ON c.id = co.cat_id"+
+			" WHERE co.owner_id = $1"+activeOnly(history)+ownershipOrder,
+		ownerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.OwnedCat{}
+	for rows.Next() {
+		var oc domain.OwnedCat
+		dest := append(catDest(&oc.Cat), ownershipDest(&oc.Ownership)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, oc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, repository.mustExist(ctx, "owners", ownerID, domain.ErrOwnerNotFound)
+	}
+	return items, nil
+}
+
+// mustExist devolve notFound se não houver linha com o id na tabela (usado quando uma listagem vem vazia).
+func (repository *OwnerRepository) mustExist(ctx context.Context, table string, id int64, notFound error) error {
+	var exists bool
+	if err := repository.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id=$1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return notFound
+	}
+	return nil
+}
+
+// AddOwner vincula um tutor ao gato a partir de start.
+// Retorna domain.ErrOwnershipConflict se o tutor já estiver vinculado ou se já houver um principal ativo.
+func (repository *OwnerRepository) AddOwner(ctx context.Context, catID int64, in domain.OwnershipCreate, start domain.Date) (domain.Ownership, error) {
+	var o domain.Ownership
+	err := repository.db.QueryRow(ctx,
+		"INSERT INTO cat_owners (cat_id, owner_id, role, start_date) VALUES ($1,$2,$3,$4) RETURNING "+ownershipColumns,
+		catID, in.OwnerID, in.Role, start,
+	).Scan(ownershipDest(&o)...)
+	if err != nil {
+		return domain.Ownership{}, ownershipErr(err)
+	}
+	return o, nil
+}
+
+// EndOwnership encerra o vínculo ativo entre o gato e o tutor em end.
+// Retorna domain.ErrOwnershipNotFound se não houver vínculo ativo.
+func (repository *OwnerRepository) EndOwnership(ctx context.Context, catID, ownerID int64, end domain.Date) (domain.Ownership, error) {
+	var o domain.Ownership
+	err := repository.db.QueryRow(ctx,
+		"UPDATE cat_owners SET end_date = $3 WHERE cat_id = $1 AND owner_id = $2 AND end_date IS NULL RETURNING "+ownershipColumns,
+		catID, ownerID, end,
+	).Scan(ownershipDest(&o)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Ownership{}, domain.ErrOwnershipNotFound
+	}
+	if err != nil {
+		return domain.Ownership{}, ownershipErr(err)
+	}
+	return o, nil
+}
+
+// Transfer passa a tutela principal do gato para in.ToOwnerID em on, numa única transação:
+// trava o gato (transferências simultâneas do mesmo gato ficam em fila) e chama transferPrimary.
+func (repository *OwnerRepository) Transfer(ctx context.Context, catID int64, in domain.TransferRequest, on domain.Date) (domain.Transfer, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	var locked int64
+	if err := tx.QueryRow(ctx, "SELECT id FROM cats WHERE id=$1 FOR UPDATE", catID).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transfer{}, domain.ErrNotFound
+		}
+		return domain.Transfer{}, err
+	}
+
+	t, err := transferPrimary(ctx, tx, catID, in.ToOwnerID, on, in.Reason)
+	if err != nil {
+		return domain.Transfer{}, err
+	}
+	return t, tx.Commit(ctx)
+}
+
+// transferPrimary faz a troca de tutor principal; deve rodar numa transação com o gato já travado:
+//  1. encerra o vínculo principal atual (e um vínculo secundário ativo do novo tutor, que é promovido);
+//  2. cria o novo vínculo principal e registra a transferência no histórico.
+//
+// Também é usada pela aprovação de uma candidatura de adoção.
+func transferPrimary(ctx context.Context, q querier, catID, toOwnerID int64, on domain.Date, reason *string) (domain.Transfer, error) {
+	// tutor principal atual (pode não existir)
+	var (
+		fromOwnerID *int64
+		fromStart   domain.Date
+	)
+	err := q.QueryRow(ctx,
+		"SELECT owner_id, start_date FROM cat_owners WHERE cat_id=$1 AND role='primary' AND end_date IS NULL",
+		catID,
+	).Scan(&fromOwnerID, &fromStart)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.Transfer{}, err
+	}
+	if fromOwnerID != nil {
+		if *fromOwnerID == toOwnerID {
+			return domain.Transfer{}, fmt.Errorf("%w: o tutor %d já é o principal", domain.ErrInvalidTransfer, toOwnerID)
+		}
+		if on.Before(fromStart) {
+			return domain.Transfer{}, fmt.Errorf("%w: a tutela atual começou em %s", domain.ErrOwnershipDates, fromStart)
+		}
+	}
+
+	// encerra o principal atual e o vínculo secundário do novo tutor, se houver
+	if _, err := q.Exec(ctx,
+		`UPDATE cat_owners SET end_date = $3
+		 WHERE cat_id = $1 AND end_date IS NULL AND (role = 'primary' OR owner_id = $2)`,
+		catID, toOwnerID, on,
+	); err != nil {
+		return domain.Transfer{}, ownershipErr(err)
+	}
+
+	if _, err := q.Exec(ctx,
+		"INSERT INTO cat_owners (cat_id, owner_id, role, start_date) VALUES ($1,$2,'primary',$3)",
+		catID, toOwnerID, on,
+	); err != nil {
+		return domain.Transfer{}, ownershipErr(err)
+	}
+
+	t, err := scanTransfer(q.QueryRow(ctx,
+		"INSERT INTO cat_transfers (cat_id, from_owner_id, to_owner_id, transferred_on, reason) VALUES ($1,$2,$3,$4,$5) RETURNING "+transferColumns,
+		catID, fromOwnerID, toOwnerID, on, reason,
+	))
+	if err != nil {
+		return domain.Transfer{}, ownershipErr(err)
+	}
+	return t, nil
+}
+
+// Transfers devolve o histórico de transferências do gato, da mais antiga para a mais recente.
+func (repository *OwnerRepository) Transfers(ctx context.Context, catID int64) ([]domain.Transfer, error) {
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+transferColumns+" FROM cat_transfers WHERE cat_id=$1 ORDER BY transferred_on, id",
+		catID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.Transfer{}
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, repository.mustExist(ctx, "cats", catID, domain.ErrNotFound)
+	}
+	return items, nil
+}
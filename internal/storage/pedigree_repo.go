@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PedigreeRepository lê a genealogia dos gatos (consultas recursivas) e persiste as ninhadas.
+type PedigreeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPedigreeRepository(db *pgxpool.Pool) *PedigreeRepository {
+	return &PedigreeRepository{db: db}
+}
+
+/*
+Ancestrais e descendentes são lidos com WITH RECURSIVE: a parte não recursiva
+escolhe os gatos de partida e a recursiva sobe (pais) ou desce (filhos) uma
+geração por vez, até o limite de gerações. UNION (e não UNION ALL) descarta as
+linhas repetidas, então um ancestral que aparece por vários caminhos (comum em
+cruzamentos consanguíneos) não multiplica o trabalho.
+*/
+
+// ancestrySQL lê os gatos de partida ($1) e os ancestrais deles até $2 gerações.
+var ancestrySQL = `WITH RECURSIVE ancestors(id, depth) AS (
+		SELECT id, 0 FROM cats WHERE id = ANY($1)
+		UNION
+		SELECT p.id, a.depth + 1
+		FROM ancestors a
+		JOIN cats c ON c.id = a.id
+		CROSS JOIN LATERAL unnest(ARRAY[c.sire_id, c.dam_id]) AS p(id)
+		WHERE p.id IS NOT NULL AND a.depth < $2
+	)
+	SELECT ` + qualify("c", catColumns) + `
+	FROM cats c
+	WHERE c.id IN (SELECT id FROM ancestors)`
+
+// Ancestry devolve os gatos ids e os ancestrais deles até generations gerações.
+// Os ids que não existem simplesmente não aparecem no resultado.
+func (repository *PedigreeRepository) Ancestry(ctx context.Context, ids []int64, generations int) (domain.Ancestry, error) {
+	rows, err := repository.db.Query(ctx, ancestrySQL, ids, generations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ancestry := domain.Ancestry{}
+	for rows.Next() {
+		c, err := scanCat(rows)
+		if err != nil {
+			return nil, err
+		}
+		ancestry[c.ID] = c
+	}
+	return ancestry, rows.Err()
+}
+
+// descendantsSQL lê os descendentes de $1 até $2 gerações, cada um na geração mais próxima.
+var descendantsSQL = `WITH RECURSIVE descendants(id, generation) AS (
+		SELECT id, 0 FROM cats WHERE id = $1
+		UNION
+		SELECT c.id, d.generation + 1
+		FROM descendants d
+		JOIN cats c ON c.sire_id = d.id OR c.dam_id = d.id
+		WHERE d.generation < $2
+	)
+	SELECT ` + qualify("c", catColumns) + `, d.generation
+	FROM (SELECT id, min(generation) AS generation FROM descendants WHERE generation > 0 GROUP BY id) d
+	JOIN cats c ON c.id = d.id
+	ORDER BY d.generation, c.created_at, c.id`
+
+// Descendants devolve filhos, netos... de catID até generations gerações.
+// Retorna domain.ErrNotFound se o gato não existir.
+func (repository *PedigreeRepository) Descendants(ctx context.Context, catID int64, generations int) ([]domain.Descendant, error) {
+	if err := catExists(ctx, repository.db, catID); err != nil {
+		return nil, err
+	}
+	rows, err := repository.db.Query(ctx, descendantsSQL, catID, generations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.Descendant
+	for rows.Next() {
+		var d domain.Descendant
+		if err := rows.Scan(append(catDest(&d.Cat), &d.Generation)...); err != nil {
+			return nil, err
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+const litterColumns = "id, sire_id, dam_id, birth_date, notes, created_at"
+
+func scanLitter(row pgx.Row) (domain.Litter, error) {
+	var l domain.Litter
+	err := row.Scan(&l.ID, &l.SireID, &l.DamID, &l.BirthDate, &l.Notes, &l.CreatedAt)
+	return l, err
+}
+
+// litterErr traduz erros de escrita em litters para erros de domínio.
+func litterErr(err error) error {
+	switch code, constraint := pgError(err); {
+	case code == pgForeignKeyViolation && constraint == "litters_sire_id_fkey":
+		return fmt.Errorf("%w: sire_id", domain.ErrParentNotFound)
+	case code == pgForeignKeyViolation && constraint == "litters_dam_id_fkey":
+		return fmt.Errorf("%w: dam_id", domain.ErrParentNotFound)
+	case code == pgCheckViolation && constraint == "litters_parents_check":
+		return fmt.Errorf("%w: o mesmo gato não pode ser pai e mãe", domain.ErrInvalidLineage)
+	}
+	return err
+}
+
+func (repository *PedigreeRepository) CreateLitter(ctx context.Context, in domain.LitterCreate) (domain.Litter, error) {
+	l, err := scanLitter(repository.db.QueryRow(ctx,
+		"INSERT INTO litters (sire_id, dam_id, birth_date, notes) VALUES ($1,$2,$3,$4) RETURNING "+litterColumns,
+		in.SireID, in.DamID, in.BirthDate, in.Notes,
+	))
+	if err != nil {
+		return domain.Litter{}, litterErr(err)
+	}
+	return l, nil
+}
+
+// GetLitter devolve a ninhada com os filhotes (gatos com litter_id dela).
+func (repository *PedigreeRepository) GetLitter(ctx context.Context, id int64) (domain.Litter, error) {
+	l, err := scanLitter(repository.db.QueryRow(ctx, "SELECT "+litterColumns+" FROM litters WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Litter{}, domain.ErrLitterNotFound
+	}
+	if err != nil {
+		return domain.Litter{}, err
+	}
+
+	rows, err := repository.db.Query(ctx, "SELECT "+catColumns+" FROM cats WHERE litter_id = $1 ORDER BY created_at, id", id)
+	if err != nil {
+		return domain.Litter{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c, err := scanCat(rows)
+		if err != nil {
+			return domain.Litter{}, err
+		}
+		l.Kittens = append(l.Kittens, c)
+	}
+	return l, rows.Err()
+}
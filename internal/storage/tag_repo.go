@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Etiquetas dos gatos. tags.key (minúsculas, coluna gerada) é a identidade da
+etiqueta: "Shy" e "shy" são a mesma, com o nome guardado como foi criado.
+Uma etiqueta nasce na primeira vez que um gato a recebe e continua existindo
+sem gatos até ser apagada ou mesclada em outra.
+*/
+
+// TagRepository persiste as etiquetas e o vínculo delas com os gatos (cat_tags).
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// tagColumns lê a etiqueta com a quantidade de gatos que a usam (alias t).
+const tagColumns = "t.id, t.name, (SELECT count(*) FROM cat_tags ct WHERE ct.tag_id = t.id), t.created_at"
+
+func scanTag(row pgx.Row) (domain.Tag, error) {
+	var t domain.Tag
+	err := row.Scan(&t.ID, &t.Name, &t.Cats, &t.CreatedAt)
+	return t, err
+}
+
+// tagKeys devolve as chaves (minúsculas, sem repetição) das etiquetas, como em tags.key.
+func tagKeys(names []string) []string {
+	names = domain.NormalizeTags(names)
+	keys := make([]string, len(names))
+	for i, n := range names {
+		keys[i] = strings.ToLower(n)
+	}
+	return keys
+}
+
+// SetCatTags substitui as etiquetas do gato, criando as que ainda não existem, e devolve as atuais.
+// Retorna domain.ErrNotFound se o gato não existir.
+func (repository *TagRepository) SetCatTags(ctx context.Context, catID int64, names []string) ([]string, error) {
+	names = domain.NormalizeTags(names)
+	keys := tagKeys(names)
+
+	tx, err := beginAudited(ctx, repository.db) // tags é auditada
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	// trava o gato: duas substituições simultâneas não se misturam
+	if err := tx.QueryRow(ctx, "SELECT id FROM cats WHERE id = $1 FOR UPDATE", catID).Scan(&catID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if len(names) > 0 {
+		if _, err := tx.Exec(ctx, "INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (key) DO NOTHING", names); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tx.Exec(ctx,
+		"DELETE FROM cat_tags WHERE cat_id = $1 AND tag_id NOT IN (SELECT id FROM tags WHERE key = ANY($2))",
+		catID, keys,
+	); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx,
+		"INSERT INTO cat_tags (cat_id, tag_id) SELECT $1, id FROM tags WHERE key = ANY($2) ON CONFLICT DO NOTHING",
+		catID, keys,
+	); err != nil {
+		return nil, err
+	}
+
+	tags, err := catTags(ctx, tx, catID)
+	if err != nil {
+		return nil, err
+	}
+	return tags, tx.Commit(ctx)
+}
+
+// CatTags devolve os nomes das etiquetas do gato, em ordem alfabética.
+// Retorna domain.ErrNotFound se o gato não existir.
+func (repository *TagRepository) CatTags(ctx context.Context, catID int64) ([]string, error) {
+	if err := catExists(ctx, repository.db, catID); err != nil {
+		return nil, err
+	}
+	return catTags(ctx, repository.db, catID)
+}
+
+func catTags(ctx context.Context, q querier, catID int64) ([]string, error) {
+	var tags []string
+	err := q.QueryRow(ctx, "SELECT "+tagsSQL+" FROM cats WHERE id = $1", catID).Scan(&tags)
+	return tags, err
+}
+
+// List lista as etiquetas que começam por prefix (sem diferenciar maiúsculas), em ordem alfabética.
+func (repository *TagRepository) List(ctx context.Context, prefix string, limit int) ([]domain.Tag, error) {
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+tagColumns+" FROM tags t WHERE starts_with(t.key, lower($1)) ORDER BY t.key LIMIT $2",
+		prefix, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.Tag
+	for rows.Next() {
+		t, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	return items, rows.Err()
+}
+
+// Rename troca o nome da etiqueta. Retorna domain.ErrTagNameTaken se outra etiqueta já usar o nome
+// (mudar só maiúsculas/minúsculas da própria etiqueta é permitido).
+func (repository *TagRepository) Rename(ctx context.Context, id int64, name string) (domain.Tag, error) {
+	tx, err := beginAudited(ctx, repository.db) // tags é auditada
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	t, err := scanTag(tx.QueryRow(ctx,
+		"UPDATE tags t SET name = $2 WHERE t.id = $1 RETURNING "+tagColumns,
+		id, name,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Tag{}, domain.ErrTagNotFound
+	}
+	if code, constraint := pgError(err); code == pgUniqueViolation && constraint == "tags_key_key" {
+		return domain.Tag{}, domain.ErrTagNameTaken
+	}
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	return t, tx.Commit(ctx)
+}
+
+// Merge move os gatos da etiqueta id para a etiqueta into e apaga id, em uma transação.
+// Gatos que já tinham as duas ficam com into uma única vez. Devolve into atualizada.
+func (repository *TagRepository) Merge(ctx context.Context, id, into int64) (domain.Tag, error) {
+	tx, err := beginAudited(ctx, repository.db) // tags é auditada
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	// trava as duas etiquetas (sempre na mesma ordem, evitando deadlock entre merges cruzados)
+	var locked int
+	if err := tx.QueryRow(ctx,
+		"SELECT count(*) FROM (SELECT id FROM tags WHERE id = ANY($1) ORDER BY id FOR UPDATE) l",
+		[]int64{id, into},
+	).Scan(&locked); err != nil {
+		return domain.Tag{}, err
+	}
+	if locked != 2 {
+		return domain.Tag{}, domain.ErrTagNotFound
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO cat_tags (cat_id, tag_id, created_at)
+		 SELECT cat_id, $2, created_at FROM cat_tags WHERE tag_id = $1
+		 ON CONFLICT DO NOTHING`,
+		id, into,
+	); err != nil {
+		return domain.Tag{}, err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM tags WHERE id = $1", id); err != nil { // os vínculos saem em cascata
+		return domain.Tag{}, err
+	}
+
+	t, err := scanTag(tx.QueryRow(ctx, "SELECT "+tagColumns+" FROM tags t WHERE t.id = $1", into))
+	if err != nil {
+		return domain.Tag{}, err
+	}
+	return t, tx.Commit(ctx)
+}
+
+// Delete apaga a etiqueta (e a tira de todos os gatos).
+func (repository *TagRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := beginAudited(ctx, repository.db) // tags é auditada
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	tag, err := tx.Exec(ctx, "DELETE FROM tags WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTagNotFound
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository persiste as assinaturas de webhooks e as entregas (migração 0019).
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookColumns = "id, url, events, active, consecutive_failures, disabled_at, disabled_reason, created_at, updated_at"
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var w domain.Webhook
+	err := row.Scan(&w.ID, &w.URL, &w.Events, &w.Active, &w.ConsecutiveFailures, &w.DisabledAt, &w.DisabledReason, &w.CreatedAt, &w.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	return w, err
+}
+
+// next_attempt_at só interessa enquanto a entrega está pendente
+const deliveryColumns = `id, subscription_id, event_id, event_type, status, attempts,
+	CASE WHEN status = 'pending' THEN next_attempt_at END,
+	response_code, response_body, error, duration_ms, delivered_at, redelivery_of, created_at, updated_at`
+
+func scanDelivery(row pgx.Row) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseCode, &d.ResponseBody, &d.Error, &d.DurationMS, &d.DeliveredAt, &d.RedeliveryOf, &d.CreatedAt, &d.UpdatedAt)
+	return d, err
+}
+
+/*** assinaturas ***/
+
+// Create cadastra a assinatura com o segredo informado (devolvido em Secret).
+func (repository *WebhookRepository) Create(ctx context.Context, in domain.WebhookCreate, secret string) (domain.Webhook, error) {
+	w, err := scanWebhook(repository.db.QueryRow(ctx,
+		"INSERT INTO webhook_subscriptions (url, events, secret) VALUES ($1, $2, $3) RETURNING "+webhookColumns,
+		in.URL, in.Events, secret,
+	))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	w.Secret = secret
+	return w, nil
+}
+
+// List devolve todas as assinaturas, das mais novas às mais antigas.
+func (repository *WebhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	rows, err := repository.db.Query(ctx, "SELECT "+webhookColumns+" FROM webhook_subscriptions ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, w)
+	}
+	return items, rows.Err()
+}
+
+// GetByID busca uma assinatura (sem o segredo). Retorna domain.ErrWebhookNotFound se não existir.
+func (repository *WebhookRepository) GetByID(ctx context.Context, id int64) (domain.Webhook, error) {
+	return scanWebhook(repository.db.QueryRow(ctx, "SELECT "+webhookColumns+" FROM webhook_subscriptions WHERE id = $1", id))
+}
+
+// Update altera a assinatura; campos nil mantêm o valor atual.
+// active=true reativa e zera as falhas seguidas; active=false desativa (disabled_reason registra que foi pela API).
+// Um segredo novo volta em Secret.
+func (repository *WebhookRepository) Update(ctx context.Context, id int64, in domain.WebhookUpdate) (domain.Webhook, error) {
+	w, err := scanWebhook(repository.db.QueryRow(ctx, `UPDATE webhook_subscriptions SET
+			url                  = COALESCE($2, url),
+			events               = COALESCE($3, events),
+			secret               = COALESCE($4, secret),
+			active               = COALESCE($5, active),
+			consecutive_failures = CASE WHEN $5 THEN 0 ELSE consecutive_failures END,
+			disabled_at          = CASE WHEN $5 THEN NULL WHEN NOT $5 AND active THEN now() ELSE disabled_at END,
+			disabled_reason      = CASE WHEN $5 THEN NULL WHEN NOT $5 AND active THEN 'disabled via API' ELSE disabled_reason END
+		WHERE id = $1
+		RETURNING `+webhookColumns,
+		id, in.URL, in.Events, in.Secret, in.Active,
+	))
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	if in.Secret != nil {
+		w.Secret = *in.Secret
+	}
+	return w, nil
+}
+
+// Delete remove a assinatura e as suas entregas.
+func (repository *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := repository.db.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+/*** entregas ***/
+
+// Deliveries devolve as entregas da assinatura, das mais novas às mais antigas, e o cursor da próxima
+// página (nil na última). O cursor é o id da última entrega; status (opcional) filtra pela situação.
+func (repository *WebhookRepository) Deliveries(ctx context.Context, webhookID int64, status *string, limit int, cursor *int64) ([]domain.WebhookDelivery, *int64, error) {
+	args := []any{webhookID}
+	conds := "subscription_id = $1"
+	if status != nil {
+		args = append(args, *status)
+		conds = joinConds(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if cursor != nil {
+		args = append(args, *cursor)
+		conds = joinConds(conds, fmt.Sprintf("id < $%d", len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE "+conds+fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 {
+		if _, err := repository.GetByID(ctx, webhookID); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(items) < limit {
+		return items, nil, nil
+	}
+	return items, &items[len(items)-1].ID, nil
+}
+
+// Redeliver agenda um novo envio do evento de uma entrega (qualquer que seja a situação dela),
+// como uma entrega nova ligada à original (redelivery_of).
+// Retorna domain.ErrDeliveryNotFound se a entrega não for da assinatura e domain.ErrWebhookDisabled
+// se a assinatura estiver desativada.
+func (repository *WebhookRepository) Redeliver(ctx context.Context, webhookID, deliveryID int64) (domain.WebhookDelivery, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	var active bool
+	if err := tx.QueryRow(ctx, "SELECT active FROM webhook_subscriptions WHERE id = $1", webhookID).Scan(&active); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.WebhookDelivery{}, domain.ErrWebhookNotFound
+		}
+		return domain.WebhookDelivery{}, err
+	}
+	if !active {
+		return domain.WebhookDelivery{}, domain.ErrWebhookDisabled
+	}
+
+	d, err := scanDelivery(tx.QueryRow(ctx,
+		`INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, redelivery_of)
+		 SELECT subscription_id, event_id, event_type, payload, id FROM webhook_deliveries
+		  WHERE id = $1 AND subscription_id = $2
+		 RETURNING `+deliveryColumns,
+		deliveryID, webhookID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return d, tx.Commit(ctx)
+}
+
+// Enqueue cria uma entrega do evento para cada assinatura ativa do tipo dele. Repetir o mesmo
+// evento não duplica as entregas. Retorna quantas foram criadas.
+func (repository *WebhookRepository) Enqueue(ctx context.Context, e domain.OutboxEvent) (int64, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+	tag, err := repository.db.Exec(ctx,
+		`INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		 SELECT s.id, $1, $2, $3 FROM webhook_subscriptions s WHERE s.active AND $2 = ANY(s.events)
+		 ON CONFLICT (subscription_id, event_id) WHERE redelivery_of IS NULL DO NOTHING`,
+		e.ID, e.Type, payload,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Claim reserva até limit entregas pendentes que já podem ser tentadas (de assinaturas ativas):
+// next_attempt_at avança lease, então outra réplica não as pega enquanto o envio acontece.
+// Se o processo cair no meio do envio, a entrega volta a ser tentada quando a reserva vencer.
+// Cada reserva troca o claim_token da entrega: Record e Release só valem com o token atual.
+func (repository *WebhookRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookSend, error) {
+	rows, err := repository.db.Query(ctx,
+		`UPDATE webhook_deliveries d SET next_attempt_at = now() + make_interval(secs => $2), claim_token = d.claim_token + 1
+		   FROM webhook_subscriptions s
+		  WHERE s.id = d.subscription_id AND d.id IN (
+		        SELECT d2.id FROM webhook_deliveries d2 JOIN webhook_subscriptions s2 ON s2.id = d2.subscription_id
+		         WHERE d2.status = 'pending' AND d2.next_attempt_at <= now() AND s2.active
+		         ORDER BY d2.next_attempt_at, d2.id LIMIT $1
+		           FOR UPDATE OF d2 SKIP LOCKED)
+		 RETURNING d.id, d.subscription_id, d.event_type, s.url, s.secret, d.payload::text, d.attempts, d.claim_token`,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.WebhookSend
+	for rows.Next() {
+		var s domain.WebhookSend
+		var body string
+		if err := rows.Scan(&s.DeliveryID, &s.WebhookID, &s.EventType, &s.URL, &s.Secret, &body, &s.Attempts, &s.ClaimToken); err != nil {
+			return nil, err
+		}
+		s.Body = []byte(body)
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
+
+// Record grava o resultado de uma tentativa. Em caso de falha, next é quando tentar de novo
+// (nil = sem novas tentativas, a entrega falhou). As falhas seguidas da assinatura são contadas;
+// ao chegar a disableAfter (> 0) a assinatura é desativada. Um sucesso zera a contagem.
+// ErrClaimExpired se a entrega foi reservada de novo depois de s (a reserva venceu durante o
+// envio): o resultado é descartado, e quem tem a reserva atual grava o seu.
+func (repository *WebhookRepository) Record(ctx context.Context, s domain.WebhookSend, r domain.WebhookResult, next *time.Time, disableAfter int) error {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	tag, err := tx.Exec(ctx, `UPDATE webhook_deliveries SET
+			attempts        = attempts + 1,
+			claim_token     = claim_token + 1, -- o resultado encerra a reserva
+			response_code   = $2,
+			response_body   = $3,
+			error           = $4,
+			duration_ms     = $5,
+			status          = CASE WHEN $4::text IS NULL THEN 'succeeded' WHEN $6::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+			next_attempt_at = COALESCE($6, next_attempt_at),
+			delivered_at    = CASE WHEN $4::text IS NULL THEN now() END
+		WHERE id = $1 AND claim_token = $7 AND status = 'pending'`,
+		s.DeliveryID, r.ResponseCode, r.ResponseBody, r.Error, r.Duration.Milliseconds(), next, s.ClaimToken,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrClaimExpired
+	}
+
+	if r.Error == nil {
+		_, err = tx.Exec(ctx, "UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures <> 0", s.WebhookID)
+	} else {
+		// trip: esta falha alcança o limite (os valores à direita são os de antes do UPDATE)
+		const trip = "(active AND $2 > 0 AND consecutive_failures + 1 >= $2)"
+		_, err = tx.Exec(ctx, `UPDATE webhook_subscriptions SET
+				consecutive_failures = consecutive_failures + 1,
+				disabled_at          = CASE WHEN `+trip+` THEN now() ELSE disabled_at END,
+				disabled_reason      = CASE WHEN `+trip+` THEN $3 ELSE disabled_reason END,
+				active               = active AND NOT `+trip+`
+			WHERE id = $1`,
+			s.WebhookID, disableAfter, fmt.Sprintf("disabled after %d consecutive failures", disableAfter),
+		)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Release devolve uma entrega reservada que não chegou a ser enviada: ela pode ser tentada
+// de novo na próxima rodada, sem esperar a reserva vencer.
+func (repository *WebhookRepository) Release(ctx context.Context, s domain.WebhookSend) error {
+	_, err := repository.db.Exec(ctx,
+		"UPDATE webhook_deliveries SET next_attempt_at = now() WHERE id = $1 AND claim_token = $2 AND status = 'pending'",
+		s.DeliveryID, s.ClaimToken,
+	)
+	return err
+}
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dya-andrade/cat-api/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+/*
+Histórico de peso (cat_weights) e alertas de variação (cat_weight_alerts).
+
+Quem mantém o histórico é o banco: o trigger record_weight grava uma medição
+sempre que cats.weight_kg muda pelo cadastro, e o trigger check_alerts compara
+cada nova medição com as regras de weight_alert_rules. Aqui só fazemos o
+caminho inverso: ao registrar (ou apagar) uma medição avulsa, cats.weight_kg
+passa a ser a medição mais recente.
+*/
+
+// WeightRepository persiste o histórico de peso dos gatos.
+type WeightRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWeightRepository(db *pgxpool.Pool) *WeightRepository {
+	return &WeightRepository{db: db}
+}
+
+const weightColumns = "id, cat_id, weight_kg, measured_at, source, notes, created_at"
+
+func scanWeight(row pgx.Row) (domain.Weight, error) {
+	var w domain.Weight
+	err := row.Scan(&w.ID, &w.CatID, &w.WeightKG, &w.MeasuredAt, &w.Source, &w.Notes, &w.CreatedAt)
+	return w, err
+}
+
+// syncLatestWeight faz cats.weight_kg ser a medição mais recente (NULL se não houver nenhuma).
+// cat_api.weight_sync avisa o trigger record_weight para não registrar essa troca de novo.
+func syncLatestWeight(ctx context.Context, tx pgx.Tx, catID int64) error {
+	if _, err := tx.Exec(ctx, "SELECT set_config('cat_api.weight_sync', 'on', true)"); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx,
+		`UPDATE cats c SET weight_kg = latest.weight_kg, version = c.version + 1
+		 FROM (SELECT (SELECT weight_kg FROM cat_weights WHERE cat_id = $1 ORDER BY measured_at DESC, id DESC LIMIT 1) AS weight_kg) latest
+		 WHERE c.id = $1 AND c.weight_kg IS DISTINCT FROM latest.weight_kg`,
+		catID,
+	)
+	return err
+}
+
+// Add registra uma medição em at e atualiza cats.weight_kg se ela for a mais recente.
+// O gato fica travado durante a transação, para duas medições simultâneas não se cruzarem.
+func (repository *WeightRepository) Add(ctx context.Context, catID int64, in domain.WeightCreate, at time.Time) (domain.Weight, error) {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return domain.Weight{}, err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	if _, err := lockCatStatus(ctx, tx, catID); err != nil {
+		return domain.Weight{}, err
+	}
+	w, err := scanWeight(tx.QueryRow(ctx,
+		"INSERT INTO cat_weights (cat_id, weight_kg, measured_at, source, notes) VALUES ($1,$2,$3,$4,$5) RETURNING "+weightColumns,
+		catID, in.WeightKG, at, domain.WeightSourceMeasurement, in.Notes,
+	))
+	if err != nil {
+		return domain.Weight{}, err
+	}
+	if err := syncLatestWeight(ctx, tx, catID); err != nil {
+		return domain.Weight{}, err
+	}
+	return w, tx.Commit(ctx)
+}
+
+// Delete apaga uma medição (e os alertas que ela disparou) e recalcula cats.weight_kg.
+func (repository *WeightRepository) Delete(ctx context.Context, catID, id int64) error {
+	tx, err := beginAudited(ctx, repository.db) // altera cats (auditado)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // sem efeito depois do Commit
+
+	if _, err := lockCatStatus(ctx, tx, catID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, "DELETE FROM cat_weights WHERE id=$1 AND cat_id=$2", id, catID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWeightNotFound
+	}
+	if err := syncLatestWeight(ctx, tx, catID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Series lê a série do gato em ordem cronológica, dentro do período [From, To] (datas inclusivas).
+// Com q.Bucket, as medições são agrupadas por dia, semana ou mês (média, mínimo, máximo e quantidade).
+func (repository *WeightRepository) Series(ctx context.Context, catID int64, q domain.WeightQuery) (domain.WeightSeries, error) {
+	const period = "cat_id = $1 AND ($2::date IS NULL OR measured_at >= $2::date) AND ($3::date IS NULL OR measured_at < $3::date + 1)"
+
+	var (
+		items []domain.WeightPoint
+		err   error
+	)
+	if q.Bucket == "" {
+		items, err = listByCat(ctx, repository.db,
+			"SELECT id, measured_at, weight_kg, source, notes FROM cat_weights WHERE "+period+" ORDER BY measured_at, id",
+			func(row pgx.Row) (domain.WeightPoint, error) {
+				var p domain.WeightPoint
+				err := row.Scan(&p.ID, &p.MeasuredAt, &p.WeightKG, &p.Source, &p.Notes)
+				return p, err
+			},
+			catID, q.From, q.To)
+	} else {
+		items, err = listByCat(ctx, repository.db,
+			`SELECT date_trunc($4, measured_at) AS bucket, round(avg(weight_kg), 2), min(weight_kg), max(weight_kg), count(*)
+			 FROM cat_weights WHERE `+period+`
+			 GROUP BY bucket ORDER BY bucket`,
+			func(row pgx.Row) (domain.WeightPoint, error) {
+				var (
+					p       domain.WeightPoint
+					lo, hi  float64
+					samples int
+				)
+				err := row.Scan(&p.MeasuredAt, &p.WeightKG, &lo, &hi, &samples)
+				p.MinKG, p.MaxKG, p.Samples = &lo, &hi, &samples
+				return p, err
+			},
+			catID, q.From, q.To, q.Bucket)
+	}
+	if err != nil {
+		return domain.WeightSeries{}, err
+	}
+	return domain.WeightSeries{CatID: catID, Bucket: q.Bucket, Items: items}, nil
+}
+
+/*** alertas ***/
+
+const weightAlertColumns = "id, cat_id, weight_id, kind, change_pct, threshold_pct, window_days, from_kg, from_at, to_kg, to_at, acknowledged_at, created_at"
+
+func scanWeightAlert(row pgx.Row) (domain.WeightAlert, error) {
+	var a domain.WeightAlert
+	err := row.Scan(&a.ID, &a.CatID, &a.WeightID, &a.Kind, &a.ChangePct, &a.ThresholdPct, &a.WindowDays,
+		&a.FromKG, &a.FromAt, &a.ToKG, &a.ToAt, &a.AcknowledgedAt, &a.CreatedAt)
+	return a, err
+}
+
+// Alerts lista alertas com paginação por cursor (created_at), do mais recente para o mais antigo.
+func (repository *WeightRepository) Alerts(ctx context.Context, f domain.WeightAlertFilter, limit int, cursor *time.Time) ([]domain.WeightAlert, *time.Time, error) {
+	var (
+		args  []any
+		conds []string
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.CatID != nil {
+		add("cat_id = $%d", *f.CatID)
+	}
+	if f.Kind != nil {
+		add("kind = $%d", *f.Kind)
+	}
+	if f.Open {
+		conds = append(conds, "acknowledged_at IS NULL")
+	}
+	if cursor != nil {
+		add("created_at < $%d", *cursor)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := repository.db.Query(ctx,
+		"SELECT "+weightAlertColumns+" FROM cat_weight_alerts"+where+fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.WeightAlert
+	var last *time.Time
+	for rows.Next() {
+		a, err := scanWeightAlert(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, a)
+		last = &a.CreatedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return items, last, nil
+}
+
+// Acknowledge marca o alerta como reconhecido (reconhecer de novo mantém a data original).
+func (repository *WeightRepository) Acknowledge(ctx context.Context, id int64) (domain.WeightAlert, error) {
+	a, err := scanWeightAlert(repository.db.QueryRow(ctx,
+		"UPDATE cat_weight_alerts SET acknowledged_at = COALESCE(acknowledged_at, now()) WHERE id=$1 RETURNING "+weightAlertColumns,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WeightAlert{}, domain.ErrWeightAlertNotFound
+	}
+	return a, err
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Pacote webhook: assinatura das entregas de webhooks.
+
+Cada entrega leva o instante do envio e a assinatura HMAC-SHA256, com o segredo da
+assinatura, de "<timestamp>.<corpo>":
+
+	X-Webhook-Timestamp: 1767225600
+	X-Webhook-Signature: sha256=<HMAC em hexadecimal>
+
+O destino recalcula a assinatura sobre o corpo exatamente como recebido (Verify) e
+recusa timestamps antigos, o que impede reaproveitar uma entrega capturada (replay).
+*/
+
+// Headers de cada entrega.
+const (
+	HeaderID        = "X-Webhook-ID"        // id da entrega (igual nas novas tentativas)
+	HeaderEvent     = "X-Webhook-Event"     // tipo do evento
+	HeaderTimestamp = "X-Webhook-Timestamp" // segundos desde 1970 (UTC)
+	HeaderSignature = "X-Webhook-Signature" // "sha256=" + HMAC em hexadecimal
+)
+
+const signaturePrefix = "sha256="
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrTimestampSkew    = errors.New("webhook timestamp outside tolerance")
+)
+
+// Sign devolve o valor do header de assinatura para o corpo enviado no instante ts.
+func Sign(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify confere a assinatura de uma entrega recebida (valores dos headers) e se o timestamp
+// está a até tolerance de now. Lado do destino; usado também para testar as entregas.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	ts := time.Unix(sec, 0)
+	if d := now.Sub(ts); d > tolerance || d < -tolerance {
+		return ErrTimestampSkew
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewSecret gera um segredo aleatório para uma assinatura nova.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
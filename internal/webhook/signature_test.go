@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	const secret = "whsec_test_secret"
+	body := []byte(`{"id":1,"type":"cat.created"}`)
+	now := time.Unix(1767225600, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sig := Sign(secret, now, body)
+
+	if !strings.HasPrefix(sig, "sha256=") || len(sig) != len("sha256=")+64 {
+		t.Fatalf("signature = %q", sig)
+	}
+	if Sign(secret, now, body) != sig {
+		t.Fatal("Sign is not deterministic")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		want      error
+	}{
+		{"válida", secret, ts, sig, body, now, nil},
+		{"dentro da tolerância", secret, ts, sig, body, now.Add(4 * time.Minute), nil},
+		{"relógio do destino atrasado", secret, ts, sig, body, now.Add(-4 * time.Minute), nil},
+		{"timestamp antigo (replay)", secret, ts, sig, body, now.Add(6 * time.Minute), ErrTimestampSkew},
+		{"timestamp no futuro", secret, ts, sig, body, now.Add(-6 * time.Minute), ErrTimestampSkew},
+		{"corpo alterado", secret, ts, sig, []byte(`{"id":2,"type":"cat.created"}`), now, ErrInvalidSignature},
+		{"segredo errado", "outro", ts, sig, body, now, ErrInvalidSignature},
+		{"timestamp trocado", secret, strconv.FormatInt(now.Unix()+1, 10), sig, body, now, ErrInvalidSignature},
+		{"timestamp inválido", secret, "ontem", sig, body, now, ErrInvalidSignature},
+		{"sem prefixo", secret, ts, strings.TrimPrefix(sig, "sha256="), body, now, ErrInvalidSignature},
+		{"assinatura vazia", secret, ts, "", body, now, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, 5*time.Minute, tt.now)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewSecret()
+	if !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+64 || a == b {
+		t.Errorf("secrets %q, %q", a, b)
+	}
+}
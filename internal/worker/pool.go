@@ -21,10 +21,15 @@ func NewPool(concurrency int) *Pool {
 	if concurrency <= 0 {
 		concurrency = 1
 	}
+	return NewPoolQueue(concurrency, concurrency*4)
+}
 
+// NewPoolQueue cria um pool com uma fila de queue tarefas (ex: muitas tarefas pequenas
+// enviadas de uma vez com TrySubmit). Valores <= 0 viram 1.
+func NewPoolQueue(concurrency, queue int) *Pool {
 	return &Pool{
-		concurrency: concurrency,
-		jobs:        make(chan job, concurrency*4),
+		concurrency: max(concurrency, 1),
+		jobs:        make(chan job, max(queue, 1)),
 	}
 }
 
@@ -61,6 +66,18 @@ func (p *Pool) Submit(fn func() error) error {
 	}
 }
 
+// TrySubmit envia uma tarefa sem bloquear: retorna false (e descarta a tarefa) se a fila estiver cheia.
+// Para tarefas que não podem segurar quem as envia, como uma requisição HTTP já respondida no banco
+// ou outra tarefa do próprio pool (que travaria esperando uma vaga na fila que ela mesma ocupa).
+func (p *Pool) TrySubmit(fn func() error) bool {
+	select {
+	case p.jobs <- fn:
+		return true
+	default:
+		return false
+	}
+}
+
 // Shutdown encerra o pool de workers.
 // Garante que só será chamado uma vez.
 // Fecha o canal de jobs e espera todos os workers terminarem.
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrySubmitFullQueue(t *testing.T) {
+	p := NewPoolQueue(1, 2)
+	release := make(chan struct{})
+	p.Start()
+	defer p.Shutdown()
+
+	started := make(chan struct{})
+	if !p.TrySubmit(func() error { close(started); <-release; return nil }) {
+		t.Fatal("first task rejected")
+	}
+	<-started // o único worker está ocupado; a fila tem 2 vagas
+	for i := range 2 {
+		if !p.TrySubmit(func() error { return nil }) {
+			t.Fatalf("task %d rejected with room in the queue", i)
+		}
+	}
+	if p.TrySubmit(func() error { return nil }) {
+		t.Fatal("task accepted with the queue full")
+	}
+	close(release)
+}
+
+// Uma tarefa que agenda outra no próprio pool não pode travar quando a fila está cheia.
+func TestTrySubmitFromTaskDoesNotDeadlock(t *testing.T) {
+	p := NewPoolQueue(1, 1)
+	p.Start()
+	defer p.Shutdown()
+
+	var rejected atomic.Bool
+	done := make(chan struct{})
+	_ = p.Submit(func() error {
+		_ = p.TrySubmit(func() error { return nil }) // ocupa a única vaga
+		rejected.Store(!p.TrySubmit(func() error { return nil }))
+		close(done)
+		return nil
+	})
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("task blocked submitting to its own pool")
+	}
+	if !rejected.Load() {
+		t.Error("expected the second nested task to be rejected")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	wait := Every(ctx, 10*time.Millisecond, func(context.Context) { runs.Add(1) })
+	time.Sleep(55 * time.Millisecond)
+	cancel()
+	wait()
+	if n := runs.Load(); n < 2 {
+		t.Errorf("runs = %d, want at least 2", n)
+	}
+
+	// interval <= 0 desliga o agendamento
+	wait = Every(context.Background(), 0, func(context.Context) { t.Error("should not run") })
+	wait()
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Every executa fn imediatamente e depois a cada interval, até ctx ser cancelado.
+// As execuções não se sobrepõem: se fn demorar mais que interval, o próximo tick é descartado.
+// Retorna uma função que espera o agendador terminar (use depois de cancelar ctx
+// e antes de Pool.Shutdown, para não submeter tarefas a um pool já fechado).
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) (wait func()) {
+	var wg sync.WaitGroup
+	if interval <= 0 {
+		return wg.Wait // agendamento desligado
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return wg.Wait
+}